@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// NewAdoptCmd creates the adopt command
+func NewAdoptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "adopt [TOOLS...]",
+		Short: "Record tools already on this machine in the manifest",
+		Long: `Look for catalog tools that are installed but not tracked in the manifest, on
+PATH and in the cargo, go, pipx and npm install locations, and record them.
+
+For each tool the version is probed and the install method guessed from where
+the binary lives: cargo_install, go_install, pipx, npm_global, system_package
+(with the package owning the binary), source_build for INSTALL_PREFIX/bin, and
+manual_download otherwise.
+
+A tool adopted as managed is treated like a tool gearbox installed: uninstall
+removes it and drift detection checks its binary. A pre-existing tool is only
+recorded, and uninstall never removes it. By default tools from the system
+package manager and manual downloads are pre-existing, and the others managed.`,
+		Example: `  gearbox adopt                       # Ask for each tool found
+  gearbox adopt --dry-run             # List the tools found
+  gearbox adopt --all                 # Adopt everything found
+  gearbox adopt --all --as pre-existing
+  gearbox adopt fd ripgrep            # Only look for these tools`,
+		ValidArgsFunction: completeNames(toolNames),
+		RunE:              runAdopt,
+	}
+
+	cmd.Flags().Bool("all", false, "Adopt every tool found without asking")
+	cmd.Flags().String("as", "auto", "Adopt tools as managed, pre-existing or auto (by install method)")
+	cmd.Flags().Bool("dry-run", false, "Show the tools found without adopting them")
+	registerValues(cmd, "as", "auto", "managed", "pre-existing")
+	return cmd
+}
+
+func runAdopt(cmd *cobra.Command, args []string) error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	orchestratorPath := filepath.Join(filepath.Dir(execPath), "orchestrator")
+	if _, err := os.Stat(orchestratorPath); err != nil {
+		return fmt.Errorf("orchestrator not found. Please run 'make build' to compile all components")
+	}
+
+	as, _ := cmd.Flags().GetString("as")
+	adoptArgs := append([]string{"adopt", "--as", as}, args...)
+	for _, name := range []string{"all", "dry-run"} {
+		if value, _ := cmd.Flags().GetBool(name); value {
+			adoptArgs = append(adoptArgs, "--"+name)
+		}
+	}
+
+	orchestratorCmd := exec.Command(orchestratorPath, adoptArgs...)
+	orchestratorCmd.Stdout = os.Stdout
+	orchestratorCmd.Stderr = os.Stderr
+	orchestratorCmd.Stdin = os.Stdin
+	return orchestratorCmd.Run()
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCacheCmd creates the cache command
+func NewCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the shared source cache",
+		Long: `Installations keep bare clones of the tool repositories in a shared cache below
+CACHE_DIR (default ~/tools/cache, set with 'gearbox config set CACHE_DIR <dir>').
+Reinstalls and build type switches fetch only new commits, and installation
+scripts clone from the cache instead of the network.
+
+Use 'gearbox install --no-cache' to bypass the cache for one installation.`,
+		Example: `  gearbox cache status             # Show cached repositories and their size
+  gearbox cache prune              # Remove repositories unused for 30 days
+  gearbox cache prune --days 7 --dry-run
+  gearbox cache prune --all        # Empty the cache`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show the cached repositories and their size",
+		RunE:  runCacheStatus,
+	})
+
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove unused repositories from the source cache",
+		Long: `Remove repositories that have not been used for a number of days or that no
+tool uses anymore.`,
+		RunE: runCachePrune,
+	}
+	pruneCmd.Flags().Int("days", 0, "Remove repositories unused for this many days (default 30)")
+	pruneCmd.Flags().Bool("all", false, "Remove every cached repository")
+	pruneCmd.Flags().Bool("dry-run", false, "Show what would be removed")
+	cmd.AddCommand(pruneCmd)
+
+	return cmd
+}
+
+func runCacheStatus(cmd *cobra.Command, args []string) error {
+	return runOrchestratorCache("status")
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	var pruneArgs []string
+	if days, _ := cmd.Flags().GetInt("days"); days > 0 {
+		pruneArgs = append(pruneArgs, "--days", strconv.Itoa(days))
+	}
+	for _, name := range []string{"all", "dry-run"} {
+		if value, _ := cmd.Flags().GetBool(name); value {
+			pruneArgs = append(pruneArgs, "--"+name)
+		}
+	}
+	return runOrchestratorCache("prune", pruneArgs...)
+}
+
+// runOrchestratorCache runs a cache subcommand of the orchestrator
+func runOrchestratorCache(subcommand string, args ...string) error {
+	// Get the directory where the gearbox binary is located
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	repoDir := filepath.Dir(execPath)
+	orchestratorPath := filepath.Join(repoDir, "orchestrator")
+
+	// Check if the orchestrator is available
+	if _, err := os.Stat(orchestratorPath); err != nil {
+		return fmt.Errorf("orchestrator not found. Please run 'make build' to compile all components")
+	}
+
+	orchestratorCmd := exec.Command(orchestratorPath, append([]string{"cache", subcommand}, args...)...)
+	orchestratorCmd.Stdout = os.Stdout
+	orchestratorCmd.Stderr = os.Stderr
+
+	return orchestratorCmd.Run()
+}
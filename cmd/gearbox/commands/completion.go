@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+	"gearbox/pkg/manifest"
+	"gearbox/pkg/orchestrator"
+)
+
+// NewCompletionCmd creates the completion command
+func NewCompletionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion bash|zsh|fish",
+		Short: "Generate shell completion scripts",
+		Long: `Generate a completion script for bash, zsh or fish.
+
+Completion reads the tool and bundle catalog when you press TAB, so new tools
+and bundles complete without regenerating the script. 'uninstall' and
+'plan uninstall' complete the tools recorded in the manifest.`,
+		Example: `  source <(gearbox completion bash)                     # Current bash session
+  gearbox completion bash > /etc/bash_completion.d/gearbox
+  gearbox completion zsh > "${fpath[1]}/_gearbox"       # zsh, then start a new shell
+  gearbox completion fish > ~/.config/fish/completions/gearbox.fish`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch args[0] {
+			case "bash":
+				return cmd.Root().GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				return cmd.Root().GenZshCompletion(os.Stdout)
+			case "fish":
+				return cmd.Root().GenFishCompletion(os.Stdout, true)
+			default:
+				return fmt.Errorf("unsupported shell %q (use bash, zsh or fish)", args[0])
+			}
+		},
+	}
+
+	return cmd
+}
+
+// NewManCmd creates the man command
+func NewManCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "man <DIR>",
+		Short: "Generate man pages",
+		Long: `Generate a man page for every gearbox command into a directory, such as
+gearbox.1 and gearbox-install.1.`,
+		Example: `  gearbox man build/man                      # Generate the pages
+  man build/man/gearbox-install.1            # Read one`,
+		Args:   cobra.ExactArgs(1),
+		Hidden: true, // Used by 'make man'
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll(args[0], 0755); err != nil {
+				return fmt.Errorf("failed to create man directory: %w", err)
+			}
+			header := &doc.GenManHeader{Title: "GEARBOX", Section: "1", Source: "Gearbox " + cmd.Root().Version}
+			cmd.Root().DisableAutoGenTag = true
+			if err := doc.GenManTree(cmd.Root(), header, args[0]); err != nil {
+				return fmt.Errorf("failed to generate man pages: %w", err)
+			}
+			fmt.Printf("📖 Man pages written to %s\n", args[0])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// loadCatalog loads the tool and bundle configuration for completion
+func loadCatalog() (*orchestrator.Orchestrator, error) {
+	return orchestrator.NewOrchestratorBuilder(orchestrator.InstallationOptions{}).
+		WithReporter(orchestrator.ReporterFunc(func(orchestrator.Event) {})).
+		Build()
+}
+
+// toolNames returns the names of the configured tools
+func toolNames() []string {
+	o, err := loadCatalog()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, tool := range o.ToolList("").Tools {
+		names = append(names, fmt.Sprintf("%s\t%s", tool.Name, tool.Description))
+	}
+	return names
+}
+
+// bundleNames returns the names of the configured bundles
+func bundleNames() []string {
+	o, err := loadCatalog()
+	if err != nil {
+		return nil
+	}
+	list, err := o.BundleList()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, bundle := range list.Bundles {
+		names = append(names, fmt.Sprintf("%s\t%s", bundle.Name, bundle.Description))
+	}
+	return names
+}
+
+// installedToolNames returns the tools gearbox installed, leaving out the
+// records of bundles and of tools that were installed before gearbox
+func installedToolNames() []string {
+	m, err := manifest.NewManager().Load()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for name, record := range m.Installations {
+		if record.Method != manifest.MethodBundle && record.Method != manifest.MethodPreExisting {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// categoryNames returns the categories of the configured tools
+func categoryNames() []string {
+	o, err := loadCatalog()
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, tool := range o.ToolList("").Tools {
+		if tool.Category != "" && !seen[tool.Category] {
+			seen[tool.Category] = true
+			names = append(names, tool.Category)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// matching returns the completions that start with prefix, leaving out the
+// arguments already given
+func matching(completions, args []string, prefix string) []string {
+	given := make(map[string]bool)
+	for _, arg := range args {
+		given[arg] = true
+	}
+	var remaining []string
+	for _, completion := range completions {
+		name, _, _ := strings.Cut(completion, "\t")
+		if !given[name] && strings.HasPrefix(name, prefix) {
+			remaining = append(remaining, completion)
+		}
+	}
+	return remaining
+}
+
+// completeNames completes any number of names from a source, skipping the
+// names already given
+func completeNames(source func() []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return matching(source(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeName completes a single name from a source
+func completeName(source func() []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return matching(source(), nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeAfter completes a fixed first argument, such as "bundle" in
+// 'show bundle <name>', and then names from a source
+func completeAfter(first string, source func() []string, many bool) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch {
+		case len(args) == 0:
+			return []string{first}, cobra.ShellCompDirectiveNoFileComp
+		case args[0] != first, !many && len(args) > 1:
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		default:
+			return matching(source(), args[1:], toComplete), cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+}
+
+// toolsAndBundles returns the configured tools and bundles
+func toolsAndBundles() []string {
+	return append(toolNames(), bundleNames()...)
+}
+
+// registerValues completes the allowed values of a flag
+func registerValues(cmd *cobra.Command, flag string, values ...string) {
+	cmd.RegisterFlagCompletionFunc(flag, cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"gearbox/pkg/manifest"
+
+	"github.com/spf13/cobra"
+)
+
+func staticNames() []string {
+	return []string{"fd\tFast file finder", "fzf\tFuzzy finder", "ripgrep\tFast grep"}
+}
+
+func TestCompleteNamesSkipsGivenArguments(t *testing.T) {
+	complete := completeNames(staticNames)
+
+	got, directive := complete(&cobra.Command{}, []string{"fd"}, "f")
+	if strings.Join(got, ",") != "fzf\tFuzzy finder" {
+		t.Errorf("expected only fzf, got %q", got)
+	}
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("expected no file completion, got %v", directive)
+	}
+}
+
+func TestCompleteAfterFixedArgument(t *testing.T) {
+	complete := completeAfter("bundle", staticNames, false)
+
+	if got, _ := complete(&cobra.Command{}, nil, ""); strings.Join(got, ",") != "bundle" {
+		t.Errorf("expected the fixed argument first, got %q", got)
+	}
+	if got, _ := complete(&cobra.Command{}, []string{"bundle"}, "r"); strings.Join(got, ",") != "ripgrep\tFast grep" {
+		t.Errorf("expected names after the fixed argument, got %q", got)
+	}
+	if got, _ := complete(&cobra.Command{}, []string{"bundle", "fd"}, ""); len(got) != 0 {
+		t.Errorf("expected a single name, got %q", got)
+	}
+	if got, _ := complete(&cobra.Command{}, []string{"tool"}, ""); len(got) != 0 {
+		t.Errorf("expected nothing after an unknown argument, got %q", got)
+	}
+}
+
+func TestFlagValuesComplete(t *testing.T) {
+	cmd := NewUninstallCmd()
+	complete, found := cmd.GetFlagCompletionFunc("safety")
+	if !found {
+		t.Fatalf("expected completion for --safety")
+	}
+	got, _ := complete(cmd, nil, "")
+	if strings.Join(got, ",") != "conservative,standard,aggressive" {
+		t.Errorf("unexpected safety levels %q", got)
+	}
+}
+
+func TestInstalledToolNamesSkipBundlesAndPreExistingTools(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := manifest.NewManifest()
+	m.Installations["fd"] = &manifest.InstallationRecord{Method: manifest.MethodSourceBuild}
+	m.Installations["minimal_bundle"] = &manifest.InstallationRecord{Method: manifest.MethodBundle}
+	m.Installations["git"] = &manifest.InstallationRecord{Method: manifest.MethodPreExisting}
+	if err := manifest.NewManager().Save(m); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := installedToolNames(); strings.Join(got, ",") != "fd" {
+		t.Errorf("expected only fd, got %q", got)
+	}
+}
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gearbox/pkg/daemon"
+	"gearbox/pkg/logger"
+	"gearbox/pkg/orchestrator"
+)
+
+// NewServeCmd creates the serve command
+func NewServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the gearbox daemon",
+		Long: `Run the gearbox daemon on a unix socket.
+
+While the daemon runs, 'gearbox install', 'gearbox uninstall' and the TUI
+hand their work to it. Jobs run one at a time, so package managers and the
+manifest are never used by two installations at once, and any client can
+watch or cancel any job with 'gearbox jobs'.
+
+The socket is ~/.gearbox/gearbox.sock, or $GEARBOX_SOCKET when set.`,
+		Example: `  gearbox serve &                            # Start the daemon
+  gearbox install fd                         # Runs as a daemon job
+  gearbox jobs                               # List jobs
+  gearbox jobs watch 1                       # Follow a job from another terminal`,
+		RunE: runServe,
+	}
+
+	cmd.Flags().String("socket", "", "Socket to listen on (default: $GEARBOX_SOCKET or ~/.gearbox/gearbox.sock)")
+	return cmd
+}
+
+// NewJobsCmd creates the jobs command
+func NewJobsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "List, watch and cancel daemon jobs",
+		Long: `List the installations and removals of the gearbox daemon, follow their
+progress or cancel them. Requires a running 'gearbox serve'.`,
+		Example: `  gearbox jobs                               # List jobs
+  gearbox jobs watch 3                       # Follow job 3
+  gearbox jobs cancel 3                      # Cancel job 3`,
+		Args: cobra.NoArgs,
+		RunE: runJobsList,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "watch JOB",
+		Short: "Follow the progress of a job",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runJobsWatch,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "cancel JOB",
+		Short: "Cancel a queued or running job",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runJobsCancel,
+	})
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	orchestratorPath := filepath.Join(filepath.Dir(execPath), "orchestrator")
+	if _, err := os.Stat(orchestratorPath); err != nil {
+		return fmt.Errorf("orchestrator not found. Please run 'make build' to compile all components")
+	}
+
+	socket, _ := cmd.Flags().GetString("socket")
+	if socket == "" {
+		socket = daemon.SocketPath()
+	}
+	listener, err := daemon.Listen(socket)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(socket)
+
+	server := daemon.NewServer(orchestratorPath)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+
+	fmt.Printf("🔧 Gearbox daemon listening on %s\n", socket)
+	return server.Serve(listener)
+}
+
+func runJobsList(cmd *cobra.Command, args []string) error {
+	client, err := daemon.Dial()
+	if err != nil {
+		return fmt.Errorf("%w (start it with 'gearbox serve')", err)
+	}
+	jobs, err := client.Jobs()
+	if err != nil {
+		return err
+	}
+
+	if len(jobs) == 0 {
+		fmt.Println("No jobs")
+		return nil
+	}
+	fmt.Printf("%-5s %-10s %-10s %-9s %s\n", "ID", "ACTION", "STATE", "CREATED", "TOOLS")
+	for _, job := range jobs {
+		fmt.Printf("%-5s %-10s %-10s %-9s %s\n", job.ID, job.Action, job.State, job.Created.Format("15:04:05"), strings.Join(job.Tools, " "))
+		if job.Error != "" {
+			fmt.Printf("      %s\n", job.Error)
+		}
+	}
+	return nil
+}
+
+func runJobsWatch(cmd *cobra.Command, args []string) error {
+	client, err := daemon.Dial()
+	if err != nil {
+		return fmt.Errorf("%w (start it with 'gearbox serve')", err)
+	}
+	verbose, _ := cmd.Root().PersistentFlags().GetBool("verbose")
+	return client.Wait(args[0], installRenderer(false, verbose))
+}
+
+func runJobsCancel(cmd *cobra.Command, args []string) error {
+	client, err := daemon.Dial()
+	if err != nil {
+		return fmt.Errorf("%w (start it with 'gearbox serve')", err)
+	}
+	job, err := client.Cancel(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🛑 Job %s %s\n", job.ID, job.State)
+	return nil
+}
+
+// dialDaemon returns a client of the running daemon, or nil when commands
+// should run on their own
+func dialDaemon() *daemon.Client {
+	client, err := daemon.Dial()
+	if err != nil {
+		logger.GetGlobalLogger().Debugf("Not using the daemon: %v", err)
+		return nil
+	}
+	return client
+}
+
+// busyToolArgs returns the orchestrator arguments naming the tools with a
+// queued or running daemon job, so that cleanups keep their checkouts
+func busyToolArgs() []string {
+	client := dialDaemon()
+	if client == nil {
+		return nil
+	}
+	jobs, err := client.Jobs()
+	if err != nil {
+		logger.GetGlobalLogger().Debugf("Failed to list daemon jobs: %v", err)
+		return nil
+	}
+
+	var tools []string
+	for _, job := range jobs {
+		if !job.State.Done() {
+			tools = append(tools, job.Tools...)
+		}
+	}
+	if len(tools) == 0 {
+		return nil
+	}
+	return []string{"--busy-tools", strings.Join(tools, ",")}
+}
+
+// installRenderer renders the events of a job like a local installation:
+// as text, or as newline-delimited JSON for --events
+func installRenderer(events, verbose bool) func(orchestrator.Event) {
+	if events {
+		reporter := orchestrator.NewJSONReporter(os.Stdout)
+		return reporter.Report
+	}
+	reporter := orchestrator.NewTextReporter(os.Stdout, verbose)
+	return func(event orchestrator.Event) {
+		if event.Type == orchestrator.EventOutput && event.Tool == "" {
+			// Text of the orchestrator itself, such as package manager output
+			fmt.Println(event.Message)
+			return
+		}
+		reporter.Report(event)
+	}
+}
+
+// runDaemonJob submits a job and follows it until it finishes. Interrupting
+// the command cancels the job.
+func runDaemonJob(client *daemon.Client, req daemon.JobRequest, render func(orchestrator.Event)) error {
+	job, err := client.Submit(req)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "📡 Submitted job %s to the gearbox daemon (gearbox jobs watch %s)\n", job.ID, job.ID)
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	defer close(done)
+	go func() {
+		select {
+		case <-signals:
+			fmt.Fprintf(os.Stderr, "\n🛑 Cancelling job %s...\n", job.ID)
+			client.Cancel(job.ID)
+		case <-done:
+		}
+	}()
+
+	return client.Wait(job.ID, render)
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"gearbox/pkg/fsutil"
 )
 
 // NewDoctorCmd creates the doctor command
@@ -32,17 +33,29 @@ Tool-specific diagnostics:
 - nerd-fonts: Font installation, cache status, and availability checks
 - zoxide: Database status, shell integration, and performance checks
 
+--fix offers a fix for each problem that has one, such as refreshing the font
+cache, loading shell-init for missing PATH entries, reinstalling tools whose
+binaries are gone, installing the Rust and Go toolchains or removing stale
+build directories. Every fix says what it will do and asks first unless
+--yes is given, then the check runs again.
+
 Examples:
   gearbox doctor                    # General system health check
+  gearbox doctor --fix              # Fix the problems found, asking first
+  gearbox doctor --fix --yes        # Fix the problems found without asking
   gearbox doctor nerd-fonts         # Nerd Fonts specific diagnostics
   gearbox doctor zoxide             # Zoxide navigation tool diagnostics  
   gearbox doctor zoxide --verbose   # Detailed zoxide analysis with database contents`,
-		RunE: runDoctor,
+		RunE:              runDoctor,
+		ValidArgsFunction: completeName(toolNames),
 	}
 
 	cmd.Flags().String("check", "", "Run specific check (system, tools, env, config)")
-	cmd.Flags().Bool("fix", false, "Attempt to fix detected issues automatically")
+	registerValues(cmd, "check", "system", "tools", "env", "config")
+	cmd.Flags().Bool("fix", false, "Offer to fix the detected problems, then check again")
+	cmd.Flags().BoolP("yes", "y", false, "Apply fixes without asking")
 	cmd.Flags().Bool("verbose", false, "Show detailed diagnostic output")
+	addReportFlag(cmd)
 	
 	// Add cleanup subcommand
 	cmd.AddCommand(NewDoctorCleanupCmd())
@@ -72,6 +85,7 @@ Examples:
 	}
 
 	cmd.Flags().String("mode", "standard", "Cleanup mode (minimal, standard, aggressive)")
+	registerValues(cmd, "mode", "minimal", "standard", "aggressive")
 	cmd.Flags().Bool("all", false, "Clean artifacts for all tools")
 	cmd.Flags().Bool("dry-run", false, "Show what would be cleaned without doing it")
 	cmd.Flags().Bool("auto-cleanup", false, "Enable automatic cleanup after future installs")
@@ -89,8 +103,21 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	
 	repoDir := filepath.Dir(execPath)
 	
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	
+	reports := reportArgs(cmd)
+	
 	// Check if tool-specific diagnostics are requested
 	if len(args) > 0 {
+		if format.Structured() {
+			return fmt.Errorf("tool-specific diagnostics only support table output")
+		}
+		if len(reports) > 0 {
+			return fmt.Errorf("tool-specific diagnostics do not support --report")
+		}
 		toolName := args[0]
 		return runToolSpecificDoctor(repoDir, toolName, cmd)
 	}
@@ -100,6 +127,13 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	if _, err := os.Stat(orchestratorPath); err == nil {
 		return runWithOrchestratorDoctor(orchestratorPath, cmd, args)
 	}
+	
+	if format.Structured() {
+		return fmt.Errorf("--output %s requires the orchestrator. Please run 'make build' to compile all components", format)
+	}
+	if len(reports) > 0 {
+		return fmt.Errorf("--report requires the orchestrator. Please run 'make build' to compile all components")
+	}
 
 	// Fallback to shell-based doctor if available for general checks
 	doctorScript := filepath.Join(repoDir, "lib", "doctor.sh")
@@ -121,9 +155,16 @@ func runWithOrchestratorDoctor(orchestratorPath string, cmd *cobra.Command, args
 	if fix, _ := cmd.Flags().GetBool("fix"); fix {
 		doctorCmd.Args = append(doctorCmd.Args, "--fix")
 	}
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		doctorCmd.Args = append(doctorCmd.Args, "--yes")
+	}
 	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
 		doctorCmd.Args = append(doctorCmd.Args, "--verbose")
 	}
+	format, _ := outputFormat(cmd)
+	doctorCmd.Args = append(doctorCmd.Args, outputArgs(format)...)
+	doctorCmd.Args = append(doctorCmd.Args, reportArgs(cmd)...)
+	doctorCmd.Args = append(doctorCmd.Args, busyToolArgs()...)
 
 	doctorCmd.Stdout = os.Stdout
 	doctorCmd.Stderr = os.Stderr
@@ -740,7 +781,7 @@ func runNerdFontsDoctor(repoDir string, cmd *cobra.Command) error {
 		
 		if verbose {
 			// Show directory size and file count
-			if size := getDirSize(fontsDir); size > 0 {
+			if size := fsutil.DirSize(fontsDir); size > 0 {
 				fmt.Printf("   Size: %s\n", humanReadableSize(size))
 			}
 			if count := countFontFiles(fontsDir); count > 0 {
@@ -881,18 +922,6 @@ func checkFontAvailable(fontName string) bool {
 	return strings.Contains(outputLower, fontLower) && strings.Contains(outputLower, "nerd")
 }
 
-func getDirSize(dirPath string) int64 {
-	cmd := exec.Command("du", "-sb", dirPath)
-	output, err := cmd.Output()
-	if err != nil {
-		return 0
-	}
-	
-	var size int64
-	fmt.Sscanf(string(output), "%d", &size)
-	return size
-}
-
 func countFontFiles(dirPath string) int {
 	cmd := exec.Command("find", dirPath, "-name", "*.ttf", "-o", "-name", "*.otf")
 	output, err := cmd.Output()
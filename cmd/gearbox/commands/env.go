@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"gearbox/pkg/manifest"
+	"gearbox/pkg/shellinit"
+)
+
+// NewEnvCmd creates the env command
+func NewEnvCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print the PATH and environment of installed tools",
+		Long: `Print the shell code that puts every install location recorded in the
+manifest on PATH, such as ~/.cargo/bin or ~/go/bin, and sets defaults for the
+variables tools need, such as FZF_DEFAULT_OPTS. Entries already on PATH are not
+added twice, and variables already set are kept.
+
+gearbox shell-init includes this setup unless AUTO_UPDATE_PATH is false in
+~/.gearboxrc. --check reports installed tools the current PATH does not reach.`,
+		Example: `  eval "$(gearbox env)"               # Set up the current shell
+  gearbox env --shell fish | source   # Set up a fish shell
+  gearbox env --check                 # Find tools that are not on PATH`,
+		Args: cobra.NoArgs,
+		RunE: runEnv,
+	}
+
+	cmd.Flags().String("shell", "", "Shell to print the setup for: bash, zsh or fish (default: from $SHELL)")
+	cmd.Flags().Bool("check", false, "Report installed tools whose binaries are not reachable on PATH")
+	cmd.RegisterFlagCompletionFunc("shell", cobra.FixedCompletions(shellinit.Shells, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func runEnv(cmd *cobra.Command, args []string) error {
+	m, err := loadManifestIfExists()
+	if err != nil {
+		return err
+	}
+
+	if check, _ := cmd.Flags().GetBool("check"); check {
+		// Problems found are not usage errors
+		cmd.SilenceUsage = true
+		return runEnvCheck(m)
+	}
+
+	shell, _ := cmd.Flags().GetString("shell")
+	if shell == "" {
+		shell = shellinit.DetectShell()
+	}
+	env := shellinit.ManifestEnvironment(m)
+	if home, err := os.UserHomeDir(); err == nil {
+		env = env.WithToolchains(home)
+	}
+	script, err := shellinit.EnvScript(shell, env)
+	if err != nil {
+		return err
+	}
+	fmt.Print(script)
+	return nil
+}
+
+func runEnvCheck(m *manifest.InstallationManifest) error {
+	problems, checked := shellinit.CheckPath(m, exec.LookPath)
+	if checked == 0 {
+		fmt.Println("No installed tools recorded in the manifest")
+		return nil
+	}
+
+	for _, problem := range problems {
+		icon := "❌"
+		if problem.Kind == manifest.DriftShadowed {
+			icon = "⚠️ "
+		}
+		fmt.Printf("%s %s: %s\n", icon, problem.Tool, problem)
+	}
+	if len(problems) == 0 {
+		fmt.Printf("✅ All %d installed tools are reachable on PATH\n", checked)
+		return nil
+	}
+
+	fmt.Printf("\n💡 Run 'eval \"$(gearbox env)\"' or load gearbox shell-init in your shell's rc file\n")
+	return fmt.Errorf("%d binaries are not reachable on PATH", len(problems))
+}
+
+// loadManifestIfExists loads the manifest without creating it, for commands
+// that run at every shell start
+func loadManifestIfExists() (*manifest.InstallationManifest, error) {
+	manager := manifest.NewManager()
+	if !manager.Exists() {
+		return manifest.NewManifest(), nil
+	}
+	return manager.Load()
+}
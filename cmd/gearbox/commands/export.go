@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// NewExportCmd creates the export command
+func NewExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [TOOLS/BUNDLES...]",
+		Short: "Export the installed set, or an environment as a Dockerfile, script or devcontainer feature",
+		Long: `Export your environment for another machine, a CI image or a devcontainer.
+
+By default the user-requested tools, bundles, build types and versions recorded
+in the manifest are written as JSON for 'gearbox import'. Pre-existing system
+tools are not included.
+
+The dockerfile, script and devcontainer formats emit a self-contained artifact
+for a set of tools and bundles (or --from-manifest). The artifact contains the
+system packages for the target distribution, the common dependencies and the
+tool installation steps in the same resolved order and with the same build
+types as 'gearbox install'.`,
+		Example: `  gearbox export > my-env.json                  # Capture the installed set
+  gearbox export --format dockerfile --bundle essential -f Dockerfile
+  gearbox export --format script fd ripgrep > setup.sh
+  gearbox export --format devcontainer --from-manifest -f .devcontainer/gearbox
+  gearbox export --format dockerfile --package-manager dnf --bundle python-dev`,
+		RunE: runExport,
+	}
+
+	cmd.Flags().String("format", "json", "Export format (json, dockerfile, script, devcontainer)")
+	cmd.Flags().String("package-manager", "apt", "Target package manager (apt, yum, dnf, pacman, zypper, apk)")
+	cmd.Flags().String("base-image", "", "Base image for Dockerfiles (default depends on package manager)")
+	cmd.Flags().String("source", "", "Git URL to clone gearbox from instead of copying this repository")
+	cmd.Flags().StringP("file", "f", "", "Output file, or directory for devcontainer (default: stdout)")
+	cmd.Flags().Bool("from-manifest", false, "Export the tools and bundles currently recorded in the manifest")
+	cmd.Flags().String("bundle", "", "Export a predefined bundle")
+
+	// Build type flags
+	cmd.Flags().Bool("minimal", false, "Fast builds with essential features")
+	cmd.Flags().Bool("maximum", false, "Full-featured builds with all optimizations")
+	cmd.Flags().Bool("skip-common-deps", false, "Skip common dependency installation")
+	cmd.Flags().Bool("run-tests", false, "Run test suites for validation")
+	cmd.Flags().Bool("no-shell", false, "Skip shell integration setup (fzf, zoxide, etc.)")
+
+	return cmd
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	if bundleName, _ := cmd.Flags().GetString("bundle"); bundleName != "" {
+		args = append(args, bundleName)
+	}
+
+	// Get the directory where the gearbox binary is located
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	repoDir := filepath.Dir(execPath)
+	orchestratorPath := filepath.Join(repoDir, "orchestrator")
+
+	// Check if the orchestrator is available
+	if _, err := os.Stat(orchestratorPath); err != nil {
+		return fmt.Errorf("orchestrator not found. Please run 'make build' to compile all components")
+	}
+
+	orchestratorCmd := exec.Command(orchestratorPath, "export")
+	orchestratorCmd.Args = append(orchestratorCmd.Args, args...)
+
+	for _, name := range []string{"format", "package-manager", "base-image", "source", "file"} {
+		if value, _ := cmd.Flags().GetString(name); value != "" {
+			orchestratorCmd.Args = append(orchestratorCmd.Args, "--"+name, value)
+		}
+	}
+	for _, name := range []string{"from-manifest", "skip-common-deps", "run-tests", "no-shell"} {
+		if value, _ := cmd.Flags().GetBool(name); value {
+			orchestratorCmd.Args = append(orchestratorCmd.Args, "--"+name)
+		}
+	}
+	if minimal, _ := cmd.Flags().GetBool("minimal"); minimal {
+		orchestratorCmd.Args = append(orchestratorCmd.Args, "--build-type", "minimal")
+	}
+	if maximum, _ := cmd.Flags().GetBool("maximum"); maximum {
+		orchestratorCmd.Args = append(orchestratorCmd.Args, "--build-type", "maximum")
+	}
+
+	orchestratorCmd.Stdout = os.Stdout
+	orchestratorCmd.Stderr = os.Stderr
+
+	return orchestratorCmd.Run()
+}
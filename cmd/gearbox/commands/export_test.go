@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExportKeepsGlobalOutputFlag(t *testing.T) {
+	root := &cobra.Command{Use: "gearbox"}
+	root.PersistentFlags().String("output", "table", "Output format")
+	export := NewExportCmd()
+	root.AddCommand(export)
+
+	if err := export.ParseFlags([]string{"--output", "json", "-f", "env.json"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if export.LocalNonPersistentFlags().Lookup("output") != nil {
+		t.Error("export should not shadow the global --output flag")
+	}
+	if file, _ := export.Flags().GetString("file"); file != "env.json" {
+		t.Errorf("expected the export file from -f, got %q", file)
+	}
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// NewGCCmd creates the gc command
+func NewGCCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove orphaned build directories, cached sources, backups and logs",
+		Long: `Find what gearbox left behind that nothing uses anymore, list it with its size
+and remove it after confirmation:
+
+- build-dir:       checkouts in ~/tools/build of tools that are not installed,
+                   such as uninstalled tools and failed builds, or that were
+                   renamed. Checkouts recorded in the manifest, checkouts of
+                   tools with a queued or running daemon job and checkouts of
+                   other projects are kept.
+- source-cache:    repositories in the source cache no configured tool uses
+- cached-build:    stashed builds in CACHE_DIR/builds of tools no longer configured
+- backup:          *.backup-<time> copies of replaced binaries, configuration
+                   files and rc files
+- log:             TUI logs
+- manifest-backup: manifest backups beyond the newest --keep-backups
+
+Orphans that changed within --days days are kept, so builds in progress and
+recent backups survive. --min-size-mb keeps small orphans.
+
+'gearbox doctor cleanup' removes build artifacts of configured tools instead,
+and 'gearbox cache prune' removes cached repositories by age.`,
+		Example: `  gearbox gc --dry-run              # Show the orphans and their size
+  gearbox gc                        # Remove orphans older than 7 days
+  gearbox gc --days 0 --yes         # Remove every orphan without asking
+  gearbox gc --min-size-mb 100      # Only remove large orphans`,
+		Args: cobra.NoArgs,
+		RunE: runGC,
+	}
+
+	cmd.Flags().Int("days", 7, "Keep orphans changed within this many days")
+	cmd.Flags().Int64("min-size-mb", 0, "Keep orphans smaller than this many megabytes")
+	cmd.Flags().Int("keep-backups", 5, "Number of manifest backups to keep")
+	cmd.Flags().Bool("dry-run", false, "Show what would be removed")
+	cmd.Flags().BoolP("yes", "y", false, "Remove without asking")
+	return cmd
+}
+
+func runGC(cmd *cobra.Command, args []string) error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	orchestratorPath := filepath.Join(filepath.Dir(execPath), "orchestrator")
+	if _, err := os.Stat(orchestratorPath); err != nil {
+		return fmt.Errorf("orchestrator not found. Please run 'make build' to compile all components")
+	}
+
+	days, _ := cmd.Flags().GetInt("days")
+	minSize, _ := cmd.Flags().GetInt64("min-size-mb")
+	keepBackups, _ := cmd.Flags().GetInt("keep-backups")
+	gcArgs := []string{"gc",
+		"--days", strconv.Itoa(days),
+		"--min-size-mb", strconv.FormatInt(minSize, 10),
+		"--keep-backups", strconv.Itoa(keepBackups),
+	}
+	for _, name := range []string{"dry-run", "yes"} {
+		if value, _ := cmd.Flags().GetBool(name); value {
+			gcArgs = append(gcArgs, "--"+name)
+		}
+	}
+
+	gcArgs = append(gcArgs, busyToolArgs()...)
+
+	orchestratorCmd := exec.Command(orchestratorPath, gcArgs...)
+	orchestratorCmd.Stdout = os.Stdout
+	orchestratorCmd.Stderr = os.Stderr
+	orchestratorCmd.Stdin = os.Stdin
+	return orchestratorCmd.Run()
+}
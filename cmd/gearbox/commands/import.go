@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// NewImportCmd creates the import command
+func NewImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <environment.json>",
+		Short: "Install the tools and bundles from an exported environment",
+		Long: `Recreate an environment captured with 'gearbox export' on another machine.
+
+The import shows a plan of the bundles and tools to install, grouped by the
+build type they were originally installed with. Entries that are already
+installed or unknown to this version of gearbox are skipped.`,
+		Example: `  gearbox import my-env.json --dry-run  # Show the plan only
+  gearbox import my-env.json            # Install the environment
+  gearbox import my-env.json --force    # Reinstall tools that are present`,
+		Args: cobra.ExactArgs(1),
+		RunE: runImport,
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Show the import plan without installing")
+	cmd.Flags().Bool("force", false, "Reinstall tools that are already installed")
+	cmd.Flags().Bool("skip-common-deps", false, "Skip common dependency installation")
+	cmd.Flags().Bool("run-tests", false, "Run test suites for validation")
+	cmd.Flags().Bool("no-shell", false, "Skip shell integration setup (fzf, zoxide, etc.)")
+	cmd.Flags().IntP("jobs", "j", 0, "Number of parallel jobs (0 = auto-detect)")
+	cmd.Flags().Bool("events", false, "Write the import plan and installation events to stdout as newline-delimited JSON")
+
+	return cmd
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	// Get the directory where the gearbox binary is located
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	repoDir := filepath.Dir(execPath)
+	orchestratorPath := filepath.Join(repoDir, "orchestrator")
+
+	// Check if the orchestrator is available
+	if _, err := os.Stat(orchestratorPath); err != nil {
+		return fmt.Errorf("orchestrator not found. Please run 'make build' to compile all components")
+	}
+
+	orchestratorCmd := exec.Command(orchestratorPath, "import", args[0])
+
+	for _, name := range []string{"dry-run", "force", "skip-common-deps", "run-tests", "no-shell", "events"} {
+		if value, _ := cmd.Flags().GetBool(name); value {
+			orchestratorCmd.Args = append(orchestratorCmd.Args, "--"+name)
+		}
+	}
+	if verbose, _ := cmd.Parent().PersistentFlags().GetBool("verbose"); verbose {
+		orchestratorCmd.Args = append(orchestratorCmd.Args, "--verbose")
+	}
+	if jobs, _ := cmd.Flags().GetInt("jobs"); jobs > 0 {
+		orchestratorCmd.Args = append(orchestratorCmd.Args, "--jobs", strconv.Itoa(jobs))
+	}
+
+	orchestratorCmd.Stdout = os.Stdout
+	orchestratorCmd.Stderr = os.Stderr
+	orchestratorCmd.Stdin = os.Stdin
+
+	return orchestratorCmd.Run()
+}
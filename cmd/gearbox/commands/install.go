@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"gearbox/pkg/daemon"
 	"gearbox/pkg/errors"
 	"gearbox/pkg/logger"
 )
@@ -29,8 +30,12 @@ comprehensive progress tracking.`,
   gearbox install --maximum ffmpeg           # Full-featured build
   gearbox install nerd-fonts --fonts="FiraCode"    # Install specific font
   gearbox install nerd-fonts --interactive   # Interactive font selection
+  gearbox install --mirror /mnt/gearbox fd    # Install from an offline mirror
+  gearbox install --events fd | jq .type     # Follow the installation as JSON events
+  gearbox install --report junit=install.xml fd  # JUnit report for CI
   gearbox install                            # Install all tools (with confirmation)`,
-		RunE: runInstall,
+		RunE:              runInstall,
+		ValidArgsFunction: completeNames(toolsAndBundles),
 	}
 
 	// Build type flags
@@ -43,11 +48,17 @@ comprehensive progress tracking.`,
 	cmd.Flags().Bool("run-tests", false, "Run test suites for validation")
 	cmd.Flags().Bool("no-shell", false, "Skip shell integration setup (fzf, zoxide, etc.)")
 	cmd.Flags().Bool("force", false, "Force reinstallation if already installed")
+	cmd.Flags().Bool("no-config", false, "Do not install default configuration files (bat, starship, lazygit, etc.)")
+	cmd.Flags().Bool("system-upgrade", false, "Upgrade the system to install missing packages with pacman (Arch does not support partial upgrades)")
 
 	// Performance options
 	cmd.Flags().IntP("jobs", "j", 0, "Number of parallel jobs (0 = auto-detect)")
-	cmd.Flags().Bool("no-cache", false, "Disable build cache")
+	cmd.Flags().Bool("no-cache", false, "Disable the build and source cache")
 	cmd.Flags().Bool("dry-run", false, "Show what would be installed without executing")
+	cmd.Flags().Bool("skip-disk-check", false, "Install even when the estimated disk space is not available")
+	cmd.Flags().String("mirror", "", "Install without network access from an offline mirror (default: $GEARBOX_MIRROR)")
+	cmd.Flags().Bool("events", false, "Write installation events to stdout as newline-delimited JSON")
+	addReportFlag(cmd)
 
 	// Nerd-fonts specific options
 	cmd.Flags().String("fonts", "", "Install specific fonts (comma-separated, e.g. 'FiraCode,JetBrainsMono')")
@@ -57,6 +68,7 @@ comprehensive progress tracking.`,
 	
 	// Bundle options
 	cmd.Flags().String("bundle", "", "Install a predefined bundle (e.g. 'essential', 'developer', 'data-science')")
+	cmd.RegisterFlagCompletionFunc("bundle", completeName(bundleNames))
 
 	return cmd
 }
@@ -104,6 +116,22 @@ func runInstall(cmd *cobra.Command, args []string) error {
 func runWithOrchestrator(orchestratorPath string, cmd *cobra.Command, args []string) error {
 	log := logger.GetGlobalLogger().Operation("orchestrator")
 	
+	flags := installFlags(cmd)
+	events, _ := cmd.Flags().GetBool("events")
+	
+	// Hand the installation to a running daemon so that other clients can
+	// watch and cancel it. Dry runs, reports and interactive font selection
+	// stay in this terminal.
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	interactive, _ := cmd.Flags().GetBool("interactive")
+	if !dryRun && !interactive && len(reportArgs(cmd)) == 0 {
+		if client := dialDaemon(); client != nil {
+			log.Debug("Submitting to the gearbox daemon")
+			verbose, _ := cmd.Parent().PersistentFlags().GetBool("verbose")
+			return runDaemonJob(client, daemon.JobRequest{Action: daemon.ActionInstall, Tools: args, Args: flags}, installRenderer(events, verbose))
+		}
+	}
+	
 	log.Debug("Delegating to orchestrator")
 	
 	// Build the orchestrator command
@@ -111,60 +139,84 @@ func runWithOrchestrator(orchestratorPath string, cmd *cobra.Command, args []str
 	
 	// Add tool arguments  
 	orchestratorCmd.Args = append(orchestratorCmd.Args, args...)
+	orchestratorCmd.Args = append(orchestratorCmd.Args, flags...)
+	if dryRun {
+		orchestratorCmd.Args = append(orchestratorCmd.Args, "--dry-run")
+	}
+	if events {
+		orchestratorCmd.Args = append(orchestratorCmd.Args, "--events")
+	}
+	orchestratorCmd.Args = append(orchestratorCmd.Args, reportArgs(cmd)...)
+
+	// Connect stdio
+	orchestratorCmd.Stdout = os.Stdout
+	orchestratorCmd.Stderr = os.Stderr
+	orchestratorCmd.Stdin = os.Stdin
+
+	return orchestratorCmd.Run()
+}
+
+// installFlags converts the flags of the install command to orchestrator
+// arguments, apart from --dry-run, --events and --report
+func installFlags(cmd *cobra.Command) []string {
+	var flags []string
 
 	// Convert flags to orchestrator arguments
 	if minimal, _ := cmd.Flags().GetBool("minimal"); minimal {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--build-type", "minimal")
+		flags = append(flags, "--build-type", "minimal")
 	}
 	if maximum, _ := cmd.Flags().GetBool("maximum"); maximum {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--build-type", "maximum")
+		flags = append(flags, "--build-type", "maximum")
 	}
 	if skipDeps, _ := cmd.Flags().GetBool("skip-common-deps"); skipDeps {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--skip-common-deps")
+		flags = append(flags, "--skip-common-deps")
 	}
 	if runTests, _ := cmd.Flags().GetBool("run-tests"); runTests {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--run-tests")
+		flags = append(flags, "--run-tests")
 	}
 	if noShell, _ := cmd.Flags().GetBool("no-shell"); noShell {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--no-shell")
+		flags = append(flags, "--no-shell")
 	}
 	if force, _ := cmd.Flags().GetBool("force"); force {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--force")
+		flags = append(flags, "--force")
+	}
+	if noConfig, _ := cmd.Flags().GetBool("no-config"); noConfig {
+		flags = append(flags, "--no-config")
+	}
+	if systemUpgrade, _ := cmd.Flags().GetBool("system-upgrade"); systemUpgrade {
+		flags = append(flags, "--system-upgrade")
 	}
 	if jobs, _ := cmd.Flags().GetInt("jobs"); jobs > 0 {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--jobs", fmt.Sprintf("%d", jobs))
+		flags = append(flags, "--jobs", fmt.Sprintf("%d", jobs))
 	}
 	if noCache, _ := cmd.Flags().GetBool("no-cache"); noCache {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--no-cache")
+		flags = append(flags, "--no-cache")
 	}
-	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--dry-run")
+	if skipDiskCheck, _ := cmd.Flags().GetBool("skip-disk-check"); skipDiskCheck {
+		flags = append(flags, "--skip-disk-check")
+	}
+	if mirror, _ := cmd.Flags().GetString("mirror"); mirror != "" {
+		// The daemon runs jobs in its own working directory
+		flags = append(flags, "--mirror", daemon.AbsPath(mirror))
 	}
 
 	// Add nerd-fonts specific flags
 	if fonts, _ := cmd.Flags().GetString("fonts"); fonts != "" {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--fonts", fonts)
+		flags = append(flags, "--fonts", fonts)
 	}
 	if interactive, _ := cmd.Flags().GetBool("interactive"); interactive {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--interactive")
+		flags = append(flags, "--interactive")
 	}
 	if preview, _ := cmd.Flags().GetBool("preview"); preview {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--preview")
+		flags = append(flags, "--preview")
 	}
 	if configureApps, _ := cmd.Flags().GetBool("configure-apps"); configureApps {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--configure-apps")
+		flags = append(flags, "--configure-apps")
 	}
 
 	// Add global flags
 	if verbose, _ := cmd.Parent().PersistentFlags().GetBool("verbose"); verbose {
-		orchestratorCmd.Args = append(orchestratorCmd.Args, "--verbose")
+		flags = append(flags, "--verbose")
 	}
-
-	// Connect stdio
-	orchestratorCmd.Stdout = os.Stdout
-	orchestratorCmd.Stderr = os.Stderr
-	orchestratorCmd.Stdin = os.Stdin
-
-	return orchestratorCmd.Run()
+	return flags
 }
-
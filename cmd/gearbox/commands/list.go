@@ -26,18 +26,25 @@ The list includes:
 - Brief descriptions
 - Language/technology used (for tools)
 - Installation status (if orchestrator is available)`,
-		RunE: runList,
+		RunE:      runList,
+		ValidArgs: []string{"bundles"},
 	}
 
 	cmd.Flags().BoolP("installed", "i", false, "Show only installed tools")
 	cmd.Flags().BoolP("available", "a", false, "Show only available (not installed) tools")
 	cmd.Flags().StringP("category", "c", "", "Filter by category (core, navigation, media, etc.)")
+	cmd.RegisterFlagCompletionFunc("category", completeName(categoryNames))
 	cmd.Flags().BoolP("verbose", "v", false, "Show detailed information")
 
 	return cmd
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Get the directory where the gearbox binary is located
 	execPath, err := os.Executable()
 	if err != nil {
@@ -56,6 +63,7 @@ func runList(cmd *cobra.Command, args []string) error {
 		if len(args) > 0 {
 			cmdArgs = append(cmdArgs, args...)
 		}
+		cmdArgs = append(cmdArgs, outputArgs(format)...)
 		
 		orchestratorCmd := exec.Command(orchestratorPath, cmdArgs...)
 		
@@ -79,6 +87,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		return orchestratorCmd.Run()
 	}
 
+	if format.Structured() {
+		return fmt.Errorf("--output %s requires the orchestrator. Please run 'make build' to compile all components", format)
+	}
+
 	// Fallback to built-in tool list
 	return showBuiltinToolList()
 }
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// NewMirrorCmd creates the mirror command
+func NewMirrorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mirror <DIR> [TOOLS/BUNDLES...]",
+		Short: "Create an offline mirror for air-gapped installs",
+		Long: `Download everything needed to install tools without network access into a
+portable directory: bare git mirrors of the source repositories, their crates
+and Go modules, release assets, font archives and the Rust and Go toolchains.
+
+Without tools or bundles every tool is mirrored. Running the command again on an
+existing mirror updates it. Copy the directory to the air-gapped machine and
+install with 'gearbox install --mirror <DIR>', or set GEARBOX_MIRROR=<DIR>.`,
+		Example: `  gearbox mirror /mnt/gearbox                  # Mirror every tool
+  gearbox mirror /mnt/gearbox --bundle essential
+  gearbox mirror /mnt/gearbox fd ripgrep fzf
+  gearbox install --mirror /mnt/gearbox fd     # On the air-gapped machine`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runMirror,
+	}
+
+	cmd.Flags().String("bundle", "", "Mirror a predefined bundle")
+	cmd.Flags().String("go-version", "", "Go toolchain version (default: the version gearbox installs)")
+	cmd.Flags().String("rust-version", "", "Rust toolchain version (default: stable)")
+	cmd.Flags().Bool("no-releases", false, "Skip prebuilt release assets (used by minimal builds)")
+
+	return cmd
+}
+
+func runMirror(cmd *cobra.Command, args []string) error {
+	if bundleName, _ := cmd.Flags().GetString("bundle"); bundleName != "" {
+		args = append(args, bundleName)
+	}
+
+	// Get the directory where the gearbox binary is located
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	repoDir := filepath.Dir(execPath)
+	orchestratorPath := filepath.Join(repoDir, "orchestrator")
+
+	// Check if the orchestrator is available
+	if _, err := os.Stat(orchestratorPath); err != nil {
+		return fmt.Errorf("orchestrator not found. Please run 'make build' to compile all components")
+	}
+
+	orchestratorCmd := exec.Command(orchestratorPath, "mirror")
+	orchestratorCmd.Args = append(orchestratorCmd.Args, args...)
+
+	for _, name := range []string{"go-version", "rust-version"} {
+		if value, _ := cmd.Flags().GetString(name); value != "" {
+			orchestratorCmd.Args = append(orchestratorCmd.Args, "--"+name, value)
+		}
+	}
+	if noReleases, _ := cmd.Flags().GetBool("no-releases"); noReleases {
+		orchestratorCmd.Args = append(orchestratorCmd.Args, "--no-releases")
+	}
+
+	orchestratorCmd.Stdout = os.Stdout
+	orchestratorCmd.Stderr = os.Stderr
+
+	return orchestratorCmd.Run()
+}
@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"gearbox/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// outputFormat returns the format selected with the global --output flag
+func outputFormat(cmd *cobra.Command) (output.Format, error) {
+	value, _ := cmd.Flags().GetString("output")
+	return output.ParseFormat(value)
+}
+
+// outputArgs returns the orchestrator arguments for an output format
+func outputArgs(format output.Format) []string {
+	if !format.Structured() {
+		return nil
+	}
+	return []string{"--output", string(format)}
+}
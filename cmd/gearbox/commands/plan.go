@@ -24,11 +24,13 @@ This is useful for understanding the impact of tool removal.`,
 		Example: `  gearbox plan uninstall fd ripgrep         # Show removal plan
   gearbox plan uninstall fd --safety conservative  # Conservative analysis
   gearbox plan uninstall fd --safety aggressive    # Aggressive analysis`,
-		RunE: runPlan,
+		RunE:              runPlan,
+		ValidArgsFunction: completeAfter("uninstall", installedToolNames, true),
 	}
 
 	// Safety options
 	cmd.Flags().String("safety", "standard", "Safety level (conservative, standard, aggressive)")
+	registerValues(cmd, "safety", "conservative", "standard", "aggressive")
 
 	return cmd
 }
@@ -90,6 +92,11 @@ func runPlanWithOrchestrator(orchestratorPath string, cmd *cobra.Command, args [
 	if verbose, _ := cmd.Parent().PersistentFlags().GetBool("verbose"); verbose {
 		orchestratorCmd.Args = append(orchestratorCmd.Args, "--verbose")
 	}
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	orchestratorCmd.Args = append(orchestratorCmd.Args, outputArgs(format)...)
 
 	// Connect stdio
 	orchestratorCmd.Stdout = os.Stdout
@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// addReportFlag adds the --report flag for CI test reports
+func addReportFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArray("report", nil, "Write a test report for CI: junit=<file> or tap[=<file>], stdout without a file (repeatable)")
+}
+
+// reportArgs returns the orchestrator arguments for the --report flags
+func reportArgs(cmd *cobra.Command) []string {
+	reports, _ := cmd.Flags().GetStringArray("report")
+	var args []string
+	for _, report := range reports {
+		args = append(args, "--report", report)
+	}
+	return args
+}
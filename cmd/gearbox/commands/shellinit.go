@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gearbox/pkg/orchestrator"
+	"gearbox/pkg/shellinit"
+)
+
+// NewShellInitCmd creates the shell-init command
+func NewShellInitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shell-init [bash|zsh|fish]",
+		Short: "Print the shell setup of installed tools",
+		Long: `Print the shell code that sets up installed tools with shell integration,
+such as fzf key bindings, zoxide, mise, bun and the starship prompt. Load it
+from your shell's rc file with a single line:
+
+  bash:  eval "$(gearbox shell-init bash)"      in ~/.bashrc
+  zsh:   eval "$(gearbox shell-init zsh)"       in ~/.zshrc
+  fish:  gearbox shell-init fish | source       in ~/.config/fish/config.fish
+
+Only tools that are installed are set up, in an order that works: the PATH
+and environment of 'gearbox env' first (unless AUTO_UPDATE_PATH is false in
+~/.gearboxrc), then tools that change PATH, and the prompt last. Removing a
+tool removes its setup with the next shell.
+
+Install scripts used to append these lines to rc files themselves. --migrate
+removes those lines, backs up every changed file and adds the line above.`,
+		Example: `  eval "$(gearbox shell-init bash)"          # Set up tools in the current shell
+  gearbox shell-init zsh                     # Show the setup for zsh
+  gearbox shell-init --migrate --dry-run     # Show which rc file lines would go
+  gearbox shell-init --migrate               # Replace the lines of the install scripts`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: shellinit.Shells,
+		RunE:      runShellInit,
+	}
+
+	cmd.Flags().Bool("migrate", false, "Remove the rc file lines written by install scripts and load shell-init instead")
+	cmd.Flags().Bool("dry-run", false, "With --migrate, show the changes without making them")
+	return cmd
+}
+
+func runShellInit(cmd *cobra.Command, args []string) error {
+	if migrate, _ := cmd.Flags().GetBool("migrate"); migrate {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		return runShellInitMigrate(dryRun)
+	}
+
+	shell := shellinit.DetectShell()
+	if len(args) > 0 {
+		shell = args[0]
+	}
+	var env shellinit.Environment
+	if orchestrator.UserConfigValue("AUTO_UPDATE_PATH", "true") != "false" {
+		if m, err := loadManifestIfExists(); err == nil {
+			env = shellinit.ManifestEnvironment(m)
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			env = env.WithToolchains(home)
+		}
+	}
+	script, err := shellinit.Script(shell, env, shellinit.FindInstalled)
+	if err != nil {
+		return err
+	}
+	fmt.Print(script)
+	return nil
+}
+
+func runShellInitMigrate(dryRun bool) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to find home directory: %w", err)
+	}
+
+	migrations, err := shellinit.Migrate(shellinit.RCFiles(home), dryRun)
+	if err != nil {
+		return err
+	}
+	if len(migrations) == 0 {
+		fmt.Println("✅ No rc file lines from install scripts found")
+		return nil
+	}
+
+	removed, added := "Removed", "Added"
+	if dryRun {
+		removed, added = "Would remove", "Would add"
+	}
+	for _, migration := range migrations {
+		fmt.Printf("📝 %s\n", migration.File.Path)
+		for _, line := range migration.Removed {
+			fmt.Printf("   %s: %s\n", removed, line)
+		}
+		if migration.AddsInit {
+			fmt.Printf("   %s: %s\n", added, shellinit.InitLine(migration.File.Shell))
+		}
+		if migration.Backup != "" {
+			fmt.Printf("   Backup: %s\n", migration.Backup)
+		}
+	}
+
+	if !dryRun {
+		fmt.Println("\n✅ Start a new shell to load the tools through gearbox shell-init")
+	}
+	return nil
+}
@@ -21,8 +21,9 @@ Shows:
 - Complete list of included tools
 - Other bundles it includes
 - Tags and metadata`,
-		Args: cobra.ExactArgs(2),
-		RunE: runShow,
+		Args:              cobra.ExactArgs(2),
+		RunE:              runShow,
+		ValidArgsFunction: completeAfter("bundle", bundleNames, false),
 	}
 
 	return cmd
@@ -35,6 +36,11 @@ func runShow(cmd *cobra.Command, args []string) error {
 
 	bundleName := args[1]
 
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Get the directory where the gearbox binary is located
 	execPath, err := os.Executable()
 	if err != nil {
@@ -50,7 +56,7 @@ func runShow(cmd *cobra.Command, args []string) error {
 	}
 
 	// Use the orchestrator to show bundle details
-	orchestratorCmd := exec.Command(orchestratorPath, "show", "bundle", bundleName)
+	orchestratorCmd := exec.Command(orchestratorPath, append([]string{"show", "bundle", bundleName}, outputArgs(format)...)...)
 	orchestratorCmd.Stdout = os.Stdout
 	orchestratorCmd.Stderr = os.Stderr
 	
@@ -9,6 +9,9 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"gearbox/pkg/fsutil"
+	"gearbox/pkg/output"
+	"gearbox/pkg/status"
 )
 
 // NewStatusCmd creates the status command
@@ -24,10 +27,16 @@ If specific tools are provided, only those tools are checked.
 Special tool status:
 - nerd-fonts: Shows installed font families and variants
 
+--drift shows the tracked tools that differ from the manifest: binaries that
+are missing, modified since installation or shadowed on PATH, and versions
+that differ from the recorded ones. 'gearbox verify --integrity' compares
+every checksum instead of only those of changed files.
+
 Examples:
   gearbox status                  # Show status for all tools
   gearbox status fd ripgrep       # Show status for specific tools
-  gearbox status nerd-fonts       # Show detailed Nerd Fonts status`,
+  gearbox status nerd-fonts       # Show detailed Nerd Fonts status
+  gearbox status --drift          # Show tools that differ from the manifest`,
 		RunE: runStatus,
 	}
 
@@ -37,11 +46,20 @@ Examples:
 	cmd.Flags().BoolP("detailed", "d", false, "Show detailed information")
 	cmd.Flags().Bool("manifest-only", false, "Show only manifest-tracked tools")
 	cmd.Flags().Bool("unified", false, "Show unified view (manifest + live detection)")
+	cmd.Flags().Bool("drift", false, "Show tracked tools whose binaries or versions differ from the manifest")
 
 	return cmd
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if format.Structured() {
+		return runStructuredStatus(cmd, args, format)
+	}
+
 	// Get the directory where the gearbox binary is located
 	execPath, err := os.Executable()
 	if err != nil {
@@ -90,6 +108,9 @@ func runStatusWithOrchestrator(orchestratorPath string, cmd *cobra.Command, args
 	if unified, _ := cmd.Flags().GetBool("unified"); unified {
 		statusCmd.Args = append(statusCmd.Args, "--unified")
 	}
+	if drift, _ := cmd.Flags().GetBool("drift"); drift {
+		statusCmd.Args = append(statusCmd.Args, "--drift")
+	}
 
 	statusCmd.Stdout = os.Stdout
 	statusCmd.Stderr = os.Stderr
@@ -97,6 +118,37 @@ func runStatusWithOrchestrator(orchestratorPath string, cmd *cobra.Command, args
 	return statusCmd.Run()
 }
 
+// runStructuredStatus writes the status of tools for scripts, combining the
+// manifest with live detection
+func runStructuredStatus(cmd *cobra.Command, args []string, format output.Format) error {
+	service, err := status.NewUnifiedStatusService()
+	if err != nil {
+		return fmt.Errorf("failed to initialize status service: %w", err)
+	}
+
+	report, err := service.GetStatusReport(args)
+	if err != nil {
+		return err
+	}
+
+	installedOnly, _ := cmd.Flags().GetBool("installed")
+	missingOnly, _ := cmd.Flags().GetBool("missing")
+	manifestOnly, _ := cmd.Flags().GetBool("manifest-only")
+	driftOnly, _ := cmd.Flags().GetBool("drift")
+	if installedOnly || missingOnly || manifestOnly || driftOnly {
+		tools := []*status.ToolStatus{}
+		for _, tool := range report.Tools {
+			if (installedOnly && !tool.Installed) || (missingOnly && tool.Installed) || (manifestOnly && !tool.InManifest) || (driftOnly && len(tool.Drift) == 0) {
+				continue
+			}
+			tools = append(tools, tool)
+		}
+		report.Tools = tools
+	}
+
+	return output.Write(os.Stdout, format, report)
+}
+
 func runBasicStatus(tools []string) error {
 	// If specific tools provided, check only those
 	if len(tools) > 0 {
@@ -167,7 +219,7 @@ func showNerdFontsStatus() error {
 
 	// Show fonts directory info
 	fontsDir := filepath.Join(os.Getenv("HOME"), ".local", "share", "fonts")
-	if size := getDirSize(fontsDir); size > 0 {
+	if size := fsutil.DirSize(fontsDir); size > 0 {
 		fmt.Printf("📁 Location: %s (%s)\n", fontsDir, humanReadableSize(size))
 	} else {
 		fmt.Printf("📁 Location: %s\n", fontsDir)
@@ -254,4 +306,4 @@ func groupFontsByFamily(fonts []string) map[string][]string {
 }
 
 // Helper functions - these are declared in doctor.go
-// getDirSize and humanReadableSize are available from doctor.go
\ No newline at end of file
+// humanReadableSize is available from doctor.go
\ No newline at end of file
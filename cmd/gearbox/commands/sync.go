@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// NewSyncCmd creates the sync command
+func NewSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync [TOOLS...]",
+		Short: "Reconcile the manifest with the tools on the system",
+		Long: `List every disagreement between the manifest and the system and resolve it:
+
+- missing:   tracked, but the binary is gone
+- untracked: a catalog tool is installed, but not tracked
+- version:   the tool reports another version than recorded
+- moved:     the recorded binary is gone, but PATH finds the tool elsewhere
+
+For each mismatch sync asks whether to update the manifest to match the
+system, reinstall the tool, untrack it or ignore the mismatch. Updating untracks
+missing tools, adopts untracked ones like 'gearbox adopt' and records the new
+version or binary path of the others. Untracking leaves the files alone.
+
+--policy resolves every mismatch the same way without asking, for scripts.
+'--policy ignore' only lists the mismatches. The manifest is backed up before
+it changes.`,
+		Example: `  gearbox sync                        # Ask for each mismatch
+  gearbox sync --policy ignore        # List the mismatches
+  gearbox sync --policy update        # Make the manifest match the system
+  gearbox sync fd --policy reinstall  # Reinstall fd if it differs`,
+		ValidArgsFunction: completeNames(toolNames),
+		RunE:              runSync,
+	}
+
+	cmd.Flags().String("policy", "ask", "Resolve every mismatch with update, reinstall, untrack or ignore instead of asking")
+	registerValues(cmd, "policy", "ask", "update", "reinstall", "untrack", "ignore")
+	return cmd
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	orchestratorPath := filepath.Join(filepath.Dir(execPath), "orchestrator")
+	if _, err := os.Stat(orchestratorPath); err != nil {
+		return fmt.Errorf("orchestrator not found. Please run 'make build' to compile all components")
+	}
+
+	policy, _ := cmd.Flags().GetString("policy")
+	syncArgs := append([]string{"sync", "--policy", policy}, args...)
+
+	orchestratorCmd := exec.Command(orchestratorPath, syncArgs...)
+	orchestratorCmd.Stdout = os.Stdout
+	orchestratorCmd.Stderr = os.Stderr
+	orchestratorCmd.Stdin = os.Stdin
+	return orchestratorCmd.Run()
+}
@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"os"
+	
 	"github.com/spf13/cobra"
 	"github.com/rs/zerolog/log"
 	
@@ -34,6 +36,9 @@ intuitive way to discover and manage development tools.`,
   # Launch TUI in test mode for automated testing
   gearbox tui --test
   
+  # Install from an offline mirror
+  gearbox tui --mirror /media/usb/gearbox-mirror
+  
   # Navigation:
   #   Tab       - Switch between views
   #   ↑/↓       - Navigate lists
@@ -48,6 +53,7 @@ intuitive way to discover and manage development tools.`,
 	cmd.Flags().BoolP("demo", "d", false, "Launch in demo mode with mock data (safe for testing)")
 	cmd.Flags().BoolP("test", "t", false, "Launch in test mode for automated testing")
 	cmd.Flags().String("test-scenario", "", "Run specific test scenario (basic-nav, tool-install, bundle-install)")
+	cmd.Flags().String("mirror", "", "Install without network access from an offline mirror (default: $GEARBOX_MIRROR)")
 	
 	// Future flags could include:
 	// cmd.Flags().StringP("theme", "t", "default", "Color theme (default, dark, light)")
@@ -64,6 +70,10 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	demoMode, _ := cmd.Flags().GetBool("demo")
 	testMode, _ := cmd.Flags().GetBool("test")
 	testScenario, _ := cmd.Flags().GetString("test-scenario")
+	mirror, _ := cmd.Flags().GetString("mirror")
+	if mirror == "" {
+		mirror = os.Getenv("GEARBOX_MIRROR")
+	}
 	
 	// Check terminal capabilities (skip for test mode)
 	if !testMode && !isTerminalInteractive() {
@@ -76,6 +86,7 @@ func runTUI(cmd *cobra.Command, args []string) error {
 		DemoMode:     demoMode,
 		TestMode:     testMode,
 		TestScenario: testScenario,
+		Mirror:       mirror,
 	}
 	
 	// Run the TUI with options
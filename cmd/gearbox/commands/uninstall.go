@@ -5,9 +5,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"gearbox/pkg/daemon"
 	"gearbox/pkg/errors"
 	"gearbox/pkg/logger"
 )
@@ -20,20 +22,39 @@ func NewUninstallCmd() *cobra.Command {
 		Long: `Uninstall one or more development tools with dependency analysis and safe removal.
 
 The uninstall command analyzes dependencies and provides a removal plan before execution.
-It ensures that removing tools won't break other installed tools unless forced.`,
+It ensures that removing tools won't break other installed tools unless forced.
+
+Removed binaries and build directories are moved to the trash in ~/.gearbox/trash
+instead of being deleted, and their manifest records are kept with them.
+'gearbox uninstall --undo' restores the last removal, files and records together,
+and '--undo <id>' an earlier one listed by '--trash'. Removals older than
+TRASH_MAX_AGE_DAYS (30) are purged automatically after each uninstall, and the
+oldest ones while the trash is larger than TRASH_MAX_SIZE_MB (2048). --purge
+deletes the files right away; without tools it empties the trash.
+
+The plan also lists what a tool left elsewhere, grouped by kind: lines that
+older install scripts added to ~/.bashrc and other rc files, and completion
+files. These are removed with the tool; rc files are backed up next to the
+original and undo appends the lines again. --remove-config adds the tool's
+configuration files and its caches and databases, such as the zoxide database.`,
 		Example: `  gearbox uninstall fd ripgrep              # Uninstall specific tools
   gearbox uninstall fd --force              # Force removal despite dependencies
   gearbox uninstall fd --cascade            # Remove unused dependencies
   gearbox uninstall fd --dry-run            # Show what would be removed
-  gearbox uninstall fd --remove-config      # Remove configuration files too
-  gearbox uninstall fd --no-backup          # Skip backup creation`,
-		RunE: runUninstall,
+  gearbox uninstall zoxide --remove-config  # Remove configuration and caches too
+  gearbox uninstall fd --no-backup          # Skip backup creation
+  gearbox uninstall fd --purge              # Delete instead of moving to the trash
+  gearbox uninstall --undo                  # Restore the last removal
+  gearbox uninstall --trash                 # List removals in the trash
+  gearbox uninstall --purge                 # Empty the trash`,
+		RunE:              runUninstall,
+		ValidArgsFunction: completeNames(installedToolNames),
 	}
 
 	// Removal options
 	cmd.Flags().Bool("force", false, "Force removal even if there are dependents")
 	cmd.Flags().Bool("cascade", false, "Remove unused dependencies")
-	cmd.Flags().Bool("remove-config", false, "Remove configuration files")
+	cmd.Flags().Bool("remove-config", false, "Remove configuration files and caches")
 	cmd.Flags().Bool("dry-run", false, "Show what would be removed without executing")
 	cmd.Flags().Bool("backup", true, "Create backup before removal")
 	cmd.Flags().Bool("no-backup", false, "Skip backup creation")
@@ -42,8 +63,14 @@ It ensures that removing tools won't break other installed tools unless forced.`
 	// Bundle options
 	cmd.Flags().Bool("bundle-contents", false, "Remove all tools in bundle, not just bundle tracking")
 
+	// Trash options
+	cmd.Flags().Bool("purge", false, "Delete files instead of moving them to the trash; without tools, empty the trash")
+	cmd.Flags().Bool("undo", false, "Restore the last removal, or the one with the given ID, from the trash")
+	cmd.Flags().Bool("trash", false, "List the removals in the trash")
+
 	// Safety options
 	cmd.Flags().String("safety", "standard", "Safety level (conservative, standard, aggressive)")
+	registerValues(cmd, "safety", "conservative", "standard", "aggressive")
 
 	return cmd
 }
@@ -52,11 +79,16 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 	start := time.Now()
 	log := logger.GetGlobalLogger().Operation("uninstall")
 	
-	if len(args) == 0 {
+	undo, _ := cmd.Flags().GetBool("undo")
+	listTrash, _ := cmd.Flags().GetBool("trash")
+	purge, _ := cmd.Flags().GetBool("purge")
+	if len(args) == 0 && !undo && !listTrash && !purge {
 		return fmt.Errorf("no tools specified for removal")
 	}
 	
-	log.Infof("Starting uninstallation of %d tools", len(args))
+	if len(args) > 0 {
+		log.Infof("Starting uninstallation of %d tools", len(args))
+	}
 	
 	// Get the directory where the gearbox binary is located
 	execPath, err := os.Executable()
@@ -119,15 +151,56 @@ func runUninstallWithOrchestrator(orchestratorPath string, cmd *cobra.Command, a
 		orchestratorCmd.Args = append(orchestratorCmd.Args, "--bundle-contents")
 	}
 
+	// Trash flags
+	for _, name := range []string{"purge", "undo", "trash"} {
+		if value, _ := cmd.Flags().GetBool(name); value {
+			orchestratorCmd.Args = append(orchestratorCmd.Args, "--"+name)
+		}
+	}
+	// Undoing, listing and emptying the trash remove no tools
+	undo, _ := cmd.Flags().GetBool("undo")
+	listTrash, _ := cmd.Flags().GetBool("trash")
+	trashOnly := undo || listTrash || len(args) == 0
+
 	// Add global flags
 	if verbose, _ := cmd.Parent().PersistentFlags().GetBool("verbose"); verbose {
 		orchestratorCmd.Args = append(orchestratorCmd.Args, "--verbose")
 	}
 
+	// With a running daemon the removal waits for installations in progress.
+	// The plan is shown and confirmed here; the daemon only removes.
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); !dryRun && !trashOnly {
+		if client := dialDaemon(); client != nil {
+			return runUninstallWithDaemon(client, orchestratorCmd, args)
+		}
+	}
+
 	// Connect stdio
 	orchestratorCmd.Stdout = os.Stdout
 	orchestratorCmd.Stderr = os.Stderr
 	orchestratorCmd.Stdin = os.Stdin
 
 	return orchestratorCmd.Run()
+}
+
+// runUninstallWithDaemon shows the removal plan with a dry run of the
+// orchestrator command and submits the removal to the daemon once confirmed
+func runUninstallWithDaemon(client *daemon.Client, orchestratorCmd *exec.Cmd, tools []string) error {
+	planCmd := exec.Command(orchestratorCmd.Path, append(orchestratorCmd.Args[1:], "--dry-run")...)
+	planCmd.Stdout = os.Stdout
+	planCmd.Stderr = os.Stderr
+	if err := planCmd.Run(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nProceed with removal? [y/N]: ")
+	var response string
+	fmt.Scanln(&response)
+	if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+		fmt.Printf("❌ Removal cancelled\n")
+		return nil
+	}
+
+	flags := orchestratorCmd.Args[2+len(tools):]
+	return runDaemonJob(client, daemon.JobRequest{Action: daemon.ActionUninstall, Tools: tools, Args: flags}, installRenderer(false, false))
 }
\ No newline at end of file
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// NewValidateCmd creates the validate command
+func NewValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate tool and bundle configuration",
+		Long: `Validate the bundle configuration in config/bundles.json and the
+package mapping table in config/packages.json.
+
+Checks:
+- Bundle includes resolve without cycles
+- Every canonical package name used by bundles, tool dependencies and common
+  dependencies has a mapping for each supported package manager
+  (apt, yum, dnf, pacman, zypper, apk)`,
+		Args: cobra.NoArgs,
+		RunE: runValidate,
+	}
+
+	return cmd
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	// Get the directory where the gearbox binary is located
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+	
+	repoDir := filepath.Dir(execPath)
+	orchestratorPath := filepath.Join(repoDir, "orchestrator")
+
+	// Check if the orchestrator is available
+	if _, err := os.Stat(orchestratorPath); err != nil {
+		return fmt.Errorf("orchestrator not found. Please run 'make build' to compile all components")
+	}
+
+	orchestratorCmd := exec.Command(orchestratorPath, "validate")
+	orchestratorCmd.Stdout = os.Stdout
+	orchestratorCmd.Stderr = os.Stderr
+	
+	return orchestratorCmd.Run()
+}
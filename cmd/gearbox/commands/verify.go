@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// NewVerifyCmd creates the verify command
+func NewVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [TOOLS...]",
+		Short: "Verify that installed tools run",
+		Long: `Run the test command of each tool, such as 'fd --version', to check that
+the installation works. Without arguments all configured tools are verified.
+
+With --integrity the binaries of tracked tools are compared with the
+sha256 checksums, sizes and modification times recorded in the manifest at
+install time. It reports binaries that are missing, were modified or are
+shadowed by another binary on PATH, and tools that report another version
+than the one recorded.
+
+The command fails when any tool fails verification. Use --report to write
+the results as JUnit XML or TAP for CI dashboards.`,
+		Example: `  gearbox verify fd ripgrep                  # Verify specific tools
+  gearbox verify --integrity                 # Check binaries against the manifest
+  gearbox verify --report junit=verify.xml   # JUnit report for CI
+  gearbox verify --report tap                # TAP on stdout`,
+		RunE: runVerify,
+	}
+
+	cmd.Flags().Bool("integrity", false, "Compare the binaries of tracked tools with the checksums in the manifest")
+	addReportFlag(cmd)
+	return cmd
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	orchestratorPath := filepath.Join(filepath.Dir(execPath), "orchestrator")
+	if _, err := os.Stat(orchestratorPath); err != nil {
+		return fmt.Errorf("orchestrator not found. Please run 'make build' to compile all components")
+	}
+
+	verifyArgs := append([]string{"verify"}, args...)
+	if integrity, _ := cmd.Flags().GetBool("integrity"); integrity {
+		verifyArgs = append(verifyArgs, "--integrity")
+	}
+	verifyCmd := exec.Command(orchestratorPath, append(verifyArgs, reportArgs(cmd)...)...)
+	verifyCmd.Stdout = os.Stdout
+	verifyCmd.Stderr = os.Stderr
+	// Failed verifications are not usage errors
+	cmd.SilenceUsage = true
+	return verifyCmd.Run()
+}
@@ -8,6 +8,7 @@ import (
 	"gearbox/cmd/gearbox/commands"
 	"gearbox/pkg/errors"
 	"gearbox/pkg/logger"
+	"gearbox/pkg/output"
 )
 
 var version = "dev" // This will be set during build
@@ -35,12 +36,28 @@ Supports parallel installation, dependency resolution, and comprehensive health
 	rootCmd.AddCommand(commands.NewConfigCmd())
 	rootCmd.AddCommand(commands.NewDoctorCmd())
 	rootCmd.AddCommand(commands.NewStatusCmd())
+	rootCmd.AddCommand(commands.NewVerifyCmd())
 	rootCmd.AddCommand(commands.NewGenerateCmd())
+	rootCmd.AddCommand(commands.NewValidateCmd())
+	rootCmd.AddCommand(commands.NewExportCmd())
+	rootCmd.AddCommand(commands.NewImportCmd())
+	rootCmd.AddCommand(commands.NewMirrorCmd())
+	rootCmd.AddCommand(commands.NewCacheCmd())
+	rootCmd.AddCommand(commands.NewGCCmd())
+	rootCmd.AddCommand(commands.NewAdoptCmd())
+	rootCmd.AddCommand(commands.NewSyncCmd())
 	rootCmd.AddCommand(commands.NewTUICmd())
+	rootCmd.AddCommand(commands.NewServeCmd())
+	rootCmd.AddCommand(commands.NewJobsCmd())
+	rootCmd.AddCommand(commands.NewShellInitCmd())
+	rootCmd.AddCommand(commands.NewEnvCmd())
+	rootCmd.AddCommand(commands.NewCompletionCmd())
+	rootCmd.AddCommand(commands.NewManCmd())
 
 	// Global flags
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress non-error output")
+	rootCmd.PersistentFlags().String("output", "table", "Output format of list, status, show, plan and doctor (table, json, yaml)")
 
 	// Set up pre-execution hook for logging configuration
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
@@ -74,6 +91,16 @@ func configureLogging(cmd *cobra.Command) {
 		log = logger.NewDefault()
 	}
 	
+	// Keep stdout for the document or events when scripts request structured output
+	value, _ := cmd.Flags().GetString("output")
+	events, _ := cmd.Flags().GetBool("events")
+	if format, err := output.ParseFormat(value); events || (err == nil && format.Structured()) {
+		config := logger.DefaultConfig()
+		config.Level = logger.ErrorLevel
+		config.Output = os.Stderr
+		log = logger.New(config)
+	}
+	
 	logger.SetGlobalLogger(log)
 }
 
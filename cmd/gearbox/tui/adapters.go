@@ -716,7 +716,7 @@ func (h *HealthAdapter) IsReady() bool {
 // OnActivate is called when the view becomes active
 func (h *HealthAdapter) OnActivate() tea.Cmd {
 	// Health view automatically starts health checks when activated
-	return h.health.RunNextHealthCheck(0)
+	return h.health.RunHealthChecks()
 }
 
 // OnDeactivate is called when the view becomes inactive
@@ -740,7 +740,7 @@ func (h *HealthAdapter) OnDestroy() tea.Cmd {
 // OnRefresh is called when the view needs to refresh its data
 func (h *HealthAdapter) OnRefresh() tea.Cmd {
 	// Re-run all health checks
-	return h.health.RunNextHealthCheck(0)
+	return h.health.RunHealthChecks()
 }
 
 // CanDeactivate returns whether the view can be deactivated
@@ -781,9 +781,9 @@ func (h *HealthAdapter) SetData(tools []orchestrator.ToolConfig, installed map[s
 	h.health.SetData(tools, installed)
 }
 
-// RunNextHealthCheck runs the next health check in sequence
-func (h *HealthAdapter) RunNextHealthCheck(checkIndex int) tea.Cmd {
-	return h.health.RunNextHealthCheck(checkIndex)
+// RunHealthChecks runs the health checks
+func (h *HealthAdapter) RunHealthChecks() tea.Cmd {
+	return h.health.RunHealthChecks()
 }
 
 // GetHealthStatus returns overall health status
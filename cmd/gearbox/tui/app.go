@@ -79,6 +79,12 @@ type Model struct {
 }
 
 func NewModel() (TUIModel, error) {
+	return newModel(Options{})
+}
+
+// newModel creates the TUI model, installing from the offline mirror of the
+// options when set
+func newModel(opts Options) (TUIModel, error) {
 	// Set up file-based logging for TUI to avoid interfering with the interface
 	logFile, err := os.OpenFile("/tmp/gearbox-tui.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err == nil {
@@ -91,6 +97,7 @@ func NewModel() (TUIModel, error) {
 	// Use factory pattern to create dependencies
 	factory := NewDependencyFactory(orchestrator.InstallationOptions{
 		BuildType: "standard",
+		Mirror:    opts.Mirror,
 	})
 	
 	deps, err := factory.CreateDependencies()
@@ -330,8 +337,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case healthCheckTriggerMsg:
 		// Trigger health checks in the health view
 		if m.state.CurrentView == ViewHealth {
-			// Directly start sequential health checks
-			cmd := m.healthView.RunNextHealthCheck(0)
+			cmd := m.healthView.RunHealthChecks()
 			return m, cmd
 		}
 		return m, nil
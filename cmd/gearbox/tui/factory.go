@@ -5,6 +5,7 @@ import (
 	
 	"gearbox/cmd/gearbox/tui/tasks"
 	"gearbox/cmd/gearbox/tui/views"
+	"gearbox/pkg/daemon"
 	"gearbox/pkg/manifest"
 	"gearbox/pkg/orchestrator"
 )
@@ -54,6 +55,11 @@ func (f *DependencyFactory) CreateDependencies() (*Dependencies, error) {
 	
 	manifestMgr := manifest.NewManager()
 	taskManager := tasks.NewTaskManager(orch, DefaultMaxParallel)
+	taskManager.SetMirror(f.orchestratorOpts.Mirror)
+	if client, err := daemon.Dial(); err == nil {
+		// Share installations with the command line through the daemon
+		taskManager.SetDaemon(client)
+	}
 	
 	// Create handlers (concrete types)
 	navigationHandler := NewNavigationHandler()
@@ -116,16 +122,8 @@ func (f *DependencyFactory) createMessageRouter(healthView *views.HealthView) *M
 	healthHandler := NewHealthViewMessageHandler(healthView)
 	
 	// Setup router with handler (using the existing setupMessageRouter pattern)
-	router.Register(reflect.TypeOf(views.MemoryCheckCompleteMsg{}), healthHandler)
-	router.Register(reflect.TypeOf(views.DiskCheckCompleteMsg{}), healthHandler)
-	router.Register(reflect.TypeOf(views.InternetCheckCompleteMsg{}), healthHandler)
-	router.Register(reflect.TypeOf(views.BuildToolsCheckCompleteMsg{}), healthHandler)
-	router.Register(reflect.TypeOf(views.GitCheckCompleteMsg{}), healthHandler)
-	router.Register(reflect.TypeOf(views.PathCheckCompleteMsg{}), healthHandler)
-	router.Register(reflect.TypeOf(views.RustToolchainCheckCompleteMsg{}), healthHandler)
-	router.Register(reflect.TypeOf(views.GoToolchainCheckCompleteMsg{}), healthHandler)
+	router.Register(reflect.TypeOf(views.HealthChecksCompleteMsg{}), healthHandler)
 	router.Register(reflect.TypeOf(views.ToolUpdatesCheckCompleteMsg{}), healthHandler)
-	router.Register(reflect.TypeOf(views.NextHealthCheckMsg{}), healthHandler)
 	
 	return router
 }
@@ -55,16 +55,8 @@ func NewHealthViewMessageHandler(view *views.HealthView) *HealthViewMessageHandl
 // HandleMessage handles health check messages
 func (h *HealthViewMessageHandler) HandleMessage(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
-	case views.MemoryCheckCompleteMsg,
-		 views.DiskCheckCompleteMsg,
-		 views.InternetCheckCompleteMsg,
-		 views.BuildToolsCheckCompleteMsg,
-		 views.GitCheckCompleteMsg,
-		 views.PathCheckCompleteMsg,
-		 views.RustToolchainCheckCompleteMsg,
-		 views.GoToolchainCheckCompleteMsg,
-		 views.ToolUpdatesCheckCompleteMsg,
-		 views.NextHealthCheckMsg:
+	case views.HealthChecksCompleteMsg,
+		 views.ToolUpdatesCheckCompleteMsg:
 		return h.view.Update(msg)
 	}
 	return nil
@@ -80,16 +72,8 @@ func (m *Model) setupMessageRouter() *MessageRouter {
 		healthHandler := NewHealthViewMessageHandler(healthAdapter.health)
 		
 		// Register all health check message types
-		router.Register(reflect.TypeOf(views.MemoryCheckCompleteMsg{}), healthHandler)
-		router.Register(reflect.TypeOf(views.DiskCheckCompleteMsg{}), healthHandler)
-		router.Register(reflect.TypeOf(views.InternetCheckCompleteMsg{}), healthHandler)
-		router.Register(reflect.TypeOf(views.BuildToolsCheckCompleteMsg{}), healthHandler)
-		router.Register(reflect.TypeOf(views.GitCheckCompleteMsg{}), healthHandler)
-		router.Register(reflect.TypeOf(views.PathCheckCompleteMsg{}), healthHandler)
-		router.Register(reflect.TypeOf(views.RustToolchainCheckCompleteMsg{}), healthHandler)
-		router.Register(reflect.TypeOf(views.GoToolchainCheckCompleteMsg{}), healthHandler)
+		router.Register(reflect.TypeOf(views.HealthChecksCompleteMsg{}), healthHandler)
 		router.Register(reflect.TypeOf(views.ToolUpdatesCheckCompleteMsg{}), healthHandler)
-		router.Register(reflect.TypeOf(views.NextHealthCheckMsg{}), healthHandler)
 	}
 	
 	return router
@@ -239,8 +239,8 @@ type HealthService interface {
 	// SetData updates health view data
 	SetData(tools []orchestrator.ToolConfig, installed map[string]*manifest.InstallationRecord)
 	
-	// RunNextHealthCheck runs the next health check in sequence
-	RunNextHealthCheck(checkIndex int) tea.Cmd
+	// RunHealthChecks runs the health checks
+	RunHealthChecks() tea.Cmd
 	
 	// GetHealthStatus returns overall health status
 	GetHealthStatus() (passing int, warning int, failing int)
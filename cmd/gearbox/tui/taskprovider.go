@@ -6,6 +6,7 @@ import (
 	
 	"gearbox/cmd/gearbox/tui/tasks"
 	"gearbox/cmd/gearbox/tui/views"
+	"gearbox/pkg/orchestrator"
 )
 
 // TaskManagerProvider wraps the task manager to implement TaskProvider
@@ -36,6 +37,7 @@ func (p *TaskManagerProvider) GetTask(taskID string) (*views.TaskInfo, bool) {
 		Stage:     task.Stage,
 		Output:    task.Output,
 		StartTime: task.StartTime.Format("15:04:05"),
+		ETA:       formatETA(task),
 		Error:     task.Error,
 	}
 	
@@ -64,6 +66,7 @@ func (p *TaskManagerProvider) GetAllTasks() []*views.TaskInfo {
 			Stage:     task.Stage,
 			Output:    task.Output,
 			StartTime: task.StartTime.Format("15:04:05"),
+			ETA:       formatETA(task),
 			Error:     task.Error,
 		}
 		
@@ -88,6 +91,24 @@ func (p *TaskManagerProvider) CancelTask(taskID string) error {
 	return p.taskManager.CancelTask(taskID)
 }
 
+// formatETA describes the expected remaining build time of a pending or running task
+func formatETA(task *tasks.InstallTask) string {
+	if task.Estimate == 0 {
+		return ""
+	}
+	switch task.Status {
+	case tasks.TaskStatusPending:
+		return "~" + orchestrator.FormatEstimate(task.Estimate)
+	case tasks.TaskStatusRunning:
+		remaining := task.Estimate - time.Since(task.StartTime)
+		if remaining <= 0 {
+			return "taking longer than usual"
+		}
+		return "~" + orchestrator.FormatEstimate(remaining) + " left"
+	}
+	return ""
+}
+
 func formatDuration(d time.Duration) string {
 	if d < time.Second {
 		return "< 1s"
@@ -2,11 +2,9 @@ package tasks
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"os"
-	"os/exec"
-	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -14,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rs/zerolog/log"
 	
+	"gearbox/pkg/daemon"
 	"gearbox/pkg/orchestrator"
 )
 
@@ -39,8 +38,10 @@ type InstallTask struct {
 	Output     []string
 	StartTime  time.Time
 	EndTime    time.Time
+	Estimate   time.Duration // Expected build time, zero when unknown
 	Error      error
 	CancelChan chan bool
+	JobID      string // Daemon job that installs the tool, empty without a daemon
 	
 	mu sync.RWMutex
 }
@@ -54,6 +55,10 @@ type TaskManager struct {
 	
 	mu          sync.RWMutex
 	updateChan  chan TaskUpdateMsg
+	
+	daemon     *daemon.Client // Set when installations run as daemon jobs
+	submitting sync.Mutex     // Held while a job is submitted and not yet assigned
+	mirror     string         // Offline mirror installations use, empty when online
 }
 
 // TaskUpdateMsg is sent when a task status changes
@@ -95,6 +100,11 @@ func (tm *TaskManager) AddTask(tool orchestrator.ToolConfig, buildType string) s
 		CancelChan: make(chan bool, 1),
 	}
 	
+	if tm.orchestrator != nil {
+		// Running tasks share the machine, each with its own orchestrator
+		task.Estimate, _ = tm.orchestrator.EstimateBuildTime(tool, buildType, tm.maxParallel)
+	}
+	
 	tm.tasks[task.ID] = task
 	return task.ID
 }
@@ -119,6 +129,7 @@ func (tm *TaskManager) StartTask(taskID string) error {
 	}
 	
 	task.Status = TaskStatusRunning
+	task.StartTime = time.Now()
 	tm.activeTasks++
 	tm.mu.Unlock()
 	
@@ -177,9 +188,10 @@ func (tm *TaskManager) runInstallation(task *InstallTask) {
 	
 	// Run installation
 	var err error
-	if tm.orchestrator != nil {
-		// Real installation with progress updates
-		err = tm.runRealInstallation(task, writer)
+	if tm.daemon != nil {
+		err = tm.runDaemonInstallation(task, writer)
+	} else if tm.orchestrator != nil {
+		err = tm.runLocalInstallation(task, writer)
 	} else {
 		// Fallback to simulation only if no orchestrator (demo mode)
 		err = tm.simulateInstallation(task, writer)
@@ -316,124 +328,336 @@ func (tm *TaskManager) readOutput(task *InstallTask, reader io.Reader, done chan
 	close(done)
 }
 
-// runRealInstallation runs a real installation with progress updates
-func (tm *TaskManager) runRealInstallation(task *InstallTask, output io.Writer) error {
-	// Send initial progress
-	tm.sendUpdate(TaskUpdateMsg{
-		TaskID:   task.ID,
-		Stage:    "Starting installation...",
-		Progress: 0.1,
-	})
-	fmt.Fprintf(output, "==> Starting installation of %s\n", task.Tool.Name)
-	
-	// Send mid-progress update
-	tm.sendUpdate(TaskUpdateMsg{
-		TaskID:   task.ID,
-		Stage:    "Running installation...",
-		Progress: 0.5,
+// runLocalInstallation installs the tool in this process, with an
+// orchestrator of its own whose events update the task. Cancelling the task
+// stops the installation scripts.
+func (tm *TaskManager) runLocalInstallation(task *InstallTask, output io.Writer) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-task.CancelChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	reporter := orchestrator.ReporterFunc(func(event orchestrator.Event) {
+		tm.applyEvent(task, event, output)
 	})
-	fmt.Fprintf(output, "    Installing %s with build type %s\n", task.Tool.Name, task.BuildType)
-	
-	// Instead of running the orchestrator directly (which corrupts TUI output),
-	// run the orchestrator as a subprocess with output redirection
-	err := tm.runInstallationSubprocess(task, output)
-	
+	orch, err := orchestrator.NewOrchestratorBuilder(tm.installOptions(task)).
+		WithReporter(reporter).
+		WithContext(ctx).
+		Build()
 	if err != nil {
-		tm.sendUpdate(TaskUpdateMsg{
-			TaskID:   task.ID,
-			Stage:    "Installation failed",
-			Progress: 0.5,
-			Error:    err,
-		})
-		fmt.Fprintf(output, "    ❌ Installation failed: %v\n", err)
+		return fmt.Errorf("failed to prepare installation: %w", err)
+	}
+
+	if err := orch.InstallTools([]string{task.Tool.Name}); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("installation cancelled")
+		}
 		return err
 	}
-	
-	// Send completion update
-	tm.sendUpdate(TaskUpdateMsg{
-		TaskID:   task.ID,
-		Stage:    "Installation completed",
-		Progress: 1.0,
-	})
-	fmt.Fprintf(output, "    ✅ Installation completed successfully\n")
-	
 	return nil
 }
 
-// runInstallationSubprocess runs the installation as a subprocess to isolate output
-func (tm *TaskManager) runInstallationSubprocess(task *InstallTask, output io.Writer) error {
-	// Get the path to the orchestrator binary
-	execPath, err := os.Executable()
-	if err != nil {
-		return fmt.Errorf("failed to get executable path: %w", err)
+// applyEvent updates a task with an installation event and writes the
+// script output to output
+func (tm *TaskManager) applyEvent(task *InstallTask, event orchestrator.Event, output io.Writer) {
+	switch event.Type {
+	case orchestrator.EventOutput:
+		fmt.Fprintf(output, "%s\n", event.Message)
+	case orchestrator.EventMessage:
+		// Messages are formatted for the terminal, with blank lines
+		message := strings.TrimSpace(event.Message)
+		if message == "" {
+			return
+		}
+		fmt.Fprintf(output, "==> %s\n", message)
+		tm.setStage(task, message, -1)
+	case orchestrator.EventToolStarted:
+		if event.Build != nil {
+			tm.setStage(task, fmt.Sprintf("Building with %d jobs", event.Build.Jobs), 0.6)
+		} else {
+			tm.setStage(task, "Building", 0.6)
+		}
+	case orchestrator.EventToolFinished:
+		if event.Result == nil {
+			return
+		}
+		if event.Result.Success {
+			tm.setStage(task, "Build finished", 0.9)
+		} else {
+			fmt.Fprintf(output, "    ❌ %s\n", event.Result.Error)
+		}
 	}
-	
-	// Build path to orchestrator binary (same directory as gearbox)
-	binDir := filepath.Dir(execPath)
-	orchestratorPath := filepath.Join(binDir, "orchestrator")
-	
-	// Check if orchestrator exists
-	if _, err := os.Stat(orchestratorPath); os.IsNotExist(err) {
-		return fmt.Errorf("orchestrator binary not found at %s", orchestratorPath)
+}
+
+// setStage updates the stage of a running task, and its progress unless
+// progress is negative
+func (tm *TaskManager) setStage(task *InstallTask, stage string, progress float64) {
+	task.mu.Lock()
+	task.Stage = stage
+	if progress >= 0 {
+		task.Progress = progress
 	}
-	
-	// Prepare the command with proper build type flags
-	var args []string
-	args = append(args, "install", task.Tool.Name)
-	
-	// Add build type flag
+	progress = task.Progress
+	task.mu.Unlock()
+
+	tm.sendUpdate(TaskUpdateMsg{TaskID: task.ID, Stage: stage, Progress: progress})
+}
+
+// buildType returns the build type of a task, standard unless it is
+// minimal or maximum
+func buildType(task *InstallTask) string {
 	switch task.BuildType {
-	case "minimal":
-		args = append(args, "--build-type", "minimal")
-	case "maximum":
-		args = append(args, "--build-type", "maximum") 
+	case "minimal", "maximum":
+		return task.BuildType
 	default:
-		args = append(args, "--build-type", "standard")
+		return "standard"
 	}
-	
-	// Create the command
-	cmd := exec.Command(orchestratorPath, args...)
-	
-	// Create pipes for stdout and stderr
-	stdoutPipe, err := cmd.StdoutPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stdout pipe: %w", err)
+}
+
+// installOptions returns the orchestrator options for installing a task
+func (tm *TaskManager) installOptions(task *InstallTask) orchestrator.InstallationOptions {
+	return orchestrator.InstallationOptions{BuildType: buildType(task), Mirror: tm.mirror}
+}
+
+// installArgs returns the orchestrator flags for installing a task as a
+// daemon job
+func (tm *TaskManager) installArgs(task *InstallTask) []string {
+	args := []string{"--build-type", buildType(task)}
+	if tm.mirror != "" {
+		args = append(args, "--mirror", tm.mirror)
 	}
-	
-	stderrPipe, err := cmd.StderrPipe()
+	return args
+}
+
+// SetMirror installs from an offline mirror instead of the network
+func (tm *TaskManager) SetMirror(dir string) {
+	// Daemon jobs run in the daemon's working directory
+	tm.mirror = daemon.AbsPath(dir)
+}
+
+// SetDaemon runs installations as jobs of the daemon and shows the jobs
+// that other clients submit as tasks
+func (tm *TaskManager) SetDaemon(client *daemon.Client) {
+	tm.daemon = client
+	go tm.adoptJobs()
+}
+
+// runDaemonInstallation installs the tool as a daemon job, which the command
+// line can watch and cancel as well
+func (tm *TaskManager) runDaemonInstallation(task *InstallTask, output io.Writer) error {
+	tm.submitting.Lock()
+	job, err := tm.daemon.Submit(daemon.JobRequest{
+		Action: daemon.ActionInstall,
+		Tools:  []string{task.Tool.Name},
+		Args:   tm.installArgs(task),
+	})
+	if err == nil {
+		task.mu.Lock()
+		task.JobID = job.ID
+		task.mu.Unlock()
+	}
+	tm.submitting.Unlock()
 	if err != nil {
-		return fmt.Errorf("failed to create stderr pipe: %w", err)
+		return fmt.Errorf("failed to submit installation: %w", err)
 	}
+	fmt.Fprintf(output, "==> Submitted as daemon job %s\n", job.ID)
 	
-	// Start the command
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start installation: %w", err)
+	// The job keeps running when the event stream drops, so only the
+	// daemon decides whether it failed
+	return tm.daemon.Wait(job.ID, func(event orchestrator.Event) {
+		tm.applyEvent(task, event, output)
+	})
+}
+
+// adoptJobs follows the daemon and shows installations submitted by other
+// clients as tasks, one for every tool in the plan of the job
+func (tm *TaskManager) adoptJobs() {
+	adopted := make(map[string]map[string]*InstallTask) // Tasks by job and tool
+	ignored := make(map[string]bool)                    // Jobs of this manager and removals
+	
+	follow := func(event daemon.JobEvent) bool {
+		if ignored[event.Job] {
+			return true
+		}
+		tasks, found := adopted[event.Job]
+		if !found {
+			if event.State.Done() || tm.ownsJob(event.Job) || !tm.isInstallJob(event.Job) {
+				ignored[event.Job] = true
+				return true
+			}
+			tasks = make(map[string]*InstallTask)
+			adopted[event.Job] = tasks
+		}
+		
+		if event.Event != nil {
+			tm.applyJobEvent(event.Job, tasks, *event.Event)
+		}
+		if event.State.Done() {
+			tm.finishJob(tasks, event.State)
+			delete(adopted, event.Job)
+		}
+		return true
 	}
 	
-	// Read stdout in background
-	go func() {
-		scanner := bufio.NewScanner(stdoutPipe)
-		for scanner.Scan() {
-			line := scanner.Text()
-			fmt.Fprintf(output, "%s\n", line)
+	for {
+		if err := tm.daemon.Events(context.Background(), "", follow); err != nil {
+			log.Warn().Err(err).Msg("Stopped following daemon jobs")
+			return
 		}
-	}()
+		
+		// The daemon dropped the stream for falling behind: finish the jobs
+		// that ended in between and follow again
+		for jobID, tasks := range adopted {
+			job, err := tm.daemon.Job(jobID)
+			if err != nil {
+				log.Warn().Err(err).Msg("Stopped following daemon jobs")
+				return
+			}
+			if job.State.Done() {
+				tm.finishJob(tasks, job.State)
+				delete(adopted, jobID)
+			}
+		}
+		if _, err := tm.daemon.Jobs(); err != nil {
+			log.Warn().Err(err).Msg("Stopped following daemon jobs")
+			return
+		}
+	}
+}
+
+// ownsJob reports whether one of the tasks of this manager submitted the job
+func (tm *TaskManager) ownsJob(jobID string) bool {
+	// Wait for a submission in progress to assign its job
+	tm.submitting.Lock()
+	defer tm.submitting.Unlock()
 	
-	// Read stderr in background
-	go func() {
-		scanner := bufio.NewScanner(stderrPipe)
-		for scanner.Scan() {
-			line := scanner.Text()
-			fmt.Fprintf(output, "[stderr] %s\n", line)
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	for _, task := range tm.tasks {
+		task.mu.RLock()
+		owned := task.JobID == jobID
+		task.mu.RUnlock()
+		if owned {
+			return true
 		}
-	}()
+	}
+	return false
+}
+
+// isInstallJob reports whether a daemon job installs tools
+func (tm *TaskManager) isInstallJob(jobID string) bool {
+	job, err := tm.daemon.Job(jobID)
+	return err == nil && job.Action == daemon.ActionInstall
+}
+
+// applyJobEvent routes an event of an adopted job to the task of its tool.
+// The plan of the job creates the tasks.
+func (tm *TaskManager) applyJobEvent(jobID string, tasks map[string]*InstallTask, event orchestrator.Event) {
+	if event.Type == orchestrator.EventPlan {
+		if event.Plan == nil {
+			return
+		}
+		for _, tool := range event.Plan.Tools {
+			tasks[tool.Name] = tm.addJobTask(jobID, tool.Name, event.Plan.BuildType)
+		}
+		return
+	}
 	
-	// Wait for the command to complete
-	if err := cmd.Wait(); err != nil {
-		return fmt.Errorf("installation failed: %w", err)
+	task, found := tasks[event.Tool]
+	if !found {
+		return
+	}
+	tm.applyEvent(task, event, taskOutput{tm: tm, task: task})
+	if event.Type != orchestrator.EventToolFinished || event.Result == nil {
+		return
 	}
 	
-	return nil
+	task.mu.Lock()
+	task.EndTime = time.Now()
+	task.Progress = 1.0
+	task.Status = TaskStatusCompleted
+	if !event.Result.Success {
+		task.Status = TaskStatusFailed
+		task.Error = fmt.Errorf("%s", event.Result.Error)
+	}
+	update := TaskUpdateMsg{TaskID: task.ID, Status: task.Status, Progress: task.Progress, Error: task.Error}
+	task.mu.Unlock()
+	tm.sendUpdate(update)
+}
+
+// addJobTask adds a running task for a tool of an adopted job
+func (tm *TaskManager) addJobTask(jobID, toolName, buildType string) *InstallTask {
+	tool := orchestrator.ToolConfig{Name: toolName}
+	if tm.orchestrator != nil {
+		for _, configured := range tm.orchestrator.GetConfig().Tools {
+			if configured.Name == toolName {
+				tool = configured
+				break
+			}
+		}
+	}
+	
+	taskID := tm.AddTask(tool, buildType)
+	tm.mu.Lock()
+	task := tm.tasks[taskID]
+	task.JobID = jobID
+	task.Status = TaskStatusRunning // Runs in the daemon, not in a slot of this manager
+	task.Stage = fmt.Sprintf("Daemon job %s", jobID)
+	tm.mu.Unlock()
+	
+	tm.sendUpdate(TaskUpdateMsg{TaskID: taskID, Status: TaskStatusRunning, Stage: task.Stage})
+	return task
+}
+
+// finishJob ends the tasks of an adopted job that are still running
+func (tm *TaskManager) finishJob(tasks map[string]*InstallTask, state daemon.JobState) {
+	for _, task := range tasks {
+		task.mu.Lock()
+		if task.Status != TaskStatusRunning {
+			task.mu.Unlock()
+			continue
+		}
+		task.EndTime = time.Now()
+		switch state {
+		case daemon.JobSucceeded:
+			task.Status = TaskStatusCompleted
+			task.Progress = 1.0
+		case daemon.JobCancelled:
+			task.Status = TaskStatusCancelled
+		default:
+			task.Status = TaskStatusFailed
+			task.Error = fmt.Errorf("installation failed")
+		}
+		update := TaskUpdateMsg{TaskID: task.ID, Status: task.Status, Progress: task.Progress, Error: task.Error}
+		task.mu.Unlock()
+		tm.sendUpdate(update)
+	}
+}
+
+// taskOutput appends the output of an adopted job to its task
+type taskOutput struct {
+	tm   *TaskManager
+	task *InstallTask
+}
+
+// Write keeps the last 100 lines of output
+func (w taskOutput) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		w.task.mu.Lock()
+		w.task.Output = append(w.task.Output, line)
+		if len(w.task.Output) > 100 {
+			w.task.Output = w.task.Output[len(w.task.Output)-100:]
+		}
+		w.task.mu.Unlock()
+		
+		w.tm.sendUpdate(TaskUpdateMsg{TaskID: w.task.ID, Output: line})
+	}
+	return len(p), nil
 }
 
 // parseProgress attempts to extract progress from output line
@@ -469,6 +693,15 @@ func (tm *TaskManager) CancelTask(taskID string) error {
 		return fmt.Errorf("task is not running")
 	}
 	
+	// Daemon jobs are cancelled as a whole, with every tool they install
+	task.mu.RLock()
+	jobID := task.JobID
+	task.mu.RUnlock()
+	if jobID != "" && tm.daemon != nil {
+		_, err := tm.daemon.Cancel(jobID)
+		return err
+	}
+	
 	select {
 	case task.CancelChan <- true:
 		return nil
@@ -15,6 +15,7 @@ type Options struct {
 	DemoMode     bool   // Use mock data, simulate installations
 	TestMode     bool   // For automated testing
 	TestScenario string // Specific test scenario to run
+	Mirror       string // Offline mirror to install from, empty when online
 }
 
 // RunWithOptions starts the TUI with specific configuration
@@ -69,7 +70,7 @@ func NewModelWithOptions(opts Options) (TUIModel, error) {
 	}
 	
 	// Use regular model for normal operation
-	return NewModel()
+	return newModel(opts)
 }
 
 // NewDemoModel creates a TUI model with mock data for safe testing
@@ -330,7 +331,7 @@ func (m DemoModel) checkForHealthViewSwitch(previousView ViewType) tea.Cmd {
 	// Only trigger if we just switched TO health view (not already on it)
 	if previousView != ViewHealth && m.state.CurrentView == ViewHealth {
 		// Trigger health checks
-		return m.healthView.RunNextHealthCheck(0)
+		return m.healthView.RunHealthChecks()
 	}
 	
 	return nil
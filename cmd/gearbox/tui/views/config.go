@@ -115,7 +115,7 @@ func NewConfigView() *ConfigView {
 			{
 				Key:         "AUTO_UPDATE_PATH",
 				Value:       "true",
-				Description: "Automatically update PATH after installations",
+				Description: "Set up PATH and environment of installed tools in gearbox shell-init",
 				Type:        "boolean",
 				Editable:    true,
 			},
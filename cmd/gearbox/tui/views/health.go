@@ -1,11 +1,10 @@
 package views
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"runtime"
-	"strconv"
 	"strings"
 	"time"
 
@@ -13,49 +12,24 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"gearbox/pkg/health"
 	"gearbox/pkg/manifest"
 	"gearbox/pkg/orchestrator"
 )
 
-// Individual health check result messages - one per check (exported for app routing)
-type MemoryCheckCompleteMsg struct {
-	Result HealthCheckUpdate
-}
-
-type DiskCheckCompleteMsg struct {
-	Result HealthCheckUpdate
-}
-
-type InternetCheckCompleteMsg struct {
-	Result HealthCheckUpdate
-}
-
-type BuildToolsCheckCompleteMsg struct {
-	Result HealthCheckUpdate
-}
-
-type GitCheckCompleteMsg struct {
-	Result HealthCheckUpdate
-}
-
-type PathCheckCompleteMsg struct {
-	Result HealthCheckUpdate
-}
-
-type RustToolchainCheckCompleteMsg struct {
-	Result HealthCheckUpdate
-}
-
-type GoToolchainCheckCompleteMsg struct {
-	Result HealthCheckUpdate
+// HealthChecksCompleteMsg carries the reports of the registry checks
+// (exported for app routing)
+type HealthChecksCompleteMsg struct {
+	Reports []health.Report
 }
 
+// ToolUpdatesCheckCompleteMsg carries the result of the update check
 type ToolUpdatesCheckCompleteMsg struct {
 	Result HealthCheckUpdate
 }
 
 type HealthCheckUpdate struct {
-	Index       int
+	ID          string
 	Status      HealthStatus  
 	Message     string
 	Details     []string
@@ -71,6 +45,7 @@ type HealthView struct {
 	systemChecks   []HealthCheck
 	toolChecks     []HealthCheck
 	installedTools map[string]*manifest.InstallationRecord
+	checks         *health.Registry
 
 	// UI state
 	cursor         int
@@ -85,6 +60,7 @@ type HealthView struct {
 
 // HealthCheck represents a health check item
 type HealthCheck struct {
+	ID          string // Registry check, empty for facts that are not rerun
 	Name        string
 	Category    string
 	Status      HealthStatus
@@ -106,12 +82,14 @@ const (
 
 // NewHealthView creates a new health monitor view
 func NewHealthView() *HealthView {
+	checks := orchestrator.SystemHealthChecks()
 	return &HealthView{
 		installedTools: make(map[string]*manifest.InstallationRecord),
 		autoRefresh:    true,
 		showDetails:    true,
-		systemChecks:   initializeSystemChecks(),
-		toolChecks:     []HealthCheck{},
+		systemChecks:   initializeSystemChecks(checks),
+		toolChecks:     initializeToolChecks(checks),
+		checks:         checks,
 	}
 }
 
@@ -167,8 +145,7 @@ func (hv *HealthView) Update(msg tea.Msg) tea.Cmd {
 			if hv.ready {
 				hv.updateContent()
 			}
-			// Start health checks sequentially to avoid overwhelming the system
-			return hv.RunNextHealthCheck(0)
+			return hv.RunHealthChecks()
 		case "a":
 			hv.autoRefresh = !hv.autoRefresh
 			if hv.ready {
@@ -180,37 +157,14 @@ func (hv *HealthView) Update(msg tea.Msg) tea.Cmd {
 				hv.updateContent()
 			}
 		}
-	// Handle individual health check completion messages
-	case MemoryCheckCompleteMsg:
-		hv.applySystemCheckResult(msg.Result)
-		return nil
-	case DiskCheckCompleteMsg:
-		hv.applySystemCheckResult(msg.Result)
-		return nil
-	case InternetCheckCompleteMsg:
-		hv.applySystemCheckResult(msg.Result)
-		return nil
-	case BuildToolsCheckCompleteMsg:
-		hv.applySystemCheckResult(msg.Result)
-		return nil
-	case GitCheckCompleteMsg:
-		hv.applySystemCheckResult(msg.Result)
-		return nil
-	case PathCheckCompleteMsg:
-		hv.applySystemCheckResult(msg.Result)
-		return nil
-	case RustToolchainCheckCompleteMsg:
-		hv.applyToolCheckResult(msg.Result)
-		return nil
-	case GoToolchainCheckCompleteMsg:
-		hv.applyToolCheckResult(msg.Result)
+	case HealthChecksCompleteMsg:
+		for _, report := range msg.Reports {
+			hv.applyCheckResult(reportUpdate(report))
+		}
 		return nil
 	case ToolUpdatesCheckCompleteMsg:
-		hv.applyToolCheckResult(msg.Result)
+		hv.applyCheckResult(msg.Result)
 		return nil
-	case NextHealthCheckMsg:
-		// Continue with next health check in sequence
-		return hv.RunNextHealthCheck(msg.NextIndex)
 	}
 
 	return nil
@@ -426,8 +380,10 @@ func (hv *HealthView) renderHelpBar() string {
 
 // Helper methods
 
-func initializeSystemChecks() []HealthCheck {
-	return []HealthCheck{
+// initializeSystemChecks returns the facts about the machine and a row for
+// every system check of the registry
+func initializeSystemChecks(checks *health.Registry) []HealthCheck {
+	rows := []HealthCheck{
 		{
 			Name:     "Operating System",
 			Category: "system",
@@ -441,71 +397,53 @@ func initializeSystemChecks() []HealthCheck {
 			Status:   HealthStatusPassing,
 			Message:  fmt.Sprintf("%d cores available", runtime.NumCPU()),
 		},
-		{
-			Name:     "Memory",
-			Category: "system",
-			Status:   HealthStatusPending,
-			Message:  "Checking...",
-			Details:  []string{"Run health check to update"},
-		},
-		{
-			Name:     "Disk Space",
-			Category: "system",
-			Status:   HealthStatusPending,
-			Message:  "Checking...",
-			Details:  []string{"Run health check to update"},
-		},
-		{
-			Name:     "Internet Connection",
-			Category: "system",
-			Status:   HealthStatusPending,
-			Message:  "Checking...",
-		},
-		{
-			Name:     "Build Tools",
-			Category: "system",
-			Status:   HealthStatusPending,
-			Message:  "Checking gcc, make, cmake...",
-		},
-		{
-			Name:     "Git",
-			Category: "system",
-			Status:   HealthStatusPending,
-			Message:  "Checking version...",
-		},
-		{
-			Name:     "PATH Configuration",
-			Category: "system",
-			Status:   HealthStatusPending,
-			Message:  "Checking /usr/local/bin...",
-		},
 	}
+	for _, check := range checks.Checks() {
+		if check.Category() == health.CategorySystem {
+			rows = append(rows, pendingCheck(check.ID(), check.Category()))
+		}
+	}
+	return rows
 }
 
-func (hv *HealthView) updateToolChecks(tools []orchestrator.ToolConfig) {
-	// Initialize tool checks based on available toolchains and tools
-	hv.toolChecks = []HealthCheck{
-		{
-			Name:     "Rust Toolchain",
-			Category: "toolchain",
-			Status:   HealthStatusPending,
-			Message:  "Checking rustc, cargo...",
-		},
-		{
-			Name:     "Go Toolchain", 
-			Category: "toolchain",
-			Status:   HealthStatusPending,
-			Message:  "Checking go version...",
-		},
-		{
-			Name:     "Tool Updates",
-			Category: "tools",
-			Status:   HealthStatusPending,
-			Message:  "Checking for updates...",
-		},
+// initializeToolChecks returns a row for every other check of the registry,
+// and the update check
+func initializeToolChecks(checks *health.Registry) []HealthCheck {
+	var rows []HealthCheck
+	for _, check := range checks.Checks() {
+		if check.Category() != health.CategorySystem {
+			rows = append(rows, pendingCheck(check.ID(), check.Category()))
+		}
+	}
+	return append(rows, pendingCheck("tool-updates", "tools"))
+}
+
+// pendingCheck returns the row of a check that has not run yet
+func pendingCheck(id, category string) HealthCheck {
+	return HealthCheck{
+		ID:       id,
+		Name:     checkName(id),
+		Category: category,
+		Status:   HealthStatusPending,
+		Message:  "Checking...",
 	}
 }
 
+// checkName turns a check ID such as disk-space into a title
+func checkName(id string) string {
+	words := strings.Split(id, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func (hv *HealthView) updateToolChecks(tools []orchestrator.ToolConfig) {
+	hv.toolChecks = initializeToolChecks(hv.checks)
+}
+
 func (hv *HealthView) moveUp() {
 	if hv.cursor > 0 {
 		hv.cursor--
@@ -536,144 +474,40 @@ func (hv *HealthView) selectCheck() {
 	}
 }
 
-// Helper functions to apply individual health check results
-func (hv *HealthView) applySystemCheckResult(result HealthCheckUpdate) {
-	if result.Index >= 0 && result.Index < len(hv.systemChecks) {
-		hv.systemChecks[result.Index].Status = result.Status
-		hv.systemChecks[result.Index].Message = result.Message
-		hv.systemChecks[result.Index].Details = result.Details
-		hv.systemChecks[result.Index].Suggestions = result.Suggestions
-		
-		// Refresh content to show the updated check
-		if hv.ready {
-			hv.updateContent()
-		}
-	}
-}
+// applyCheckResult shows the result of the check with the ID of the update
+func (hv *HealthView) applyCheckResult(result HealthCheckUpdate) {
+	for _, rows := range [][]HealthCheck{hv.systemChecks, hv.toolChecks} {
+		for i := range rows {
+			if rows[i].ID != result.ID {
+				continue
+			}
+			rows[i].Status = result.Status
+			rows[i].Message = result.Message
+			rows[i].Details = result.Details
+			rows[i].Suggestions = result.Suggestions
 
-func (hv *HealthView) applyToolCheckResult(result HealthCheckUpdate) {
-	if result.Index >= 0 && result.Index < len(hv.toolChecks) {
-		hv.toolChecks[result.Index].Status = result.Status
-		hv.toolChecks[result.Index].Message = result.Message
-		hv.toolChecks[result.Index].Details = result.Details
-		hv.toolChecks[result.Index].Suggestions = result.Suggestions
-		
-		// Refresh content to show the updated check
-		if hv.ready {
-			hv.updateContent()
+			// Refresh content to show the updated check
+			if hv.ready {
+				hv.updateContent()
+			}
+			return
 		}
 	}
 }
 
-// RunNextHealthCheck runs health checks sequentially to avoid overwhelming the system
-func (hv *HealthView) RunNextHealthCheck(checkIndex int) tea.Cmd {
-	healthChecks := []func() tea.Cmd{
-		hv.RunMemoryCheckAsync,
-		hv.RunDiskCheckAsync,
-		hv.RunInternetCheckAsync,
-		hv.RunBuildToolsCheckAsync,
-		hv.RunGitCheckAsync,
-		hv.RunPathCheckAsync,
-		// Tool checks re-enabled
-		hv.RunRustToolchainCheckAsync,
-		hv.RunGoToolchainCheckAsync,
-		hv.RunToolUpdatesCheckAsync,
-	}
-	
-	if checkIndex >= len(healthChecks) {
-		// Log completion of all health checks
-		debugLog("DEBUG: RunNextHealthCheck() - All health checks completed (index %d >= %d)", checkIndex, len(healthChecks))
-		return nil // All checks completed
-	}
-	
-	// Log which health check is being started
-	checkNames := []string{"Memory", "Disk", "Internet", "Build Tools", "Git", "PATH", "Rust Toolchain", "Go Toolchain", "Tool Updates"}
-	checkName := "Unknown"
-	if checkIndex < len(checkNames) {
-		checkName = checkNames[checkIndex]
-	}
-	debugLog("DEBUG: RunNextHealthCheck() - Starting check %d: %s", checkIndex, checkName)
-	
-	// Run current check and trigger next one
+// RunHealthChecks runs the checks of the registry shared with gearbox doctor
+// concurrently, each bounded by its timeout, and the update check
+func (hv *HealthView) RunHealthChecks() tea.Cmd {
+	checks := hv.checks
 	return tea.Batch(
-		healthChecks[checkIndex](),
 		func() tea.Msg {
-			return NextHealthCheckMsg{NextIndex: checkIndex + 1}
+			debugLog("DEBUG: RunHealthChecks() - Running %d checks", len(checks.Checks()))
+			return HealthChecksCompleteMsg{Reports: checks.Run(context.Background(), health.DefaultTimeout)}
 		},
+		hv.RunToolUpdatesCheckAsync(),
 	)
 }
 
-type NextHealthCheckMsg struct {
-	NextIndex int
-}
-
-// CheckMemoryDirect performs memory check synchronously and returns result
-func (hv *HealthView) CheckMemoryDirect() HealthCheckUpdate {
-	return hv.checkMemory()
-}
-
-// CheckToolUpdatesDirect performs tool updates check synchronously and returns result
-func (hv *HealthView) CheckToolUpdatesDirect() HealthCheckUpdate {
-	return hv.checkToolUpdates()
-}
-
-// Individual asynchronous health check commands - each runs independently
-func (hv *HealthView) RunMemoryCheckAsync() tea.Cmd {
-	return func() tea.Msg {
-		result := hv.checkMemory()
-		return MemoryCheckCompleteMsg{Result: result}
-	}
-}
-
-func (hv *HealthView) RunDiskCheckAsync() tea.Cmd {
-	return func() tea.Msg {
-		result := hv.checkDiskSpace()
-		return DiskCheckCompleteMsg{Result: result}
-	}
-}
-
-func (hv *HealthView) RunInternetCheckAsync() tea.Cmd {
-	return func() tea.Msg {
-		result := hv.checkInternet()
-		return InternetCheckCompleteMsg{Result: result}
-	}
-}
-
-func (hv *HealthView) RunBuildToolsCheckAsync() tea.Cmd {
-	return func() tea.Msg {
-		result := hv.checkBuildTools()
-		return BuildToolsCheckCompleteMsg{Result: result}
-	}
-}
-
-func (hv *HealthView) RunGitCheckAsync() tea.Cmd {
-	return func() tea.Msg {
-		result := hv.checkGit()
-		return GitCheckCompleteMsg{Result: result}
-	}
-}
-
-func (hv *HealthView) RunPathCheckAsync() tea.Cmd {
-	return func() tea.Msg {
-		result := hv.checkPATH()
-		return PathCheckCompleteMsg{Result: result}
-	}
-}
-
-func (hv *HealthView) RunRustToolchainCheckAsync() tea.Cmd {
-	return func() tea.Msg {
-		result := hv.checkRustToolchain()
-		return RustToolchainCheckCompleteMsg{Result: result}
-	}
-}
-
-func (hv *HealthView) RunGoToolchainCheckAsync() tea.Cmd {
-	return func() tea.Msg {
-		result := hv.checkGoToolchain()
-		return GoToolchainCheckCompleteMsg{Result: result}
-	}
-}
-
 func (hv *HealthView) RunToolUpdatesCheckAsync() tea.Cmd {
 	return func() tea.Msg {
 		result := hv.checkToolUpdates()
@@ -682,329 +516,39 @@ func (hv *HealthView) RunToolUpdatesCheckAsync() tea.Cmd {
 }
 
 func (hv *HealthView) resetChecksToChecking() {
-	// Reset dynamic system checks (Memory=2, Disk=3, Internet=4, Build Tools=5, Git=6, PATH=7)
-	dynamicSystemIndices := []int{2, 3, 4, 5, 6, 7}
-	for _, i := range dynamicSystemIndices {
-		if i < len(hv.systemChecks) {
-			hv.systemChecks[i].Status = HealthStatusPending
-			hv.systemChecks[i].Message = "Checking..."
-			hv.systemChecks[i].Details = nil
-			hv.systemChecks[i].Suggestions = nil
-		}
-	}
-	
-	// Reset all tool checks
-	for i := range hv.toolChecks {
-		hv.toolChecks[i].Status = HealthStatusPending
-		hv.toolChecks[i].Message = "Checking..."
-		hv.toolChecks[i].Details = nil
-		hv.toolChecks[i].Suggestions = nil
-	}
-}
-
-// Actual health check implementations
-
-func (hv *HealthView) checkMemory() HealthCheckUpdate {
-	result := HealthCheckUpdate{Index: 2}
-	
-	// Read memory info from /proc/meminfo
-	data, err := os.ReadFile("/proc/meminfo")
-	if err != nil {
-		result.Status = HealthStatusWarning
-		result.Message = "Could not read memory info"
-		return result
-	}
-
-	lines := strings.Split(string(data), "\n")
-	var totalKB, availableKB int
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, "MemTotal:") {
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				if val, err := strconv.Atoi(parts[1]); err == nil {
-					totalKB = val
-				}
-			}
-		} else if strings.HasPrefix(line, "MemAvailable:") {
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				if val, err := strconv.Atoi(parts[1]); err == nil {
-					availableKB = val
-				}
-			}
-		}
-	}
-
-	if totalKB > 0 && availableKB > 0 {
-		totalGB := float64(totalKB) / 1024 / 1024
-		availableGB := float64(availableKB) / 1024 / 1024
-		usedGB := totalGB - availableGB
-		usagePercent := (usedGB / totalGB) * 100
-
-		result.Status = HealthStatusPassing
-		if usagePercent > 90 {
-			result.Status = HealthStatusWarning
-		}
-		
-		result.Message = fmt.Sprintf("%.1f GB available", availableGB)
-		result.Details = []string{
-			fmt.Sprintf("Total: %.1f GB", totalGB),
-			fmt.Sprintf("Used: %.1f GB (%.0f%%)", usedGB, usagePercent),
-			fmt.Sprintf("Available: %.1f GB", availableGB),
-		}
-	} else {
-		result.Status = HealthStatusWarning
-		result.Message = "Could not parse memory info"
-	}
-	
-	return result
-}
-
-func (hv *HealthView) checkDiskSpace() HealthCheckUpdate {
-	result := HealthCheckUpdate{Index: 3}
-	
-	// Check disk space for current directory
-	cmd := exec.Command("df", "-h", ".")
-	output, err := cmd.Output()
-	if err != nil {
-		result.Status = HealthStatusWarning
-		result.Message = "Could not check disk space"
-		return result
-	}
-
-	lines := strings.Split(string(output), "\n")
-	if len(lines) >= 2 {
-		fields := strings.Fields(lines[1])
-		if len(fields) >= 5 {
-			usage := strings.TrimSuffix(fields[4], "%")
-			if usageInt, err := strconv.Atoi(usage); err == nil {
-				if usageInt > 90 {
-					result.Status = HealthStatusWarning
-					result.Message = fmt.Sprintf("Low disk space (%d%% used)", usageInt)
-					result.Suggestions = []string{
-						"Consider cleaning build cache",
-						"Remove unused tools or files",
-					}
-				} else {
-					result.Status = HealthStatusPassing
-					result.Message = fmt.Sprintf("%s available (%d%% used)", fields[3], usageInt)
-				}
-				result.Details = []string{
-					fmt.Sprintf("Filesystem: %s", fields[0]),
-					fmt.Sprintf("Size: %s", fields[1]),
-					fmt.Sprintf("Used: %s", fields[2]),
-					fmt.Sprintf("Available: %s", fields[3]),
-				}
-			}
-		}
-	}
-	
-	return result
-}
-
-func (hv *HealthView) checkInternet() HealthCheckUpdate {
-	result := HealthCheckUpdate{Index: 4}
-	
-	// Test internet connectivity
-	cmd := exec.Command("ping", "-c", "1", "-W", "3", "github.com")
-	err := cmd.Run()
-	
-	if err != nil {
-		result.Status = HealthStatusWarning
-		result.Message = "No internet connection"
-		result.Suggestions = []string{
-			"Check network connection",
-			"Some installs may fail without internet",
-		}
-	} else {
-		result.Status = HealthStatusPassing
-		result.Message = "Connected"
-	}
-	
-	return result
-}
-
-func (hv *HealthView) checkBuildTools() HealthCheckUpdate {
-	result := HealthCheckUpdate{Index: 5}
-	var details []string
-	var missing []string
-	
-	tools := map[string]string{
-		"gcc":   "--version",
-		"make":  "--version", 
-		"cmake": "--version",
-	}
-
-	allPresent := true
-	for tool, flag := range tools {
-		cmd := exec.Command(tool, flag)
-		output, err := cmd.Output()
-		if err != nil {
-			missing = append(missing, tool)
-			allPresent = false
-		} else {
-			lines := strings.Split(string(output), "\n")
-			if len(lines) > 0 {
-				// Extract version from first line
-				firstLine := strings.TrimSpace(lines[0])
-				if len(firstLine) > 50 {
-					firstLine = firstLine[:50] + "..."
-				}
-				details = append(details, firstLine)
+	// Reset every check that runs again, the facts about the machine stay
+	for _, rows := range [][]HealthCheck{hv.systemChecks, hv.toolChecks} {
+		for i := range rows {
+			if rows[i].ID == "" {
+				continue
 			}
+			rows[i].Status = HealthStatusPending
+			rows[i].Message = "Checking..."
+			rows[i].Details = nil
+			rows[i].Suggestions = nil
 		}
 	}
-
-	if allPresent {
-		result.Status = HealthStatusPassing
-		result.Message = "All required build tools installed"
-		result.Details = details
-	} else {
-		result.Status = HealthStatusWarning
-		result.Message = fmt.Sprintf("Missing tools: %s", strings.Join(missing, ", "))
-		result.Suggestions = []string{
-			"Install build essentials: sudo apt install build-essential",
-			"Install cmake: sudo apt install cmake",
-		}
-	}
-	
-	return result
 }
 
-func (hv *HealthView) checkGit() HealthCheckUpdate {
-	result := HealthCheckUpdate{Index: 6}
-	
-	cmd := exec.Command("git", "--version")
-	output, err := cmd.Output()
-	if err != nil {
-		result.Status = HealthStatusWarning
-		result.Message = "Git not installed"
-		result.Suggestions = []string{
-			"Install git: sudo apt install git",
-		}
-	} else {
-		version := strings.TrimSpace(string(output))
+// reportUpdate converts the report of a registry check for its row
+func reportUpdate(report health.Report) HealthCheckUpdate {
+	result := HealthCheckUpdate{ID: report.ID}
+	switch report.Severity {
+	case health.SeverityOK:
 		result.Status = HealthStatusPassing
-		result.Message = version
-	}
-	
-	return result
-}
-
-func (hv *HealthView) checkPATH() HealthCheckUpdate {
-	result := HealthCheckUpdate{Index: 7}
-	
-	path := os.Getenv("PATH")
-	pathDirs := strings.Split(path, ":")
-	
-	var details []string
-	hasUsrLocal := false
-	hasCargo := false
-	
-	for _, dir := range pathDirs {
-		if dir == "/usr/local/bin" {
-			hasUsrLocal = true
-			details = append(details, "/usr/local/bin is in PATH")
-		}
-		if strings.Contains(dir, ".cargo/bin") {
-			hasCargo = true
-			details = append(details, "~/.cargo/bin is in PATH")
-		}
-	}
-	
-	if hasUsrLocal && hasCargo {
-		result.Status = HealthStatusPassing
-		result.Message = "Correctly configured"
-	} else if hasUsrLocal {
+	case health.SeverityWarning:
 		result.Status = HealthStatusWarning
-		result.Message = "Missing ~/.cargo/bin in PATH"
-		result.Suggestions = []string{
-			"Add ~/.cargo/bin to PATH for Rust tools",
-		}
-	} else {
-		result.Status = HealthStatusWarning
-		result.Message = "PATH may need configuration"
-		result.Suggestions = []string{
-			"Ensure /usr/local/bin is in PATH",
-			"Add ~/.cargo/bin to PATH for Rust tools",
-		}
+	default:
+		result.Status = HealthStatusFailing
 	}
-	
-	result.Details = details
-	return result
-}
-
-func (hv *HealthView) checkRustToolchain() HealthCheckUpdate {
-	result := HealthCheckUpdate{Index: 0}
-	
-	cmd := exec.Command("rustc", "--version")
-	output, err := cmd.Output()
-	if err != nil {
-		result.Status = HealthStatusWarning
-		result.Message = "Rust not installed"
-		result.Suggestions = []string{
-			"Install Rust: curl --proto '=https' --tlsv1.2 -sSf https://sh.rustup.rs | sh",
-		}
-		return result
-	}
-
-	rustcVersion := strings.TrimSpace(string(output))
-	result.Status = HealthStatusPassing
-	result.Message = rustcVersion
-
-	// Check cargo and rustup
-	var details []string
-	details = append(details, rustcVersion)
-
-	if cargoCmd := exec.Command("cargo", "--version"); cargoCmd.Run() == nil {
-		if cargoOutput, err := cargoCmd.Output(); err == nil {
-			details = append(details, strings.TrimSpace(string(cargoOutput)))
-		}
-	}
-
-	if rustupCmd := exec.Command("rustup", "--version"); rustupCmd.Run() == nil {
-		if rustupOutput, err := rustupCmd.Output(); err == nil {
-			details = append(details, strings.TrimSpace(string(rustupOutput)))
-		}
-	}
-
-	result.Details = details
-	return result
-}
-
-func (hv *HealthView) checkGoToolchain() HealthCheckUpdate {
-	result := HealthCheckUpdate{Index: 1}
-	
-	cmd := exec.Command("go", "version")
-	output, err := cmd.Output()
-	if err != nil {
-		result.Status = HealthStatusWarning
-		result.Message = "Go not installed"
-		result.Suggestions = []string{
-			"Install Go from https://golang.org/dl/",
-		}
-		return result
-	}
-
-	goVersion := strings.TrimSpace(string(output))
-	result.Status = HealthStatusPassing
-	result.Message = goVersion
-
-	var details []string
-	if gopath := os.Getenv("GOPATH"); gopath != "" {
-		details = append(details, "GOPATH: "+gopath)
-	}
-	if goroot := os.Getenv("GOROOT"); goroot != "" {
-		details = append(details, "GOROOT: "+goroot)
-	}
-
-	result.Details = details
+	result.Message = report.Message
+	result.Details = report.Details
+	result.Suggestions = report.Remediation
 	return result
 }
 
 func (hv *HealthView) checkToolUpdates() HealthCheckUpdate {
-	result := HealthCheckUpdate{Index: 2}
+	result := HealthCheckUpdate{ID: "tool-updates"}
 	
 	// Simple check - in a real implementation this would check for tool updates
 	result.Status = HealthStatusPassing
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"gearbox/pkg/health"
 	"gearbox/pkg/manifest"
 	"gearbox/pkg/orchestrator"
 )
@@ -22,38 +23,25 @@ func TestHealthView_AsyncHealthCheckExecution(t *testing.T) {
 	
 	hv.SetData(tools, installed)
 	
-	// Test individual async health checks
-	memoryCmd := hv.RunMemoryCheckAsync()
-	memoryMsg := memoryCmd()
+	// Run the health checks and apply their results
+	runHealthChecks(t, hv)
 	
-	if memMsg, ok := memoryMsg.(MemoryCheckCompleteMsg); ok {
-		// Should have a valid result
-		if memMsg.Result.Message == "" {
-			t.Error("Expected memory check result message")
-		}
-		
-		// Apply the result
-		hv.Update(memMsg)
-		
-		// Verify that memory check was updated
-		if hv.systemChecks[2].Message == "Checking..." {
-			t.Error("Memory check still shows 'Checking...' after async update")
-		}
-		
-		t.Logf("Successfully updated memory check: %s", hv.systemChecks[2].Message)
-	} else {
-		t.Errorf("Expected MemoryCheckCompleteMsg, got %T", memoryMsg)
+	memory := findCheck(t, hv, "memory")
+	if memory.Message == "" || memory.Message == "Checking..." {
+		t.Errorf("Memory check still shows '%s' after async update", memory.Message)
 	}
+	t.Logf("Successfully updated memory check: %s", memory.Message)
 }
 
 func TestHealthView_RealHealthCheckExecution(t *testing.T) {
 	hv := NewHealthView()
 	hv.SetSize(80, 24)
 	
-	// Test individual real health checks
-	memoryResult := hv.checkMemory()
-	diskResult := hv.checkDiskSpace()
-	internetResult := hv.checkInternet()
+	// Run the real health checks
+	runHealthChecks(t, hv)
+	memoryResult := findCheck(t, hv, "memory")
+	diskResult := findCheck(t, hv, "disk-space")
+	internetResult := findCheck(t, hv, "internet")
 	
 	// Check that results contain real data, not "Checking..."
 	if memoryResult.Message == "Checking..." || memoryResult.Message == "" {
@@ -82,42 +70,34 @@ func TestHealthView_ThreadSafeUpdates(t *testing.T) {
 	hv := NewHealthView()
 	hv.SetSize(80, 24)
 	
-	// Create individual health check result messages
-	memoryMsg := MemoryCheckCompleteMsg{
-		Result: HealthCheckUpdate{
-			Index: 2, 
-			Status: HealthStatusPassing, 
-			Message: "Mock memory result",
-		},
-	}
-	
-	diskMsg := DiskCheckCompleteMsg{
-		Result: HealthCheckUpdate{
-			Index: 3, 
-			Status: HealthStatusWarning, 
-			Message: "Mock disk result",
+	// Create health check reports
+	msg := HealthChecksCompleteMsg{
+		Reports: []health.Report{
+			{ID: "memory", Result: health.Result{Severity: health.SeverityOK, Message: "Mock memory result"}},
+			{ID: "disk-space", Result: health.Result{Severity: health.SeverityWarning, Message: "Mock disk result"}},
 		},
 	}
 	
-	// Apply results via individual messages (simulates thread-safe async updates)
-	hv.Update(memoryMsg)
-	hv.Update(diskMsg)
+	// Apply results via the message (simulates thread-safe async updates)
+	hv.Update(msg)
 	
 	// Verify updates were applied correctly
-	if hv.systemChecks[2].Message != "Mock memory result" {
-		t.Errorf("Memory result not applied, got '%s'", hv.systemChecks[2].Message)
+	memory := findCheck(t, hv, "memory")
+	if memory.Message != "Mock memory result" {
+		t.Errorf("Memory result not applied, got '%s'", memory.Message)
 	}
 	
-	if hv.systemChecks[2].Status != HealthStatusPassing {
-		t.Errorf("Memory status not applied, got %v", hv.systemChecks[2].Status)
+	if memory.Status != HealthStatusPassing {
+		t.Errorf("Memory status not applied, got %v", memory.Status)
 	}
 	
-	if hv.systemChecks[3].Message != "Mock disk result" {
-		t.Errorf("Disk result not applied, got '%s'", hv.systemChecks[3].Message)
+	disk := findCheck(t, hv, "disk-space")
+	if disk.Message != "Mock disk result" {
+		t.Errorf("Disk result not applied, got '%s'", disk.Message)
 	}
 	
-	if hv.systemChecks[3].Status != HealthStatusWarning {
-		t.Errorf("Disk status not applied, got %v", hv.systemChecks[3].Status)
+	if disk.Status != HealthStatusWarning {
+		t.Errorf("Disk status not applied, got %v", disk.Status)
 	}
 }
 
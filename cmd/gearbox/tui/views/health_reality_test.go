@@ -49,53 +49,15 @@ func TestHealthView_RealityCheck(t *testing.T) {
 		t.Fatal("Expected health check commands after 'r' key, got nil")
 	}
 	
-	// Execute the individual async commands and apply their results
-	// This simulates each health check completing asynchronously
-	
-	// Test memory check
-	memoryCmd := hv.RunMemoryCheckAsync()
-	memoryMsg := memoryCmd()
-	if memMsg, ok := memoryMsg.(MemoryCheckCompleteMsg); ok {
-		t.Logf("Memory check: '%s' (status: %v)", memMsg.Result.Message, memMsg.Result.Status)
-		hv.Update(memMsg)
-	}
-	
-	// Test disk check 
-	diskCmd := hv.RunDiskCheckAsync()
-	diskMsg := diskCmd()
-	if dMsg, ok := diskMsg.(DiskCheckCompleteMsg); ok {
-		t.Logf("Disk check: '%s' (status: %v)", dMsg.Result.Message, dMsg.Result.Status)
-		hv.Update(dMsg)
-	}
-	
-	// Test internet check
-	internetCmd := hv.RunInternetCheckAsync()
-	internetMsg := internetCmd()
-	if iMsg, ok := internetMsg.(InternetCheckCompleteMsg); ok {
-		t.Logf("Internet check: '%s' (status: %v)", iMsg.Result.Message, iMsg.Result.Status)
-		hv.Update(iMsg)
-	}
-	
-	// Test remaining checks
-	buildCmd := hv.RunBuildToolsCheckAsync()
-	buildMsg := buildCmd()
-	if bMsg, ok := buildMsg.(BuildToolsCheckCompleteMsg); ok {
-		t.Logf("Build tools check: '%s' (status: %v)", bMsg.Result.Message, bMsg.Result.Status)
-		hv.Update(bMsg)
-	}
-	
-	gitCmd := hv.RunGitCheckAsync()
-	gitMsg := gitCmd()
-	if gMsg, ok := gitMsg.(GitCheckCompleteMsg); ok {
-		t.Logf("Git check: '%s' (status: %v)", gMsg.Result.Message, gMsg.Result.Status)
-		hv.Update(gMsg)
+	// Execute the commands and apply their results, as the TUI does
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Expected a batch of health check commands")
 	}
-	
-	pathCmd := hv.RunPathCheckAsync()
-	pathMsg := pathCmd()
-	if pMsg, ok := pathMsg.(PathCheckCompleteMsg); ok {
-		t.Logf("PATH check: '%s' (status: %v)", pMsg.Result.Message, pMsg.Result.Status)
-		hv.Update(pMsg)
+	for _, check := range batch {
+		msg := check()
+		t.Logf("Health check result: %T", msg)
+		hv.Update(msg)
 	}
 	
 	// Render again - this should show the updated results
@@ -130,7 +92,7 @@ func TestHealthView_RealityCheck(t *testing.T) {
 			diskWorking = true
 			t.Logf("Disk check is working: %s", line)
 		}
-		if containsString(line, "Internet Connection") && !containsString(line, "Checking...") {
+		if containsString(line, "Internet") && !containsString(line, "Checking...") {
 			internetWorking = true
 			t.Logf("Internet check is working: %s", line)
 		}
@@ -143,35 +105,50 @@ func TestHealthView_RealityCheck(t *testing.T) {
 		t.Error("Disk Space check is still showing 'Checking...' - this matches the user's report!")
 	}
 	if !internetWorking {
-		t.Error("Internet Connection check is still showing 'Checking...' - this matches the user's report!")
+		t.Error("Internet check is still showing 'Checking...' - this matches the user's report!")
 	}
 }
 
-// TestHealthView_DirectFunctionCalls - This tests the functions directly (should pass)
-func TestHealthView_DirectFunctionCalls(t *testing.T) {
+// TestHealthView_RowsFollowRegistry - Every check of the registry has one row
+func TestHealthView_RowsFollowRegistry(t *testing.T) {
 	hv := NewHealthView()
-	hv.SetSize(80, 24)
-	
-	// Call the functions directly - these should work
-	memResult := hv.checkMemory()
-	t.Logf("Direct memory check: Index=%d, Message='%s', Status=%v", memResult.Index, memResult.Message, memResult.Status)
-	
-	diskResult := hv.checkDiskSpace()
-	t.Logf("Direct disk check: Index=%d, Message='%s', Status=%v", diskResult.Index, diskResult.Message, diskResult.Status)
-	
-	internetResult := hv.checkInternet()
-	t.Logf("Direct internet check: Index=%d, Message='%s', Status=%v", internetResult.Index, internetResult.Message, internetResult.Status)
-	
-	// These should all return real data
-	if memResult.Message == "Checking..." || memResult.Message == "" {
-		t.Errorf("Direct memory check failed: %s", memResult.Message)
+	hv.SetData(nil, nil)
+
+	for _, check := range hv.checks.Checks() {
+		count := 0
+		for _, row := range append(append([]HealthCheck(nil), hv.systemChecks...), hv.toolChecks...) {
+			if row.ID == check.ID() {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Errorf("Check %s has %d rows, expected 1", check.ID(), count)
+		}
 	}
-	if diskResult.Message == "Checking..." || diskResult.Message == "" {
-		t.Errorf("Direct disk check failed: %s", diskResult.Message) 
+}
+
+// runHealthChecks runs the checks of the view and applies their results
+func runHealthChecks(t *testing.T, hv *HealthView) {
+	t.Helper()
+	batch, ok := hv.RunHealthChecks()().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Expected a batch of health check commands")
+	}
+	for _, cmd := range batch {
+		hv.Update(cmd())
 	}
-	if internetResult.Message == "Checking..." || internetResult.Message == "" {
-		t.Errorf("Direct internet check failed: %s", internetResult.Message)
+}
+
+// findCheck returns the row of the check with an ID
+func findCheck(t *testing.T, hv *HealthView, id string) HealthCheck {
+	t.Helper()
+	for _, row := range append(append([]HealthCheck(nil), hv.systemChecks...), hv.toolChecks...) {
+		if row.ID == id {
+			return row
+		}
 	}
+	t.Fatalf("No row for check %s", id)
+	return HealthCheck{}
 }
 
 // Helper functions
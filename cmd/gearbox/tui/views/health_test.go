@@ -5,6 +5,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"gearbox/pkg/health"
 )
 
 func TestHealthView_Initialization(t *testing.T) {
@@ -66,42 +67,20 @@ func TestHealthView_HealthCheckUpdates(t *testing.T) {
 	hv := NewHealthView()
 	hv.SetSize(80, 24)
 	
-	// Test memory check
-	memResult := hv.checkMemory()
-	if memResult.Index != 2 {
-		t.Errorf("Memory check should return index 2, got %d", memResult.Index)
+	// Reports of the registry are converted for the row of their check
+	memResult := reportUpdate(health.Report{ID: "memory", Result: health.Result{Severity: health.SeverityOK, Message: "4 GB available"}})
+	if memResult.ID != "memory" || memResult.Status != HealthStatusPassing {
+		t.Errorf("Memory report converted to %+v", memResult)
 	}
 	
-	// Memory check should return valid status
-	if memResult.Status != HealthStatusPassing && memResult.Status != HealthStatusWarning {
-		t.Errorf("Memory check should return passing or warning, got %v", memResult.Status)
+	diskResult := reportUpdate(health.Report{ID: "disk-space", Result: health.Result{Severity: health.SeverityWarning}})
+	if diskResult.Status != HealthStatusWarning {
+		t.Errorf("Disk warning should be a warning, got %v", diskResult.Status)
 	}
 	
-	// Should have meaningful message
-	if memResult.Message == "" || memResult.Message == "Checking..." {
-		t.Errorf("Memory check should return real message, got '%s'", memResult.Message)
-	}
-	
-	// Test disk space check
-	diskResult := hv.checkDiskSpace()
-	if diskResult.Index != 3 {
-		t.Errorf("Disk check should return index 3, got %d", diskResult.Index)
-	}
-	
-	// Should return valid status
-	if diskResult.Status != HealthStatusPassing && diskResult.Status != HealthStatusWarning {
-		t.Errorf("Disk check should return passing or warning, got %v", diskResult.Status)
-	}
-	
-	// Test internet check
-	internetResult := hv.checkInternet()
-	if internetResult.Index != 4 {
-		t.Errorf("Internet check should return index 4, got %d", internetResult.Index)
-	}
-	
-	// Should return valid status (might be warning if no internet)
-	if internetResult.Status != HealthStatusPassing && internetResult.Status != HealthStatusWarning {
-		t.Errorf("Internet check should return passing or warning, got %v", internetResult.Status)
+	rustResult := reportUpdate(health.Report{ID: "rust", Result: health.Result{Severity: health.SeverityCritical, Remediation: []string{"Install rustup"}}})
+	if rustResult.Status != HealthStatusFailing || len(rustResult.Suggestions) != 1 {
+		t.Errorf("Critical report converted to %+v", rustResult)
 	}
 }
 
@@ -109,50 +88,31 @@ func TestHealthView_MessageHandling(t *testing.T) {
 	hv := NewHealthView()
 	hv.SetSize(80, 24)
 	
-	// Create mock health check results
-	systemResults := []HealthCheckUpdate{
-		{
-			Index:   2,
-			Status:  HealthStatusPassing,
-			Message: "Test memory result",
-			Details: []string{"Detail 1", "Detail 2"},
+	// Send the reports of a run
+	hv.Update(HealthChecksCompleteMsg{
+		Reports: []health.Report{
+			{ID: "memory", Result: health.Result{Severity: health.SeverityOK, Message: "Test memory result", Details: []string{"Detail 1", "Detail 2"}}},
+			{ID: "rust", Result: health.Result{Severity: health.SeverityWarning, Message: "Test tool result", Remediation: []string{"Suggestion 1"}}},
 		},
-	}
-	
-	toolResults := []HealthCheckUpdate{
-		{
-			Index:   0,
-			Status:  HealthStatusWarning,
-			Message: "Test tool result",
-			Suggestions: []string{"Suggestion 1"},
-		},
-	}
-	
-	// Send individual health check complete messages
-	memoryMsg := MemoryCheckCompleteMsg{Result: systemResults[0]}
-	toolMsg := RustToolchainCheckCompleteMsg{Result: toolResults[0]}
-	
-	hv.Update(memoryMsg)
-	hv.Update(toolMsg)
+	})
 	
 	// Verify results were applied
-	if hv.systemChecks[2].Status != HealthStatusPassing {
-		t.Errorf("System check 2 status not updated, got %v", hv.systemChecks[2].Status)
+	memory := findCheck(t, hv, "memory")
+	if memory.Status != HealthStatusPassing {
+		t.Errorf("Memory check status not updated, got %v", memory.Status)
 	}
 	
-	if hv.systemChecks[2].Message != "Test memory result" {
-		t.Errorf("System check 2 message not updated, got '%s'", hv.systemChecks[2].Message)
+	if memory.Message != "Test memory result" {
+		t.Errorf("Memory check message not updated, got '%s'", memory.Message)
 	}
 	
-	if len(hv.systemChecks[2].Details) != 2 {
-		t.Errorf("System check 2 details not updated, got %d details", len(hv.systemChecks[2].Details))
+	if len(memory.Details) != 2 {
+		t.Errorf("Memory check details not updated, got %d details", len(memory.Details))
 	}
 	
 	// Check tool results too
-	if len(hv.toolChecks) > 0 {
-		if hv.toolChecks[0].Status != HealthStatusWarning {
-			t.Errorf("Tool check 0 status not updated, got %v", hv.toolChecks[0].Status)
-		}
+	if rust := findCheck(t, hv, "rust"); rust.Status != HealthStatusWarning || len(rust.Suggestions) != 1 {
+		t.Errorf("Rust check not updated, got %v with %d suggestions", rust.Status, len(rust.Suggestions))
 	}
 }
 
@@ -223,9 +183,10 @@ func TestHealthView_RealHealthChecksReturnData(t *testing.T) {
 	hv.SetSize(80, 24)
 	
 	// Test that real health checks return actual data, not mocks
+	runHealthChecks(t, hv)
 	
 	// Memory check should read real /proc/meminfo
-	memResult := hv.checkMemory()
+	memResult := findCheck(t, hv, "memory")
 	if memResult.Message == "Checking..." || memResult.Message == "" {
 		t.Error("Memory check should return real memory information")
 	}
@@ -235,15 +196,15 @@ func TestHealthView_RealHealthChecksReturnData(t *testing.T) {
 		t.Error("Memory check should return memory usage details")
 	}
 	
-	// Disk check should run real 'df' command
-	diskResult := hv.checkDiskSpace()
+	// Disk check should look at the real file systems
+	diskResult := findCheck(t, hv, "disk-space")
 	if diskResult.Message == "Checking..." || diskResult.Message == "" {
 		// Only fail if we can't get any disk info (might fail in some test environments)
 		t.Logf("Warning: Disk check returned no data (might be expected in test environment)")
 	}
 	
-	// Internet check should actually try to ping
-	internetResult := hv.checkInternet()
+	// Internet check should actually try to connect
+	internetResult := findCheck(t, hv, "internet")
 	if internetResult.Message == "Checking..." || internetResult.Message == "" {
 		t.Error("Internet check should return connection status")
 	}
@@ -311,6 +311,11 @@ func (im *InstallManagerNew) renderTaskItem(task *TaskInfo, selected bool) strin
 		progress = "Failed"
 	}
 	
+	// Expected remaining build time
+	if task.ETA != "" && (task.Status == TaskStatusPending || task.Status == TaskStatusRunning) {
+		progress = strings.TrimSpace(progress + " (" + task.ETA + ")")
+	}
+	
 	item := fmt.Sprintf("%s %s %s %s",
 		statusStyle.Render(statusIcon),
 		name,
@@ -337,6 +342,10 @@ func (im *InstallManagerNew) renderTaskDetails(task *TaskInfo) []string {
 		details = append(details, fmt.Sprintf("%sDuration: %s", indent, task.Duration))
 	}
 	
+	if task.ETA != "" && (task.Status == TaskStatusPending || task.Status == TaskStatusRunning) {
+		details = append(details, fmt.Sprintf("%sEstimated: %s", indent, task.ETA))
+	}
+	
 	// Progress for running tasks
 	if task.Status == TaskStatusRunning {
 		if task.Progress > 0 {
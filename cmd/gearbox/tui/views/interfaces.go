@@ -45,5 +45,6 @@ type TaskInfo struct {
 	StartTime  string
 	EndTime    string
 	Duration   string
+	ETA        string // Expected remaining build time, empty when unknown
 	Error      error
 }
\ No newline at end of file
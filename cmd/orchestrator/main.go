@@ -1,9 +1,12 @@
 package main
 
 import (
+	"gearbox/pkg/daemon"
 	"gearbox/pkg/orchestrator"
 )
 
 func main() {
+	// Reinstalls of sync and doctor queue behind the jobs of a running daemon
+	orchestrator.DaemonInstaller = daemon.Install
 	orchestrator.Main()
-}
\ No newline at end of file
+}
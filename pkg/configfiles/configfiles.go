@@ -0,0 +1,199 @@
+// Package configfiles installs the default configuration files of tools from
+// templates, such as the bat theme or starship.toml. Templates ship with the
+// repository under config/templates and can be overridden per file under
+// ~/.gearbox/config-templates.
+//
+// Files the user changed are never overwritten. gearbox keeps a copy of every
+// file it installed, so an update can tell the user's changes from its own
+// and show both against that copy.
+package configfiles
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+)
+
+const (
+	// OverrideDir holds user templates below the gearbox directory, which
+	// take precedence over the templates of the repository
+	OverrideDir = "config-templates"
+	// StateDir holds copies of the installed files below the gearbox
+	// directory, the base of three-way diffs
+	StateDir = "configs"
+)
+
+// Template declares a configuration file of a tool in tools.json
+type Template struct {
+	Source string `json:"source"` // Template path relative to the template directories
+	Target string `json:"target"` // Installed path, a template such as {{.ConfigHome}}/bat/config
+}
+
+// Data is available to templates and targets
+type Data struct {
+	Tool       string
+	Home       string
+	ConfigHome string // $XDG_CONFIG_HOME or ~/.config
+}
+
+// Action is what happened to a configuration file
+type Action string
+
+const (
+	ActionCreated   Action = "created"   // The file did not exist
+	ActionUpdated   Action = "updated"   // The template changed and the user had not
+	ActionUnchanged Action = "unchanged" // The file is up to date
+	ActionKept      Action = "kept"      // The user changed the file, it was left alone
+)
+
+// Result is the outcome for one configuration file
+type Result struct {
+	Target string
+	Action Action
+	Backup string // Copy of the replaced file, if any
+	Diff   string // Three-way diff when the user's file was kept
+}
+
+// Managed reports whether the file holds the gearbox version, so removing
+// the tool may remove it
+func (r Result) Managed() bool {
+	return r.Action != ActionKept
+}
+
+// Installer renders templates and installs them
+type Installer struct {
+	TemplateDirs []string // Searched in order, user overrides first
+	StateDir     string
+	Data         Data
+	DryRun       bool
+}
+
+// NewInstaller creates an installer for the templates of a repository and
+// the overrides and state below ~/.gearbox
+func NewInstaller(repoTemplates, gearboxDir, tool string) *Installer {
+	home := os.Getenv("HOME")
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+	return &Installer{
+		TemplateDirs: []string{filepath.Join(gearboxDir, OverrideDir), repoTemplates},
+		StateDir:     filepath.Join(gearboxDir, StateDir),
+		Data:         Data{Tool: tool, Home: home, ConfigHome: configHome},
+	}
+}
+
+// ToolStateDir returns the directory holding the copies of a tool's files
+func ToolStateDir(gearboxDir, tool string) string {
+	return filepath.Join(gearboxDir, StateDir, tool)
+}
+
+// Install installs the configuration files of the templates
+func (i *Installer) Install(templates []Template) ([]Result, error) {
+	var results []Result
+	for _, t := range templates {
+		result, err := i.install(t)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (i *Installer) install(t Template) (Result, error) {
+	target, err := i.render("target", t.Target)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid target of %s: %w", t.Source, err)
+	}
+	result := Result{Target: filepath.Clean(target)}
+
+	source, err := i.findTemplate(t.Source)
+	if err != nil {
+		return result, err
+	}
+	text, err := os.ReadFile(source)
+	if err != nil {
+		return result, fmt.Errorf("failed to read template %s: %w", source, err)
+	}
+	rendered, err := i.render(t.Source, string(text))
+	if err != nil {
+		return result, fmt.Errorf("failed to render %s: %w", source, err)
+	}
+
+	statePath := filepath.Join(i.StateDir, i.Data.Tool, t.Source)
+	base, baseErr := os.ReadFile(statePath)
+	current, err := os.ReadFile(result.Target)
+	switch {
+	case os.IsNotExist(err):
+		result.Action = ActionCreated
+	case err != nil:
+		return result, fmt.Errorf("failed to read %s: %w", result.Target, err)
+	case string(current) == rendered:
+		result.Action = ActionUnchanged
+	case baseErr == nil && bytes.Equal(current, base):
+		result.Action = ActionUpdated
+	default:
+		result.Action = ActionKept
+		if baseErr != nil {
+			base = nil
+		}
+		result.Diff = ThreeWayDiff(string(base), string(current), rendered)
+		return result, nil
+	}
+
+	if i.DryRun {
+		return result, nil
+	}
+	if result.Action == ActionUpdated {
+		result.Backup = fmt.Sprintf("%s.backup-%s", result.Target, time.Now().Format("20060102-150405"))
+		if err := os.WriteFile(result.Backup, current, 0644); err != nil {
+			return result, fmt.Errorf("failed to back up %s: %w", result.Target, err)
+		}
+	}
+	if result.Action != ActionUnchanged {
+		if err := writeFile(result.Target, rendered); err != nil {
+			return result, err
+		}
+	}
+	if err := writeFile(statePath, rendered); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// findTemplate returns the first template directory holding the source
+func (i *Installer) findTemplate(source string) (string, error) {
+	for _, dir := range i.TemplateDirs {
+		path := filepath.Join(dir, source)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("template not found: %s", source)
+}
+
+func (i *Installer) render(name, text string) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, i.Data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func writeFile(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
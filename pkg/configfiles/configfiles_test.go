@@ -0,0 +1,138 @@
+package configfiles
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestInstaller(t *testing.T, template string) (*Installer, string) {
+	t.Helper()
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "templates")
+	if err := os.MkdirAll(filepath.Join(repo, "bat"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "bat", "config"), []byte(template), 0644); err != nil {
+		t.Fatal(err)
+	}
+	installer := &Installer{
+		TemplateDirs: []string{filepath.Join(dir, "overrides"), repo},
+		StateDir:     filepath.Join(dir, "state"),
+		Data:         Data{Tool: "bat", Home: dir, ConfigHome: filepath.Join(dir, ".config")},
+	}
+	return installer, dir
+}
+
+var batTemplate = []Template{{Source: "bat/config", Target: "{{.ConfigHome}}/bat/config"}}
+
+func setTemplate(t *testing.T, installer *Installer, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(installer.TemplateDirs[1], "bat", "config"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInstallCreatesAndUpdatesUnchangedFiles(t *testing.T) {
+	installer, dir := newTestInstaller(t, "--theme=\"TwoDark\"\n# {{.Tool}} in {{.Home}}\n")
+	target := filepath.Join(dir, ".config", "bat", "config")
+
+	results, err := installer.Install(batTemplate)
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if results[0].Action != ActionCreated || results[0].Target != target {
+		t.Fatalf("unexpected result %+v", results[0])
+	}
+	if data, _ := os.ReadFile(target); string(data) != "--theme=\"TwoDark\"\n# bat in "+dir+"\n" {
+		t.Errorf("unexpected rendering:\n%s", data)
+	}
+
+	if results, _ := installer.Install(batTemplate); results[0].Action != ActionUnchanged {
+		t.Errorf("expected unchanged, got %s", results[0].Action)
+	}
+
+	// A new template replaces a file the user did not touch
+	setTemplate(t, installer, "--theme=\"Nord\"\n")
+	results, err = installer.Install(batTemplate)
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if results[0].Action != ActionUpdated || results[0].Backup == "" {
+		t.Fatalf("unexpected result %+v", results[0])
+	}
+	if data, _ := os.ReadFile(target); string(data) != "--theme=\"Nord\"\n" {
+		t.Errorf("file not updated:\n%s", data)
+	}
+	if backup, _ := os.ReadFile(results[0].Backup); !strings.Contains(string(backup), "TwoDark") {
+		t.Errorf("backup does not hold the old file:\n%s", backup)
+	}
+}
+
+func TestInstallKeepsUserChanges(t *testing.T) {
+	installer, dir := newTestInstaller(t, "--theme=\"TwoDark\"\n--style=\"numbers\"\n")
+	target := filepath.Join(dir, ".config", "bat", "config")
+	if _, err := installer.Install(batTemplate); err != nil {
+		t.Fatal(err)
+	}
+
+	userFile := "--theme=\"TwoDark\"\n--style=\"full\"\n"
+	if err := os.WriteFile(target, []byte(userFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	setTemplate(t, installer, "--theme=\"Nord\"\n--style=\"numbers\"\n")
+
+	results, err := installer.Install(batTemplate)
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if results[0].Action != ActionKept || results[0].Managed() {
+		t.Fatalf("unexpected result %+v", results[0])
+	}
+	if data, _ := os.ReadFile(target); string(data) != userFile {
+		t.Errorf("user file was overwritten:\n%s", data)
+	}
+	for _, want := range []string{"+++ your changes", "+ --style=\"full\"", "+++ template changes", "+ --theme=\"Nord\""} {
+		if !strings.Contains(results[0].Diff, want) {
+			t.Errorf("expected %q in diff:\n%s", want, results[0].Diff)
+		}
+	}
+}
+
+func TestInstallPrefersOverridesAndHonorsDryRun(t *testing.T) {
+	installer, dir := newTestInstaller(t, "repo\n")
+	override := filepath.Join(installer.TemplateDirs[0], "bat", "config")
+	if err := os.MkdirAll(filepath.Dir(override), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(override, []byte("mine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	installer.DryRun = true
+	results, err := installer.Install(batTemplate)
+	if err != nil || results[0].Action != ActionCreated {
+		t.Fatalf("unexpected dry run %+v, %v", results, err)
+	}
+	target := filepath.Join(dir, ".config", "bat", "config")
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Fatalf("dry run wrote %s", target)
+	}
+
+	installer.DryRun = false
+	if _, err := installer.Install(batTemplate); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "mine\n" {
+		t.Errorf("override not used:\n%s", data)
+	}
+}
+
+func TestDiffShowsContext(t *testing.T) {
+	got := Diff("a\nb\nc\nd\ne\nf\ng\n", "a\nb\nc\nd\ne\nF\ng\n")
+	want := "@@\n  d\n  e\n- f\n+ F\n  g\n"
+	if got != want {
+		t.Errorf("unexpected diff:\n%s", got)
+	}
+}
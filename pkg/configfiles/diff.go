@@ -0,0 +1,111 @@
+package configfiles
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around a change
+const contextLines = 2
+
+// ThreeWayDiff shows the user's changes and the template's changes against
+// the version gearbox installed last. Without that version, the user's file
+// is compared with the template directly.
+func ThreeWayDiff(base, yours, template string) string {
+	var b strings.Builder
+	if base == "" {
+		b.WriteString("--- your file\n+++ gearbox template\n")
+		b.WriteString(Diff(yours, template))
+		return b.String()
+	}
+	b.WriteString("--- installed by gearbox\n+++ your changes\n")
+	b.WriteString(Diff(base, yours))
+	b.WriteString("--- installed by gearbox\n+++ template changes\n")
+	b.WriteString(Diff(base, template))
+	return b.String()
+}
+
+// Diff returns the changed lines from a to b with a few lines of context,
+// prefixed with "-", "+" or " ". Skipped unchanged lines are shown as "@@".
+func Diff(a, b string) string {
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	var out strings.Builder
+	lastShown := -1
+	for i, op := range ops {
+		if op.kind == ' ' && !nearChange(ops, i) {
+			continue
+		}
+		if lastShown >= 0 && i > lastShown+1 || lastShown < 0 && i > 0 {
+			out.WriteString("@@\n")
+		}
+		fmt.Fprintf(&out, "%c %s\n", op.kind, op.line)
+		lastShown = i
+	}
+	return out.String()
+}
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// diffLines computes an edit script from the longest common subsequence
+func diffLines(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+// nearChange reports whether a change is within the context of ops[i]
+func nearChange(ops []diffOp, i int) bool {
+	for j := i - contextLines; j <= i+contextLines; j++ {
+		if j >= 0 && j < len(ops) && ops[j].kind != ' ' {
+			return true
+		}
+	}
+	return false
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,260 @@
+package daemon
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"gearbox/pkg/orchestrator"
+	"gearbox/pkg/status"
+)
+
+// Client talks to a running daemon
+type Client struct {
+	http *http.Client
+}
+
+// Dial connects to the daemon on SocketPath. It fails when no daemon is
+// running, in which case callers work on their own.
+func Dial() (*Client, error) {
+	return DialSocket(SocketPath())
+}
+
+// DialSocket connects to the daemon on the given socket
+func DialSocket(path string) (*Client, error) {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("gearbox daemon not running: %w", err)
+	}
+	conn.Close()
+
+	dialer := &net.Dialer{}
+	return &Client{http: &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", path)
+		},
+	}}}, nil
+}
+
+// Tools lists the configured tools, optionally of one category
+func (c *Client) Tools(category string) (orchestrator.ToolList, error) {
+	var list orchestrator.ToolList
+	err := c.do(http.MethodGet, "/v1/tools?"+url.Values{"category": {category}}.Encode(), nil, &list)
+	return list, err
+}
+
+// Status reports the status of the given tools, or of all tools
+func (c *Client) Status(tools []string) (*status.StatusReport, error) {
+	var report status.StatusReport
+	if err := c.do(http.MethodGet, "/v1/status?"+url.Values{"tool": tools}.Encode(), nil, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Plan returns the installation plan for the tools without installing them
+func (c *Client) Plan(tools, args []string) (*orchestrator.InstallPlan, error) {
+	var plan orchestrator.InstallPlan
+	if err := c.do(http.MethodPost, "/v1/plan", JobRequest{Action: ActionInstall, Tools: tools, Args: args, Env: ClientEnv()}, &plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// Submit queues a job. Jobs run with the forwarded variables of this
+// process's environment unless the request sets them.
+func (c *Client) Submit(req JobRequest) (Job, error) {
+	if req.Env == nil {
+		req.Env = ClientEnv()
+	}
+	var job Job
+	err := c.do(http.MethodPost, "/v1/jobs", req, &job)
+	return job, err
+}
+
+// Jobs lists all jobs in submission order
+func (c *Client) Jobs() ([]Job, error) {
+	var jobs []Job
+	err := c.do(http.MethodGet, "/v1/jobs", nil, &jobs)
+	return jobs, err
+}
+
+// Job returns one job
+func (c *Client) Job(id string) (Job, error) {
+	var job Job
+	err := c.do(http.MethodGet, "/v1/jobs/"+url.PathEscape(id), nil, &job)
+	return job, err
+}
+
+// Cancel cancels a queued or running job
+func (c *Client) Cancel(id string) (Job, error) {
+	var job Job
+	err := c.do(http.MethodPost, "/v1/jobs/"+url.PathEscape(id)+"/cancel", nil, &job)
+	return job, err
+}
+
+// Events calls fn for every event until ctx is done or fn returns false.
+// With a job ID the past events of the job come first and the stream ends
+// when the job finishes; without one the events of all jobs follow.
+//
+// The daemon drops subscribers that fall behind. The stream of a job that
+// ends while the job is queued or running is resumed after the events
+// already seen, and a final state missed in between is passed to fn.
+func (c *Client) Events(ctx context.Context, jobID string, fn func(JobEvent) bool) error {
+	if jobID == "" {
+		_, err := c.stream(ctx, "", fn)
+		return err
+	}
+
+	seen, done := 0, false
+	for {
+		stopped, err := c.stream(ctx, jobID, func(event JobEvent) bool {
+			if event.Seq <= seen {
+				return true
+			}
+			seen = event.Seq
+			done = event.State.Done()
+			return fn(event)
+		})
+		if err != nil || stopped || done || ctx.Err() != nil {
+			return err
+		}
+
+		job, err := c.Job(jobID)
+		if err != nil {
+			return err
+		}
+		if job.State.Done() {
+			fn(JobEvent{Job: jobID, State: job.State})
+			return nil
+		}
+	}
+}
+
+// stream calls fn for the events of one subscription and reports whether fn
+// stopped it
+func (c *Client) stream(ctx context.Context, jobID string, fn func(JobEvent) bool) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://gearbox/v1/events?"+url.Values{"job": {jobID}}.Encode(), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to follow events: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, responseError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event JobEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return false, fmt.Errorf("invalid event from daemon: %w", err)
+		}
+		if !fn(event) {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return false, fmt.Errorf("event stream interrupted: %w", err)
+	}
+	return false, nil
+}
+
+// Wait calls fn for the installation events of a job until it finishes, and
+// returns an error unless it succeeded
+func (c *Client) Wait(id string, fn func(orchestrator.Event)) error {
+	var state JobState
+	err := c.Events(context.Background(), id, func(event JobEvent) bool {
+		if event.Event != nil {
+			fn(*event.Event)
+		}
+		if event.State != "" {
+			state = event.State
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	switch state {
+	case JobSucceeded:
+		return nil
+	case JobCancelled:
+		return fmt.Errorf("job %s was cancelled", id)
+	default:
+		job, err := c.Job(id)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("job %s failed: %s", id, job.Error)
+	}
+}
+
+// Install installs tools as a job of the daemon on SocketPath and reports
+// its events. It returns orchestrator.ErrNoDaemon when no daemon runs.
+func Install(tools, args []string, report func(orchestrator.Event)) error {
+	client, err := Dial()
+	if err != nil {
+		return orchestrator.ErrNoDaemon
+	}
+	job, err := client.Submit(JobRequest{Action: ActionInstall, Tools: tools, Args: args})
+	if err != nil {
+		return err
+	}
+	report(orchestrator.Event{Type: orchestrator.EventMessage, Message: fmt.Sprintf("📡 Submitted job %s to the gearbox daemon (gearbox jobs watch %s)\n", job.ID, job.ID)})
+	return client.Wait(job.ID, report)
+}
+
+// do sends a request and decodes the response into out
+func (c *Client) do(method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, "http://gearbox"+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach gearbox daemon: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return responseError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response from daemon: %w", err)
+	}
+	return nil
+}
+
+// responseError returns the error of a failed request
+func responseError(resp *http.Response) error {
+	var body apiError
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error == "" {
+		return fmt.Errorf("gearbox daemon returned %s", resp.Status)
+	}
+	return fmt.Errorf("%s", body.Error)
+}
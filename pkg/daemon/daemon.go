@@ -0,0 +1,638 @@
+// Package daemon runs installations for the CLI and the TUI in one long-running
+// process. The daemon owns the install queue: jobs run one at a time, which
+// serializes access to package managers and the manifest, and every client
+// can watch or cancel any job through an HTTP API on a unix socket.
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"gearbox/pkg/manifest"
+	"gearbox/pkg/orchestrator"
+	"gearbox/pkg/status"
+)
+
+// socketFile is the name of the socket in ~/.gearbox
+const socketFile = "gearbox.sock"
+
+// maxJobHistory limits the events kept per job for late watchers
+const maxJobHistory = 2000
+
+// Job actions
+const (
+	ActionInstall   = "install"
+	ActionUninstall = "uninstall"
+)
+
+// JobState is the lifecycle state of a job
+type JobState string
+
+const (
+	JobQueued    JobState = "queued"
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+	JobCancelled JobState = "cancelled"
+)
+
+// Done reports whether a job in this state has finished
+func (s JobState) Done() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCancelled
+}
+
+// JobRequest asks the daemon to install or uninstall tools
+type JobRequest struct {
+	Action string   `json:"action"`         // install or uninstall
+	Tools  []string `json:"tools"`          // Tools or bundles
+	Args   []string `json:"args,omitempty"` // Flags for the orchestrator command, such as --build-type minimal
+	Env    []string `json:"env,omitempty"`  // Variables of the client's environment, see ClientEnv
+}
+
+// forwardedEnv lists the variables of the client's environment that change
+// what an installation does. The daemon may have been started from another
+// shell, so jobs run with the client's values.
+var forwardedEnv = []string{
+	"PATH", "SHELL",
+	"GEARBOX_MIRROR", "GEARBOX_CACHE_DIR",
+	"CARGO_HOME", "GOPATH", "GOBIN",
+	"XDG_CONFIG_HOME", "XDG_DATA_HOME", "XDG_CACHE_HOME",
+	"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy",
+}
+
+// Forwarded variables that hold a list of directories or one directory
+var (
+	pathListEnv = []string{"PATH", "GOPATH"}
+	pathEnv     = []string{"GEARBOX_MIRROR", "GEARBOX_CACHE_DIR", "CARGO_HOME", "GOBIN", "XDG_CONFIG_HOME", "XDG_DATA_HOME", "XDG_CACHE_HOME"}
+)
+
+// ClientEnv returns the forwarded variables of this process's environment
+// for a job request. Jobs run in the daemon's working directory, so
+// relative directories are made absolute.
+func ClientEnv() []string {
+	var env []string
+	for _, name := range forwardedEnv {
+		value, found := os.LookupEnv(name)
+		if !found {
+			continue
+		}
+		switch {
+		case containsString(pathListEnv, name):
+			dirs := filepath.SplitList(value)
+			for i, dir := range dirs {
+				dirs[i] = AbsPath(dir)
+			}
+			value = strings.Join(dirs, string(filepath.ListSeparator))
+		case containsString(pathEnv, name):
+			value = AbsPath(value)
+		}
+		env = append(env, name+"="+value)
+	}
+	return env
+}
+
+// AbsPath returns a path as absolute, for the daemon, which runs jobs in
+// its own working directory. Empty paths and paths that cannot be resolved
+// are returned as they are.
+func AbsPath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// jobEnv returns the daemon's environment with the client's variables.
+// Forwarded variables the client did not set are removed, so that a mirror
+// of the daemon's shell does not apply to a client without one.
+func jobEnv(clientEnv []string) []string {
+	if clientEnv == nil {
+		return os.Environ()
+	}
+	var env []string
+	for _, entry := range os.Environ() {
+		name, _, _ := strings.Cut(entry, "=")
+		if !containsString(forwardedEnv, name) {
+			env = append(env, entry)
+		}
+	}
+	for _, entry := range clientEnv {
+		name, _, _ := strings.Cut(entry, "=")
+		if containsString(forwardedEnv, name) {
+			env = append(env, entry)
+		}
+	}
+	return env
+}
+
+func containsString(list []string, item string) bool {
+	for _, s := range list {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
+
+// Job is a queued, running or finished request
+type Job struct {
+	ID       string     `json:"id"`
+	Action   string     `json:"action"`
+	Tools    []string   `json:"tools"`
+	Args     []string   `json:"args,omitempty"`
+	State    JobState   `json:"state"`
+	Error    string     `json:"error,omitempty"`
+	Created  time.Time  `json:"created"`
+	Started  *time.Time `json:"started,omitempty"`
+	Finished *time.Time `json:"finished,omitempty"`
+}
+
+// JobEvent is an installation event of a job, or a change of its state
+type JobEvent struct {
+	Job   string              `json:"job"`
+	Seq   int                 `json:"seq"`             // Position among the events of the job, from 1
+	State JobState            `json:"state,omitempty"` // Set when the state changed
+	Event *orchestrator.Event `json:"event,omitempty"` // Set for installation events
+}
+
+// SocketPath returns the socket of the daemon, $GEARBOX_SOCKET or
+// ~/.gearbox/gearbox.sock
+func SocketPath() string {
+	if path := os.Getenv("GEARBOX_SOCKET"); path != "" {
+		return path
+	}
+	return filepath.Join(os.Getenv("HOME"), manifest.ManifestDir, socketFile)
+}
+
+// job is a job with its events and the means to cancel it
+type job struct {
+	Job
+	env     []string // Client environment, kept out of job listings
+	history []JobEvent
+	events  int         // Events published so far
+	process *os.Process // Set while running
+}
+
+// Server serves the daemon API and runs the jobs
+type Server struct {
+	orchestratorPath string
+
+	mu          sync.Mutex
+	jobs        map[string]*job
+	order       []string // Job IDs in submission order
+	nextID      int
+	subscribers map[chan JobEvent]string // Job to follow, empty for all
+	queue       chan *job
+	http        *http.Server
+}
+
+// NewServer returns a server that runs jobs with the orchestrator binary
+func NewServer(orchestratorPath string) *Server {
+	s := &Server{
+		orchestratorPath: orchestratorPath,
+		jobs:             make(map[string]*job),
+		subscribers:      make(map[chan JobEvent]string),
+		queue:            make(chan *job, 100),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/tools", s.handleTools)
+	mux.HandleFunc("GET /v1/status", s.handleStatus)
+	mux.HandleFunc("POST /v1/plan", s.handlePlan)
+	mux.HandleFunc("GET /v1/jobs", s.handleJobs)
+	mux.HandleFunc("POST /v1/jobs", s.handleSubmit)
+	mux.HandleFunc("GET /v1/jobs/{id}", s.handleJob)
+	mux.HandleFunc("POST /v1/jobs/{id}/cancel", s.handleCancel)
+	mux.HandleFunc("GET /v1/events", s.handleEvents)
+	s.http = &http.Server{Handler: mux}
+	return s
+}
+
+// Listen creates the unix socket. A socket left behind by a daemon that
+// stopped is replaced; a running daemon is an error.
+func Listen(path string) (net.Listener, error) {
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("gearbox daemon already running on %s", path)
+	}
+	os.Remove(path)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	// Only the user may control installations
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// Serve runs jobs and answers requests until Shutdown
+func (s *Server) Serve(listener net.Listener) error {
+	go s.runJobs()
+	if err := s.http.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown cancels queued and running jobs and stops serving
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	for _, id := range s.order {
+		if j := s.jobs[id]; !j.State.Done() {
+			s.cancelLocked(j)
+		}
+	}
+	s.mu.Unlock()
+	return s.http.Shutdown(ctx)
+}
+
+// runJobs runs the queued jobs one at a time
+func (s *Server) runJobs() {
+	for j := range s.queue {
+		s.mu.Lock()
+		if j.State != JobQueued {
+			// Cancelled while waiting
+			s.mu.Unlock()
+			continue
+		}
+		s.mu.Unlock()
+
+		s.runJob(j)
+	}
+}
+
+// runJob runs the orchestrator for a job and publishes its events
+func (s *Server) runJob(j *job) {
+	args := append(append([]string{j.Action}, j.Tools...), j.Args...)
+	if j.Action == ActionInstall {
+		args = append(args, "--events")
+	}
+	cmd := exec.Command(s.orchestratorPath, args...)
+	cmd.Env = jobEnv(j.env)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true} // Cancelling stops the scripts too
+	if j.Action == ActionUninstall {
+		// Confirmation was given by submitting the job
+		cmd.Stdin = strings.NewReader("y\n")
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		s.finish(j, err)
+		return
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		s.finish(j, err)
+		return
+	}
+
+	s.mu.Lock()
+	j.process = cmd.Process
+	now := time.Now()
+	j.Started = &now
+	if j.State == JobCancelled {
+		// Cancelled while starting
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	} else {
+		j.State = JobRunning
+		s.publishLocked(j, JobEvent{Job: j.ID, State: JobRunning})
+	}
+	s.mu.Unlock()
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event orchestrator.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.Type == "" {
+			// Uninstallations and package managers print text
+			event = orchestrator.Event{Type: orchestrator.EventOutput, Time: time.Now(), Message: scanner.Text()}
+		}
+		s.mu.Lock()
+		s.publishLocked(j, JobEvent{Job: j.ID, Event: &event})
+		s.mu.Unlock()
+	}
+
+	s.finish(j, cmd.Wait())
+}
+
+// finish records the outcome of a job
+func (s *Server) finish(j *job, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	j.Finished = &now
+	j.process = nil
+	switch {
+	case j.State == JobCancelled:
+	case err != nil:
+		j.State = JobFailed
+		j.Error = err.Error()
+	default:
+		j.State = JobSucceeded
+	}
+	s.publishLocked(j, JobEvent{Job: j.ID, State: j.State})
+}
+
+// submit queues a job
+func (s *Server) submit(req JobRequest) (Job, error) {
+	if req.Action != ActionInstall && req.Action != ActionUninstall {
+		return Job{}, fmt.Errorf("unsupported action %q (use install or uninstall)", req.Action)
+	}
+	if req.Action == ActionUninstall && len(req.Tools) == 0 {
+		return Job{}, fmt.Errorf("no tools specified for removal")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	j := &job{Job: Job{
+		ID:      fmt.Sprintf("%d", s.nextID),
+		Action:  req.Action,
+		Tools:   req.Tools,
+		Args:    req.Args,
+		State:   JobQueued,
+		Created: time.Now(),
+	}, env: req.Env}
+	select {
+	case s.queue <- j:
+	default:
+		return Job{}, fmt.Errorf("too many queued jobs")
+	}
+	s.jobs[j.ID] = j
+	s.order = append(s.order, j.ID)
+	s.publishLocked(j, JobEvent{Job: j.ID, State: JobQueued})
+	return j.Job, nil
+}
+
+// cancelLocked cancels a queued job or stops a running one
+func (s *Server) cancelLocked(j *job) {
+	switch j.State {
+	case JobQueued:
+		j.State = JobCancelled
+		now := time.Now()
+		j.Finished = &now
+		s.publishLocked(j, JobEvent{Job: j.ID, State: JobCancelled})
+	case JobRunning:
+		// finish publishes the state once the process exited
+		j.State = JobCancelled
+		if j.process != nil {
+			syscall.Kill(-j.process.Pid, syscall.SIGTERM)
+		}
+	}
+}
+
+// publishLocked keeps an event in the history of its job and sends it to
+// the subscribers. Subscribers that cannot keep up are dropped, and clients
+// resume from the history (see Client.Events).
+func (s *Server) publishLocked(j *job, event JobEvent) {
+	j.events++
+	event.Seq = j.events
+	j.history = append(j.history, event)
+	if len(j.history) > maxJobHistory {
+		j.history = j.history[len(j.history)-maxJobHistory:]
+	}
+
+	for ch, follow := range s.subscribers {
+		if follow != "" && follow != event.Job {
+			continue
+		}
+		select {
+		case ch <- event:
+		default:
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// subscribe returns the past events of a job and a channel for new ones.
+// An empty job ID follows all jobs.
+func (s *Server) subscribe(jobID string) ([]JobEvent, chan JobEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var history []JobEvent
+	if jobID != "" {
+		j, found := s.jobs[jobID]
+		if !found {
+			return nil, nil, fmt.Errorf("job not found: %s", jobID)
+		}
+		history = append(history, j.history...)
+	}
+	ch := make(chan JobEvent, 256)
+	s.subscribers[ch] = jobID
+	return history, ch, nil
+}
+
+// unsubscribe stops sending events to a channel
+func (s *Server) unsubscribe(ch chan JobEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, found := s.subscribers[ch]; found {
+		delete(s.subscribers, ch)
+		close(ch)
+	}
+}
+
+// handleTools lists the configured tools, optionally of one category
+func (s *Server) handleTools(w http.ResponseWriter, r *http.Request) {
+	o, err := orchestrator.NewOrchestratorBuilder(orchestrator.InstallationOptions{}).Build()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, o.ToolList(r.URL.Query().Get("category")))
+}
+
+// handleStatus reports the status of the given tools, or of all tools
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	service, err := status.NewUnifiedStatusService()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	report, err := service.GetStatusReport(r.URL.Query()["tool"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, report)
+}
+
+// handlePlan returns the installation plan of a dry run
+func (s *Server) handlePlan(w http.ResponseWriter, r *http.Request) {
+	var req JobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		return
+	}
+
+	args := append(append([]string{"install"}, req.Tools...), req.Args...)
+	cmd := exec.CommandContext(r.Context(), s.orchestratorPath, append(args, "--dry-run", "--events")...)
+	cmd.Env = jobEnv(req.Env)
+	output, err := cmd.Output()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("failed to plan installation: %w", err))
+		return
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		var event orchestrator.Event
+		if json.Unmarshal([]byte(line), &event) == nil && event.Type == orchestrator.EventPlan {
+			writeJSON(w, event.Plan)
+			return
+		}
+	}
+	writeError(w, http.StatusInternalServerError, fmt.Errorf("orchestrator returned no plan"))
+}
+
+// handleJobs lists all jobs in submission order
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	jobs := make([]Job, 0, len(s.order))
+	for _, id := range s.order {
+		jobs = append(jobs, s.jobs[id].Job)
+	}
+	s.mu.Unlock()
+	writeJSON(w, jobs)
+}
+
+// handleSubmit queues a job
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req JobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		return
+	}
+	j, err := s.submit(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(j)
+}
+
+// handleJob returns one job
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	j, found := s.jobs[r.PathValue("id")]
+	var snapshot Job
+	if found {
+		snapshot = j.Job
+	}
+	s.mu.Unlock()
+
+	if !found {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job not found: %s", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, snapshot)
+}
+
+// handleCancel cancels a job
+func (s *Server) handleCancel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	j, found := s.jobs[r.PathValue("id")]
+	var snapshot Job
+	if found {
+		s.cancelLocked(j)
+		snapshot = j.Job
+	}
+	s.mu.Unlock()
+
+	if !found {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job not found: %s", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, snapshot)
+}
+
+// handleEvents streams events as newline-delimited JSON. With ?job=<id> the
+// past events of the job come first and the stream ends with the job.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	jobID := r.URL.Query().Get("job")
+	history, events, err := s.subscribe(jobID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	defer s.unsubscribe(events)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+
+	send := func(event JobEvent) bool {
+		if err := encoder.Encode(event); err != nil {
+			return false
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		// A watched job ends the stream when it finishes
+		return jobID == "" || !event.State.Done()
+	}
+
+	for _, event := range history {
+		if !send(event) {
+			return
+		}
+	}
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, open := <-events:
+			if !open || !send(event) {
+				return
+			}
+		}
+	}
+}
+
+// writeJSON writes a response body
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// apiError is the body of failed requests
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// writeError writes a failed response
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(apiError{Error: err.Error()})
+}
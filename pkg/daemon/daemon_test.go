@@ -0,0 +1,274 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gearbox/pkg/orchestrator"
+)
+
+// fakeOrchestrator prints an event and a line of text for installations,
+// sleeps when asked to install "slow" and prints its mirror for "env"
+const fakeOrchestrator = `#!/bin/sh
+if [ "$1" = "install" ] && [ "$2" = "slow" ]; then
+	exec sleep 30
+fi
+if [ "$2" = "env" ]; then
+	echo "mirror=$GEARBOX_MIRROR"
+fi
+echo '{"type":"tool_finished","tool":"'"$2"'","result":{"tool":"'"$2"'","success":true}}'
+echo "plain text"
+`
+
+// startServer runs a daemon with the fake orchestrator on a temporary socket
+func startServer(t *testing.T) *Client {
+	t.Helper()
+	_, client := startTestServer(t)
+	return client
+}
+
+// startTestServer is startServer that returns the server as well
+func startTestServer(t *testing.T) (*Server, *Client) {
+	t.Helper()
+	dir := t.TempDir()
+	script := filepath.Join(dir, "orchestrator")
+	if err := os.WriteFile(script, []byte(fakeOrchestrator), 0755); err != nil {
+		t.Fatalf("failed to write fake orchestrator: %v", err)
+	}
+
+	socket := filepath.Join(dir, "gearbox.sock")
+	listener, err := Listen(socket)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := NewServer(script)
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Shutdown(context.Background()) })
+
+	client, err := DialSocket(socket)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	return server, client
+}
+
+// follow collects the events of a job until it finishes
+func follow(t *testing.T, client *Client, id string) []JobEvent {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var events []JobEvent
+	err := client.Events(ctx, id, func(event JobEvent) bool {
+		events = append(events, event)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("failed to follow job %s: %v", id, err)
+	}
+	return events
+}
+
+func TestServerRunsJobsAndReplaysEvents(t *testing.T) {
+	client := startServer(t)
+
+	job, err := client.Submit(JobRequest{Action: ActionInstall, Tools: []string{"fd"}})
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if job.State != JobQueued {
+		t.Errorf("expected a queued job, got %s", job.State)
+	}
+
+	events := follow(t, client, job.ID)
+	var states []string
+	var finished, output bool
+	for _, event := range events {
+		if event.State != "" {
+			states = append(states, string(event.State))
+		}
+		if event.Event != nil && event.Event.Type == orchestrator.EventToolFinished && event.Event.Tool == "fd" {
+			finished = true
+		}
+		if event.Event != nil && event.Event.Type == orchestrator.EventOutput && event.Event.Message == "plain text" {
+			output = true
+		}
+	}
+	if strings.Join(states, ",") != "queued,running,succeeded" {
+		t.Errorf("unexpected states %v", states)
+	}
+	if !finished || !output {
+		t.Errorf("expected the tool_finished event and the text line, got %+v", events)
+	}
+
+	// A late watcher sees the same history
+	if replay := follow(t, client, job.ID); len(replay) != len(events) {
+		t.Errorf("expected %d replayed events, got %d", len(events), len(replay))
+	}
+}
+
+func TestEventsResumeWhenTheDaemonDropsTheStream(t *testing.T) {
+	server, client := startTestServer(t)
+
+	job, err := client.Submit(JobRequest{Action: ActionInstall, Tools: []string{"slow"}})
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var states []string
+	err = client.Events(ctx, job.ID, func(event JobEvent) bool {
+		if event.State == "" {
+			return true
+		}
+		states = append(states, string(event.State))
+		if event.State == JobRunning {
+			// Drop the subscriber as if it fell behind, then finish the job
+			server.mu.Lock()
+			for ch := range server.subscribers {
+				delete(server.subscribers, ch)
+				close(ch)
+			}
+			server.mu.Unlock()
+			client.Cancel(job.ID)
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatalf("failed to follow job: %v", err)
+	}
+	if strings.Join(states, ",") != "queued,running,cancelled" {
+		t.Errorf("expected each state once and the final one after the drop, got %v", states)
+	}
+}
+
+func TestServerCancelsRunningAndQueuedJobs(t *testing.T) {
+	client := startServer(t)
+
+	running, err := client.Submit(JobRequest{Action: ActionInstall, Tools: []string{"slow"}})
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	queued, err := client.Submit(JobRequest{Action: ActionInstall, Tools: []string{"fd"}})
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	if job, err := client.Cancel(queued.ID); err != nil || job.State != JobCancelled {
+		t.Fatalf("expected the queued job to be cancelled, got %+v, %v", job, err)
+	}
+	if _, err := client.Cancel(running.ID); err != nil {
+		t.Fatalf("cancel failed: %v", err)
+	}
+
+	follow(t, client, running.ID)
+	jobs, err := client.Jobs()
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].State != JobCancelled || jobs[1].State != JobCancelled || jobs[1].Started != nil {
+		t.Errorf("expected both jobs cancelled and the queued one never started, got %+v", jobs)
+	}
+}
+
+func TestServerRejectsUnknownJobsAndActions(t *testing.T) {
+	client := startServer(t)
+
+	if _, err := client.Submit(JobRequest{Action: "upgrade", Tools: []string{"fd"}}); err == nil || !strings.Contains(err.Error(), "unsupported action") {
+		t.Errorf("expected an unsupported action error, got %v", err)
+	}
+	if _, err := client.Job("42"); err == nil || !strings.Contains(err.Error(), "job not found") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if err := client.Events(context.Background(), "42", func(JobEvent) bool { return true }); err == nil {
+		t.Errorf("expected following an unknown job to fail")
+	}
+}
+
+func TestListenRefusesRunningDaemon(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "gearbox.sock")
+	listener, err := Listen(socket)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	if _, err := Listen(socket); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("expected an already running error, got %v", err)
+	}
+}
+
+func TestDialFailsWithoutDaemon(t *testing.T) {
+	if _, err := DialSocket(filepath.Join(t.TempDir(), "missing.sock")); err == nil {
+		t.Errorf("expected dialing a missing socket to fail")
+	}
+}
+
+func TestJobsRunWithTheClientEnvironment(t *testing.T) {
+	t.Setenv("GEARBOX_MIRROR", "/daemon/mirror")
+	client := startServer(t)
+
+	mirrorOf := func(env []string) string {
+		job, err := client.Submit(JobRequest{Action: ActionInstall, Tools: []string{"env"}, Env: env})
+		if err != nil {
+			t.Fatalf("submit failed: %v", err)
+		}
+		for _, event := range follow(t, client, job.ID) {
+			if event.Event != nil && strings.HasPrefix(event.Event.Message, "mirror=") {
+				return strings.TrimPrefix(event.Event.Message, "mirror=")
+			}
+		}
+		t.Fatalf("job printed no mirror")
+		return ""
+	}
+
+	path := "PATH=" + os.Getenv("PATH")
+	if got := mirrorOf([]string{path, "GEARBOX_MIRROR=/client/mirror"}); got != "/client/mirror" {
+		t.Errorf("expected the client's mirror, got %q", got)
+	}
+	if got := mirrorOf([]string{path}); got != "" {
+		t.Errorf("expected no mirror for a client without one, got %q", got)
+	}
+}
+
+func TestClientEnvForwardsInstallationVariables(t *testing.T) {
+	t.Setenv("GEARBOX_MIRROR", "/media/mirror")
+	t.Setenv("GEARBOX_SOCKET", "/tmp/other.sock")
+
+	env := strings.Join(ClientEnv(), "\n")
+	if !strings.Contains(env, "GEARBOX_MIRROR=/media/mirror") || !strings.Contains(env, "PATH=") {
+		t.Errorf("expected the mirror and PATH, got:\n%s", env)
+	}
+	if strings.Contains(env, "GEARBOX_SOCKET") {
+		t.Errorf("expected only installation variables, got:\n%s", env)
+	}
+}
+
+func TestClientEnvMakesRelativeDirectoriesAbsolute(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	t.Setenv("GEARBOX_MIRROR", "mirror")
+	t.Setenv("PATH", "bin"+string(filepath.ListSeparator)+"/usr/bin")
+
+	env := ClientEnv()
+	for _, expected := range []string{
+		"GEARBOX_MIRROR=" + filepath.Join(dir, "mirror"),
+		"PATH=" + filepath.Join(dir, "bin") + string(filepath.ListSeparator) + "/usr/bin",
+	} {
+		if !containsString(env, expected) {
+			t.Errorf("expected %s, got %v", expected, env)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Package fsutil contains filesystem helpers shared by the commands and the
+// orchestrator
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DirSize calculates the total size of the files in a directory, skipping
+// files it cannot access. A missing directory has size 0.
+func DirSize(path string) int64 {
+	var size int64
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip files we can't access
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
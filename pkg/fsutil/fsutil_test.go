@@ -0,0 +1,64 @@
+package fsutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDirSize(t *testing.T) {
+	// Create temporary directory structure
+	tempDir := t.TempDir()
+
+	// Create nested directories with files
+	subDir := filepath.Join(tempDir, "subdir")
+	if err := os.Mkdir(subDir, 0755); err != nil {
+		t.Fatalf("Failed to create subdirectory: %v", err)
+	}
+
+	file1 := filepath.Join(tempDir, "file1.txt")
+	file2 := filepath.Join(subDir, "file2.txt")
+
+	content1 := "Hello, World!"
+	content2 := "This is a test file."
+
+	if err := os.WriteFile(file1, []byte(content1), 0644); err != nil {
+		t.Fatalf("Failed to create file1: %v", err)
+	}
+
+	if err := os.WriteFile(file2, []byte(content2), 0644); err != nil {
+		t.Fatalf("Failed to create file2: %v", err)
+	}
+
+	size := DirSize(tempDir)
+	expectedSize := int64(len(content1) + len(content2))
+	if size != expectedSize {
+		t.Errorf("DirSize() = %d, want %d", size, expectedSize)
+	}
+}
+
+func TestDirSize_NonExistentDir(t *testing.T) {
+	if size := DirSize("/nonexistent/directory"); size != 0 {
+		t.Errorf("DirSize() should return 0 for non-existent directory, got %d", size)
+	}
+}
+
+func BenchmarkDirSize(b *testing.B) {
+	// Create a temporary directory with some files
+	tempDir := b.TempDir()
+
+	for i := 0; i < 10; i++ {
+		file := filepath.Join(tempDir, fmt.Sprintf("file%d.txt", i))
+		content := strings.Repeat("test content ", 100) // ~1.3KB per file
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			b.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DirSize(tempDir)
+	}
+}
@@ -0,0 +1,281 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gearbox/pkg/manifest"
+	"gearbox/pkg/shellinit"
+)
+
+// memoryWarnPercent is the memory usage above which builds may run out of memory
+const memoryWarnPercent = 90
+
+// buildTools are the commands source builds need besides a language toolchain
+var buildTools = []string{"gcc", "make", "cmake"}
+
+// NewDefaultRegistry returns a registry of the built-in checks
+func NewDefaultRegistry() *Registry {
+	return NewRegistry(
+		MemoryCheck(),
+		InternetCheck(),
+		BuildToolsCheck(),
+		GitCheck(),
+		PathCheck(),
+		RustCheck(),
+		GoCheck(),
+	)
+}
+
+// MemoryCheck reports the available memory
+func MemoryCheck() Check {
+	return NewCheck("memory", CategorySystem, func(ctx context.Context) Result {
+		data, err := os.ReadFile("/proc/meminfo")
+		if err != nil {
+			return Result{Severity: SeverityWarning, Message: "Could not read memory info"}
+		}
+
+		var totalKB, availableKB int
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			switch fields[0] {
+			case "MemTotal:":
+				totalKB, _ = strconv.Atoi(fields[1])
+			case "MemAvailable:":
+				availableKB, _ = strconv.Atoi(fields[1])
+			}
+		}
+		if totalKB <= 0 || availableKB <= 0 {
+			return Result{Severity: SeverityWarning, Message: "Could not parse memory info"}
+		}
+
+		totalGB := float64(totalKB) / 1024 / 1024
+		availableGB := float64(availableKB) / 1024 / 1024
+		usedGB := totalGB - availableGB
+		usagePercent := usedGB / totalGB * 100
+		result := Result{
+			Severity: SeverityOK,
+			Message:  fmt.Sprintf("%.1f GB available", availableGB),
+			Details: []string{
+				fmt.Sprintf("Total: %.1f GB", totalGB),
+				fmt.Sprintf("Used: %.1f GB (%.0f%%)", usedGB, usagePercent),
+				fmt.Sprintf("Available: %.1f GB", availableGB),
+			},
+		}
+		if usagePercent > memoryWarnPercent {
+			result.Severity = SeverityWarning
+			result.Remediation = []string{"Close other applications or build with fewer jobs: gearbox install --jobs 1"}
+		}
+		return result
+	})
+}
+
+// InternetCheck reports whether GitHub, where most sources come from, is reachable
+func InternetCheck() Check {
+	return NewCheck("internet", CategorySystem, func(ctx context.Context) Result {
+		if err := exec.CommandContext(ctx, "ping", "-c", "1", "-W", "3", "github.com").Run(); err != nil {
+			return Result{
+				Severity: SeverityWarning,
+				Message:  "No internet connection",
+				Remediation: []string{
+					"Check network connection",
+					"Some installs may fail without internet",
+				},
+			}
+		}
+		return Result{Severity: SeverityOK, Message: "Connected"}
+	})
+}
+
+// BuildToolsCheck reports the compilers and build systems source builds need
+func BuildToolsCheck() Check {
+	return NewCheck("build-tools", CategoryToolchain, func(ctx context.Context) Result {
+		var details, missing []string
+		for _, tool := range buildTools {
+			output, err := exec.CommandContext(ctx, tool, "--version").Output()
+			if err != nil {
+				missing = append(missing, tool)
+				continue
+			}
+			details = append(details, firstLine(output))
+		}
+
+		if len(missing) > 0 {
+			return Result{
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("Missing tools: %s", strings.Join(missing, ", ")),
+				Details:  details,
+				Remediation: []string{
+					"Install build essentials: sudo apt install build-essential",
+					"Install cmake: sudo apt install cmake",
+				},
+			}
+		}
+		return Result{Severity: SeverityOK, Message: "All required build tools installed", Details: details}
+	})
+}
+
+// GitCheck reports the git version sources are cloned with
+func GitCheck() Check {
+	return NewCheck("git", CategoryToolchain, func(ctx context.Context) Result {
+		output, err := exec.CommandContext(ctx, "git", "--version").Output()
+		if err != nil {
+			return Result{
+				Severity:    SeverityWarning,
+				Message:     "Git not installed",
+				Remediation: []string{"Install git: sudo apt install git"},
+			}
+		}
+		return Result{Severity: SeverityOK, Message: firstLine(output)}
+	})
+}
+
+// PathCheck reports whether PATH reaches the install locations and the
+// binaries recorded in the manifest
+func PathCheck() Check {
+	return NewCheck("path", CategorySystem, func(ctx context.Context) Result {
+		installDirs := []string{"/usr/local/bin"}
+		// ~/.cargo/bin only matters once Rust is installed
+		home, _ := os.UserHomeDir()
+		if cargoBin := filepath.Join(home, ".cargo", "bin"); isDir(cargoBin) {
+			installDirs = append(installDirs, cargoBin)
+		}
+		onPath := make(map[string]bool)
+		for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+			onPath[filepath.Clean(dir)] = true
+		}
+
+		result := Result{Severity: SeverityOK, Message: "Correctly configured"}
+		var missing []string
+		for _, dir := range installDirs {
+			if onPath[dir] {
+				result.Details = append(result.Details, dir+" is in PATH")
+			} else {
+				missing = append(missing, dir)
+			}
+		}
+
+		// Missing binaries are a matter of reinstalling, not of PATH
+		var problems []manifest.Drift
+		var env shellinit.Environment
+		if manager := manifest.NewManager(); manager.Exists() {
+			if m, err := manager.Load(); err == nil {
+				found, _ := shellinit.CheckPath(m, exec.LookPath)
+				for _, problem := range found {
+					if problem.Kind != manifest.DriftMissing {
+						problems = append(problems, problem)
+					}
+				}
+				env = shellinit.ManifestEnvironment(m)
+			}
+		}
+		for _, problem := range problems {
+			result.Details = append(result.Details, fmt.Sprintf("%s: %s", problem.Tool, problem))
+		}
+
+		switch {
+		case len(missing) > 0:
+			result.Severity = SeverityWarning
+			result.Message = fmt.Sprintf("Missing %s in PATH", strings.Join(missing, ", "))
+		case len(problems) > 0:
+			result.Severity = SeverityWarning
+			result.Message = fmt.Sprintf("%d installed binaries not reachable", len(problems))
+		}
+		if result.Severity != SeverityOK {
+			result.Remediation = []string{`Run 'eval "$(gearbox env)"' or load gearbox shell-init in your shell's rc file`}
+		}
+		if len(problems) > 0 {
+			result.Fix = shellInitFix(home, env)
+		}
+		return result
+	})
+}
+
+// shellInitFix loads shell-init in the rc file of the user's shell, which
+// puts the install locations of the manifest on PATH. The locations are also
+// added to PATH of this process, as a new shell would see them. It returns
+// nil when the rc file already loads shell-init.
+func shellInitFix(home string, env shellinit.Environment) *Fix {
+	file, found := shellinit.RCFileFor(home, shellinit.DetectShell())
+	if !found || file.LoadsShellInit() {
+		return nil
+	}
+	return &Fix{
+		Description: fmt.Sprintf("Add %q to %s", shellinit.InitLine(file.Shell), file.Path),
+		Apply: func(ctx context.Context) error {
+			if _, err := shellinit.AddInitLine(file); err != nil {
+				return err
+			}
+			path := os.Getenv("PATH")
+			for i := len(env.PathDirs) - 1; i >= 0; i-- {
+				path = env.PathDirs[i] + string(filepath.ListSeparator) + path
+			}
+			return os.Setenv("PATH", path)
+		},
+	}
+}
+
+// RustCheck reports the Rust toolchain that cargo installations build with
+func RustCheck() Check {
+	return NewCheck("rust", CategoryToolchain, func(ctx context.Context) Result {
+		output, err := exec.CommandContext(ctx, "rustc", "--version").Output()
+		if err != nil {
+			return Result{
+				Severity:    SeverityWarning,
+				Message:     "Rust not installed",
+				Remediation: []string{"Install Rust: curl --proto '=https' --tlsv1.2 -sSf https://sh.rustup.rs | sh"},
+			}
+		}
+
+		version := firstLine(output)
+		result := Result{Severity: SeverityOK, Message: version, Details: []string{version}}
+		for _, command := range []string{"cargo", "rustup"} {
+			if output, err := exec.CommandContext(ctx, command, "--version").Output(); err == nil {
+				result.Details = append(result.Details, firstLine(output))
+			}
+		}
+		return result
+	})
+}
+
+// GoCheck reports the Go toolchain that go installations build with
+func GoCheck() Check {
+	return NewCheck("go", CategoryToolchain, func(ctx context.Context) Result {
+		output, err := exec.CommandContext(ctx, "go", "version").Output()
+		if err != nil {
+			return Result{
+				Severity:    SeverityWarning,
+				Message:     "Go not installed",
+				Remediation: []string{"Install Go from https://golang.org/dl/"},
+			}
+		}
+
+		result := Result{Severity: SeverityOK, Message: firstLine(output)}
+		if gopath := os.Getenv("GOPATH"); gopath != "" {
+			result.Details = append(result.Details, "GOPATH: "+gopath)
+		}
+		if goroot := os.Getenv("GOROOT"); goroot != "" {
+			result.Details = append(result.Details, "GOROOT: "+goroot)
+		}
+		return result
+	})
+}
+
+// firstLine returns the first line of command output
+func firstLine(output []byte) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return line
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
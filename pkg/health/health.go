@@ -0,0 +1,194 @@
+// Package health runs the health checks shared by 'gearbox doctor', its JSON
+// output and CI reports, and the Health view of the TUI. A check inspects one
+// aspect of the system, such as free disk space or the Rust toolchain, and
+// returns how severe the problem is and how to fix it.
+//
+// Checks are collected in a registry. Packages that know more about the
+// installation register their own checks, or replace a built-in one by
+// registering a check with the same ID.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds a single check
+const DefaultTimeout = 10 * time.Second
+
+// Check categories
+const (
+	CategorySystem    = "system"
+	CategoryToolchain = "toolchain"
+	CategoryGearbox   = "gearbox"
+)
+
+// Severity is how serious the outcome of a check is
+type Severity int
+
+const (
+	SeverityOK       Severity = iota // Nothing to do
+	SeverityWarning                  // Some installations may fail or behave unexpectedly
+	SeverityCritical                 // Installations will fail
+)
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityOK:
+		return "ok"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Result is the outcome of a check
+type Result struct {
+	Severity    Severity
+	Message     string   // One line for listings
+	Details     []string // What was found
+	Remediation []string // How to fix the problem, one step per line
+	Fix         *Fix     // Fixes the problem automatically, nil when it cannot
+}
+
+// Fix remediates the problem a check found. Run the check again afterwards
+// to confirm the fix worked.
+type Fix struct {
+	Description string // What the fix will do, shown before asking to apply it
+	Apply       func(ctx context.Context) error
+}
+
+// Check inspects one aspect of the system
+type Check interface {
+	ID() string       // Stable name, such as disk-space
+	Category() string // CategorySystem, CategoryToolchain or CategoryGearbox
+	Run(ctx context.Context) Result
+}
+
+// NewCheck creates a check that runs a function
+func NewCheck(id, category string, run func(ctx context.Context) Result) Check {
+	return funcCheck{id: id, category: category, run: run}
+}
+
+type funcCheck struct {
+	id       string
+	category string
+	run      func(ctx context.Context) Result
+}
+
+func (c funcCheck) ID() string                     { return c.id }
+func (c funcCheck) Category() string               { return c.category }
+func (c funcCheck) Run(ctx context.Context) Result { return c.run(ctx) }
+
+// WithFix returns a check that offers a fix for the problems of another
+// check. fix returns nil for problems it cannot fix.
+func WithFix(check Check, fix func(result Result) *Fix) Check {
+	return NewCheck(check.ID(), check.Category(), func(ctx context.Context) Result {
+		result := check.Run(ctx)
+		if result.Severity != SeverityOK && result.Fix == nil {
+			result.Fix = fix(result)
+		}
+		return result
+	})
+}
+
+// Report is a result with the check it came from
+type Report struct {
+	ID       string
+	Category string
+	Result
+	Duration time.Duration
+}
+
+// Registry holds checks in the order they are shown
+type Registry struct {
+	checks []Check
+}
+
+// NewRegistry creates a registry holding the checks
+func NewRegistry(checks ...Check) *Registry {
+	r := &Registry{}
+	for _, check := range checks {
+		r.Register(check)
+	}
+	return r
+}
+
+// Register adds a check. A check with the ID of a registered check replaces
+// it in place.
+func (r *Registry) Register(check Check) {
+	for i, existing := range r.checks {
+		if existing.ID() == check.ID() {
+			r.checks[i] = check
+			return
+		}
+	}
+	r.checks = append(r.checks, check)
+}
+
+// Get returns the check with an ID
+func (r *Registry) Get(id string) (Check, bool) {
+	for _, check := range r.checks {
+		if check.ID() == id {
+			return check, true
+		}
+	}
+	return nil, false
+}
+
+// Checks returns the registered checks
+func (r *Registry) Checks() []Check {
+	return append([]Check(nil), r.checks...)
+}
+
+// Run runs the registered checks, see Run
+func (r *Registry) Run(ctx context.Context, timeout time.Duration) []Report {
+	return Run(ctx, r.checks, timeout)
+}
+
+// Run runs checks concurrently, each bounded by the timeout, and returns the
+// reports in the order of the checks
+func Run(ctx context.Context, checks []Check, timeout time.Duration) []Report {
+	reports := make([]Report, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			reports[i] = RunCheck(ctx, check, timeout)
+		}(i, check)
+	}
+	wg.Wait()
+	return reports
+}
+
+// RunCheck runs a single check. A check still running when the timeout
+// expires is reported as a warning and left to finish in the background.
+func RunCheck(ctx context.Context, check Check, timeout time.Duration) Report {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	report := Report{ID: check.ID(), Category: check.Category()}
+	start := time.Now()
+	done := make(chan Result, 1)
+	go func() {
+		done <- check.Run(ctx)
+	}()
+
+	select {
+	case report.Result = <-done:
+	case <-ctx.Done():
+		report.Result = Result{Severity: SeverityWarning, Message: fmt.Sprintf("timed out after %s", timeout)}
+		if ctx.Err() == context.Canceled {
+			report.Message = "canceled"
+		}
+	}
+	report.Duration = time.Since(start)
+	return report
+}
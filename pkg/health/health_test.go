@@ -0,0 +1,124 @@
+package health
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func staticCheck(id string, severity Severity) Check {
+	return NewCheck(id, CategorySystem, func(ctx context.Context) Result {
+		return Result{Severity: severity, Message: id}
+	})
+}
+
+func TestRegistryReplacesChecksWithTheSameID(t *testing.T) {
+	r := NewRegistry(staticCheck("a", SeverityOK), staticCheck("b", SeverityOK))
+	r.Register(staticCheck("a", SeverityCritical))
+	r.Register(staticCheck("c", SeverityOK))
+
+	checks := r.Checks()
+	if len(checks) != 3 || checks[0].ID() != "a" || checks[1].ID() != "b" || checks[2].ID() != "c" {
+		t.Fatalf("unexpected checks %v", checks)
+	}
+	if result := checks[0].Run(context.Background()); result.Severity != SeverityCritical {
+		t.Errorf("expected the replacement to run, got %+v", result)
+	}
+	if _, found := r.Get("missing"); found {
+		t.Error("expected no check for an unknown ID")
+	}
+}
+
+func TestRunRunsChecksConcurrentlyInOrder(t *testing.T) {
+	var checks []Check
+	for _, id := range []string{"first", "second", "third"} {
+		id := id
+		checks = append(checks, NewCheck(id, CategorySystem, func(ctx context.Context) Result {
+			time.Sleep(100 * time.Millisecond)
+			return Result{Message: id}
+		}))
+	}
+
+	start := time.Now()
+	reports := Run(context.Background(), checks, time.Second)
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("expected the checks to run concurrently, took %s", elapsed)
+	}
+	for i, id := range []string{"first", "second", "third"} {
+		if reports[i].ID != id || reports[i].Message != id || reports[i].Category != CategorySystem {
+			t.Errorf("unexpected report %d: %+v", i, reports[i])
+		}
+		if reports[i].Duration < 100*time.Millisecond {
+			t.Errorf("expected the duration of %s to be measured, got %s", id, reports[i].Duration)
+		}
+	}
+}
+
+func TestRunCheckTimesOut(t *testing.T) {
+	blocking := NewCheck("slow", CategorySystem, func(ctx context.Context) Result {
+		<-ctx.Done()
+		time.Sleep(time.Second)
+		return Result{Severity: SeverityOK, Message: "too late"}
+	})
+
+	start := time.Now()
+	report := RunCheck(context.Background(), blocking, 50*time.Millisecond)
+	if time.Since(start) > 500*time.Millisecond {
+		t.Error("expected the timeout to stop waiting for the check")
+	}
+	if report.Severity != SeverityWarning || report.Message != "timed out after 50ms" {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestSeverityString(t *testing.T) {
+	for severity, want := range map[Severity]string{
+		SeverityOK:       "ok",
+		SeverityWarning:  "warning",
+		SeverityCritical: "critical",
+	} {
+		if got := severity.String(); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestPathCheckReportsMissingInstallLocations(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("PATH", "/usr/local/bin:/usr/bin")
+	if result := PathCheck().Run(context.Background()); result.Severity != SeverityOK {
+		t.Errorf("expected ~/.cargo/bin to be optional without Rust, got %+v", result)
+	}
+
+	if err := os.MkdirAll(filepath.Join(home, ".cargo", "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	result := PathCheck().Run(context.Background())
+	if result.Severity != SeverityWarning || result.Message != "Missing "+home+"/.cargo/bin in PATH" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(result.Remediation) != 1 {
+		t.Errorf("expected a remediation, got %v", result.Remediation)
+	}
+
+	t.Setenv("PATH", "/usr/local/bin:"+home+"/.cargo/bin")
+	if result := PathCheck().Run(context.Background()); result.Severity != SeverityOK {
+		t.Errorf("expected PATH to be configured, got %+v", result)
+	}
+}
+
+func TestWithFixAddsFixesToProblems(t *testing.T) {
+	fix := func(result Result) *Fix {
+		return &Fix{Description: "fix " + result.Message}
+	}
+
+	if result := WithFix(staticCheck("broken", SeverityWarning), fix).Run(context.Background()); result.Fix == nil || result.Fix.Description != "fix broken" {
+		t.Errorf("expected a fix for the problem, got %+v", result)
+	}
+	if result := WithFix(staticCheck("fine", SeverityOK), fix).Run(context.Background()); result.Fix != nil {
+		t.Errorf("expected no fix without a problem, got %+v", result.Fix)
+	}
+}
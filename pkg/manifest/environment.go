@@ -0,0 +1,103 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EnvironmentSchemaVersion defines the current environment file schema version
+const EnvironmentSchemaVersion = "1.0"
+
+// Environment is a portable description of an installed tool set, used to
+// recreate the same environment on another machine
+type Environment struct {
+	SchemaVersion string             `json:"schema_version"`
+	ExportedAt    time.Time          `json:"exported_at"`
+	Hostname      string             `json:"hostname,omitempty"`
+	Bundles       []EnvironmentEntry `json:"bundles"`
+	Tools         []EnvironmentEntry `json:"tools"`        // Requested explicitly by the user
+	Dependencies  []EnvironmentEntry `json:"dependencies"` // Installed by bundles or as dependencies
+}
+
+// EnvironmentEntry describes a single exported tool or bundle
+type EnvironmentEntry struct {
+	Name              string             `json:"name"`
+	Method            InstallationMethod `json:"method,omitempty"`
+	Version           string             `json:"version,omitempty"`
+	BuildType         string             `json:"build_type,omitempty"`
+	InstalledByBundle string             `json:"installed_by_bundle,omitempty"`
+}
+
+// ExportEnvironment builds a portable environment from the manifest.
+// Pre-existing tools are skipped so that system tools are not reinstalled.
+func ExportEnvironment(m *InstallationManifest) *Environment {
+	env := &Environment{
+		SchemaVersion: EnvironmentSchemaVersion,
+		ExportedAt:    time.Now(),
+		Bundles:       []EnvironmentEntry{},
+		Tools:         []EnvironmentEntry{},
+		Dependencies:  []EnvironmentEntry{},
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		env.Hostname = hostname
+	}
+
+	var names []string
+	for name := range m.Installations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		record := m.Installations[name]
+		if record.Method == MethodPreExisting {
+			continue
+		}
+
+		if record.Method == MethodBundle {
+			bundleName := strings.TrimSuffix(name, "_bundle")
+			if record.UserRequested {
+				env.Bundles = append(env.Bundles, EnvironmentEntry{Name: bundleName, Method: MethodBundle, BuildType: record.BuildType})
+			}
+			continue
+		}
+
+		entry := EnvironmentEntry{
+			Name:              name,
+			Method:            record.Method,
+			Version:           record.Version,
+			BuildType:         record.BuildType,
+			InstalledByBundle: record.InstalledByBundle,
+		}
+		if record.UserRequested {
+			env.Tools = append(env.Tools, entry)
+		} else {
+			env.Dependencies = append(env.Dependencies, entry)
+		}
+	}
+
+	return env
+}
+
+// LoadEnvironment reads an environment file
+func LoadEnvironment(path string) (*Environment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read environment file: %w", err)
+	}
+
+	var env Environment
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse environment file: %w", err)
+	}
+
+	if env.SchemaVersion != EnvironmentSchemaVersion {
+		return nil, fmt.Errorf("unsupported environment schema version: %s (expected %s)", env.SchemaVersion, EnvironmentSchemaVersion)
+	}
+
+	return &env, nil
+}
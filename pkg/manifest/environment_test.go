@@ -0,0 +1,93 @@
+package manifest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportEnvironment(t *testing.T) {
+	manifest := NewManifest()
+	manifest.AddInstallation("fd", &InstallationRecord{
+		Method:        MethodSourceBuild,
+		Version:       "10.2.0",
+		BuildType:     "maximum",
+		UserRequested: true,
+	})
+	manifest.AddInstallation("ripgrep", &InstallationRecord{
+		Method:            MethodSourceBuild,
+		Version:           "14.1.0",
+		InstalledByBundle: "minimal",
+		UserRequested:     false,
+	})
+	manifest.AddInstallation("minimal_bundle", &InstallationRecord{
+		Method:        MethodBundle,
+		UserRequested: true,
+		Dependencies:  []string{"fd", "ripgrep", "fzf"},
+	})
+	manifest.AddInstallation("git", &InstallationRecord{
+		Method:        MethodPreExisting,
+		Version:       "2.43.0",
+		UserRequested: false,
+	})
+
+	env := ExportEnvironment(manifest)
+
+	if env.SchemaVersion != EnvironmentSchemaVersion {
+		t.Errorf("ExportEnvironment() SchemaVersion = %v, want %v", env.SchemaVersion, EnvironmentSchemaVersion)
+	}
+
+	if len(env.Bundles) != 1 || env.Bundles[0].Name != "minimal" {
+		t.Errorf("ExportEnvironment() bundles = %+v, want [minimal]", env.Bundles)
+	}
+
+	if len(env.Tools) != 1 || env.Tools[0].Name != "fd" {
+		t.Fatalf("ExportEnvironment() tools = %+v, want [fd]", env.Tools)
+	}
+	if env.Tools[0].BuildType != "maximum" || env.Tools[0].Version != "10.2.0" {
+		t.Errorf("ExportEnvironment() should keep build type and version, got %+v", env.Tools[0])
+	}
+
+	if len(env.Dependencies) != 1 || env.Dependencies[0].Name != "ripgrep" {
+		t.Errorf("ExportEnvironment() dependencies = %+v, want [ripgrep]", env.Dependencies)
+	}
+
+	for _, entry := range append(env.Tools, env.Dependencies...) {
+		if entry.Name == "git" {
+			t.Error("ExportEnvironment() should skip pre-existing tools")
+		}
+	}
+}
+
+func TestLoadEnvironment(t *testing.T) {
+	tempDir := t.TempDir()
+
+	env := ExportEnvironment(NewManifest())
+	data, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("failed to marshal environment: %v", err)
+	}
+
+	validPath := filepath.Join(tempDir, "env.json")
+	if err := os.WriteFile(validPath, data, 0644); err != nil {
+		t.Fatalf("failed to write environment: %v", err)
+	}
+
+	if _, err := LoadEnvironment(validPath); err != nil {
+		t.Errorf("LoadEnvironment() error = %v", err)
+	}
+
+	invalidPath := filepath.Join(tempDir, "future.json")
+	if err := os.WriteFile(invalidPath, []byte(`{"schema_version": "99.0"}`), 0644); err != nil {
+		t.Fatalf("failed to write environment: %v", err)
+	}
+
+	if _, err := LoadEnvironment(invalidPath); err == nil {
+		t.Error("LoadEnvironment() should reject unsupported schema versions")
+	}
+
+	if _, err := LoadEnvironment(filepath.Join(tempDir, "missing.json")); err == nil {
+		t.Error("LoadEnvironment() should fail for missing files")
+	}
+}
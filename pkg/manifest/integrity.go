@@ -0,0 +1,158 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileRecord is the state of an installed file when it was installed
+type FileRecord struct {
+	Path    string    `json:"path"`
+	SHA256  string    `json:"sha256"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// DriftKind is how an installation differs from its record
+type DriftKind string
+
+const (
+	DriftMissing     DriftKind = "missing"     // A recorded binary is gone
+	DriftModified    DriftKind = "modified"    // A recorded binary changed since installation
+	DriftShadowed    DriftKind = "shadowed"    // PATH runs another binary of the same name
+	DriftUnreachable DriftKind = "unreachable" // PATH does not reach a recorded binary
+	DriftVersion     DriftKind = "version"     // The tool reports another version than recorded
+)
+
+// Drift is a difference between an installation and its record
+type Drift struct {
+	Tool   string    `json:"tool"`
+	Kind   DriftKind `json:"kind"`
+	Path   string    `json:"path,omitempty"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// String describes the drift in one line
+func (d Drift) String() string {
+	switch d.Kind {
+	case DriftMissing:
+		return fmt.Sprintf("%s is missing", d.Path)
+	case DriftModified:
+		return fmt.Sprintf("%s was modified (%s)", d.Path, d.Detail)
+	case DriftShadowed:
+		return fmt.Sprintf("%s is shadowed by %s", d.Path, d.Detail)
+	case DriftUnreachable:
+		return fmt.Sprintf("%s is not on PATH", d.Path)
+	case DriftVersion:
+		return fmt.Sprintf("version %s", d.Detail)
+	default:
+		return fmt.Sprintf("%s: %s %s", d.Kind, d.Path, d.Detail)
+	}
+}
+
+// RecordFile reads the size, modification time and checksum of a file
+func RecordFile(path string) (FileRecord, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return FileRecord{}, err
+	}
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return FileRecord{}, err
+	}
+	return FileRecord{Path: path, SHA256: sum, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// RecordFiles records the files that exist, skipping the others
+func RecordFiles(paths []string) []FileRecord {
+	var records []FileRecord
+	for _, path := range paths {
+		if record, err := RecordFile(path); err == nil {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// CheckFiles compares the recorded binaries of an installation with the
+// files on disk and with what PATH runs: a binary is missing, modified,
+// shadowed by another one or not reached by PATH at all. Without full, a file is only hashed
+// when its modification time changed but its size did not, so that checking
+// every tool stays fast; full hashes every file. Binaries installed before
+// checksums were recorded are only checked for existence and PATH.
+func CheckFiles(name string, record *InstallationRecord, lookPath func(string) (string, error), full bool) []Drift {
+	recorded := make(map[string]FileRecord)
+	for _, file := range record.Files {
+		recorded[file.Path] = file
+	}
+
+	var drifts []Drift
+	for _, path := range record.BinaryPaths {
+		info, err := os.Stat(path)
+		if err != nil {
+			drifts = append(drifts, Drift{Tool: name, Kind: DriftMissing, Path: path})
+			continue
+		}
+
+		if file, ok := recorded[path]; ok {
+			if detail := compareFile(file, info, full); detail != "" {
+				drifts = append(drifts, Drift{Tool: name, Kind: DriftModified, Path: path, Detail: detail})
+			}
+		}
+
+		found, err := lookPath(filepath.Base(path))
+		switch {
+		case err != nil:
+			drifts = append(drifts, Drift{Tool: name, Kind: DriftUnreachable, Path: path})
+		case !sameFile(found, path):
+			drifts = append(drifts, Drift{Tool: name, Kind: DriftShadowed, Path: path, Detail: found})
+		}
+	}
+	return drifts
+}
+
+// compareFile describes how a file differs from its record, empty when it
+// does not
+func compareFile(file FileRecord, info os.FileInfo, full bool) string {
+	if info.Size() != file.Size {
+		return fmt.Sprintf("size %d, was %d", info.Size(), file.Size)
+	}
+	if !full && info.ModTime().Equal(file.ModTime) {
+		return ""
+	}
+	sum, err := fileSHA256(file.Path)
+	if err != nil {
+		return err.Error()
+	}
+	if sum != file.SHA256 {
+		return "checksum changed"
+	}
+	return ""
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sameFile reports whether two paths lead to the same file, such as a
+// symlink in /usr/local/bin to a binary in ~/.cargo/bin
+func sameFile(a, b string) bool {
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
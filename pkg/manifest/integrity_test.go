@@ -0,0 +1,110 @@
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeBinary(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// lookPathIn resolves binaries in a single directory, like a PATH holding it
+func lookPathIn(dir string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			return "", errors.New("not found")
+		}
+		return path, nil
+	}
+}
+
+func TestRecordFilesSkipsMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fd")
+	writeBinary(t, path, "fd")
+
+	files := RecordFiles([]string{path, filepath.Join(dir, "missing")})
+	if len(files) != 1 {
+		t.Fatalf("expected one record, got %+v", files)
+	}
+	if files[0].Path != path || files[0].Size != 2 || files[0].SHA256 != "8bd574fdb05c2dc5017188a2f4c32d5b81963e0a33eccba92404e968c665006d" {
+		t.Errorf("unexpected record %+v", files[0])
+	}
+}
+
+func TestCheckFilesReportsMissingModifiedAndShadowedBinaries(t *testing.T) {
+	dir, other := t.TempDir(), t.TempDir()
+	for _, name := range []string{"fd", "rg", "bat", "eza", "dust"} {
+		writeBinary(t, filepath.Join(dir, name), name)
+	}
+	record := &InstallationRecord{
+		BinaryPaths: []string{filepath.Join(dir, "fd"), filepath.Join(dir, "rg"), filepath.Join(dir, "bat"), filepath.Join(dir, "eza"), filepath.Join(dir, "dust")},
+	}
+	record.Files = RecordFiles(record.BinaryPaths)
+
+	os.Remove(filepath.Join(dir, "rg"))
+	writeBinary(t, filepath.Join(dir, "bat"), "bat 2")
+	writeBinary(t, filepath.Join(other, "eza"), "eza")
+	lookPath := func(name string) (string, error) {
+		switch name {
+		case "eza":
+			return filepath.Join(other, "eza"), nil
+		case "dust":
+			return "", os.ErrNotExist
+		}
+		return lookPathIn(dir)(name)
+	}
+
+	drifts := CheckFiles("tools", record, lookPath, false)
+	want := []Drift{
+		{Tool: "tools", Kind: DriftMissing, Path: filepath.Join(dir, "rg")},
+		{Tool: "tools", Kind: DriftModified, Path: filepath.Join(dir, "bat"), Detail: "size 5, was 3"},
+		{Tool: "tools", Kind: DriftShadowed, Path: filepath.Join(dir, "eza"), Detail: filepath.Join(other, "eza")},
+		{Tool: "tools", Kind: DriftUnreachable, Path: filepath.Join(dir, "dust")},
+	}
+	if len(drifts) != len(want) {
+		t.Fatalf("expected %+v, got %+v", want, drifts)
+	}
+	for i := range want {
+		if drifts[i] != want[i] {
+			t.Errorf("expected %+v, got %+v", want[i], drifts[i])
+		}
+	}
+}
+
+func TestCheckFilesHashesUnchangedFilesOnlyWhenFull(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fd")
+	writeBinary(t, path, "fd 1")
+	record := &InstallationRecord{BinaryPaths: []string{path}, Files: RecordFiles([]string{path})}
+
+	// Touched but not changed
+	later := record.Files[0].ModTime.Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if drifts := CheckFiles("fd", record, lookPathIn(dir), false); len(drifts) != 0 {
+		t.Errorf("expected a touched file to match its checksum, got %+v", drifts)
+	}
+
+	// Changed with the same size and modification time
+	writeBinary(t, path, "fd 2")
+	if err := os.Chtimes(path, record.Files[0].ModTime, record.Files[0].ModTime); err != nil {
+		t.Fatal(err)
+	}
+	if drifts := CheckFiles("fd", record, lookPathIn(dir), false); len(drifts) != 0 {
+		t.Errorf("expected files with the recorded modification time not to be hashed, got %+v", drifts)
+	}
+	drifts := CheckFiles("fd", record, lookPathIn(dir), true)
+	if len(drifts) != 1 || drifts[0].Kind != DriftModified || drifts[0].Detail != "checksum changed" {
+		t.Errorf("expected the checksum to differ, got %+v", drifts)
+	}
+}
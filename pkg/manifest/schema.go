@@ -21,6 +21,7 @@ type InstallationManifest struct {
 type InstallationRecord struct {
 	Method           InstallationMethod `json:"method"`
 	Version          string             `json:"version"`
+	BuildType        string             `json:"build_type,omitempty"`
 	InstalledAt      time.Time          `json:"installed_at"`
 	BinaryPaths      []string           `json:"binary_paths"`
 	BuildDir         string             `json:"build_dir,omitempty"`
@@ -31,6 +32,8 @@ type InstallationRecord struct {
 	InstallationContext []string        `json:"installation_context"`
 	ConfigFiles      []string           `json:"config_files,omitempty"`
 	SystemPackages   []string           `json:"system_packages,omitempty"`
+	Environment      map[string]string  `json:"environment,omitempty"` // Variables the tool needs in the shell
+	Files            []FileRecord       `json:"files,omitempty"`       // Binaries as installed, to detect drift
 }
 
 // DependencyRecord tracks shared dependencies
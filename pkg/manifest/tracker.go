@@ -38,6 +38,7 @@ func (t *Tracker) TrackInstallation(name string, config TrackingConfig) error {
 	record := &InstallationRecord{
 		Method:              config.Method,
 		Version:             config.Version,
+		BuildType:           config.BuildType,
 		InstalledAt:         time.Now(),
 		BinaryPaths:         config.BinaryPaths,
 		BuildDir:            config.BuildDir,
@@ -48,6 +49,8 @@ func (t *Tracker) TrackInstallation(name string, config TrackingConfig) error {
 		InstallationContext: config.InstallationContext,
 		ConfigFiles:         config.ConfigFiles,
 		SystemPackages:      config.SystemPackages,
+		Environment:         config.Environment,
+		Files:               config.Files,
 	}
 	
 	// Add to manifest
@@ -176,6 +179,7 @@ func (t *Tracker) trackDependency(depName, dependent string, method Installation
 type TrackingConfig struct {
 	Method              InstallationMethod
 	Version             string
+	BuildType           string
 	BinaryPaths         []string
 	BuildDir            string
 	SourceRepo          string
@@ -185,6 +189,8 @@ type TrackingConfig struct {
 	InstallationContext []string
 	ConfigFiles         []string
 	SystemPackages      []string
+	Environment         map[string]string
+	Files               []FileRecord
 }
 
 // GetDependents returns tools that depend on a given dependency
@@ -245,6 +251,124 @@ func (t *Tracker) GetBuildDir(toolName string) (string, error) {
 	return record.BuildDir, nil
 }
 
+// AddConfigFiles records configuration files installed for a tracked tool
+func (t *Tracker) AddConfigFiles(toolName string, files []string) error {
+	record, exists := t.manifest.GetInstallation(toolName)
+	if !exists {
+		return fmt.Errorf("tool %s is not tracked", toolName)
+	}
+
+	for _, file := range files {
+		if !contains(record.ConfigFiles, file) {
+			record.ConfigFiles = append(record.ConfigFiles, file)
+		}
+	}
+	return t.manager.Save(t.manifest)
+}
+
+// SetEnvironment records the environment variables a tracked tool needs
+func (t *Tracker) SetEnvironment(toolName string, env map[string]string) error {
+	record, exists := t.manifest.GetInstallation(toolName)
+	if !exists {
+		return fmt.Errorf("tool %s is not tracked", toolName)
+	}
+
+	record.Environment = env
+	return t.manager.Save(t.manifest)
+}
+
+// MarkUserRequested records that the user asked for a tracked tool, which
+// was installed for a bundle or as a dependency before
+func (t *Tracker) MarkUserRequested(toolName string) error {
+	record, exists := t.manifest.GetInstallation(toolName)
+	if !exists {
+		return fmt.Errorf("tool %s is not tracked", toolName)
+	}
+
+	record.UserRequested = true
+	if !contains(record.InstallationContext, "user_request") {
+		record.InstallationContext = append(record.InstallationContext, "user_request")
+	}
+	return t.manager.Save(t.manifest)
+}
+
+// RecordFiles records the binaries of a tracked tool as they are now, after
+// it was installed or reinstalled
+func (t *Tracker) RecordFiles(toolName string) error {
+	record, exists := t.manifest.GetInstallation(toolName)
+	if !exists {
+		return fmt.Errorf("tool %s is not tracked", toolName)
+	}
+
+	record.Files = RecordFiles(record.BinaryPaths)
+	return t.manager.Save(t.manifest)
+}
+
+// UpdateInstallation changes the recorded version and binaries of a tracked
+// tool to what is on the system now, and records the binaries again. An empty
+// version or nil binary paths keep the recorded ones.
+func (t *Tracker) UpdateInstallation(toolName, version string, binaryPaths []string) error {
+	record, exists := t.manifest.GetInstallation(toolName)
+	if !exists {
+		return fmt.Errorf("tool %s is not tracked", toolName)
+	}
+
+	if version != "" {
+		record.Version = version
+	}
+	if binaryPaths != nil {
+		record.BinaryPaths = binaryPaths
+	}
+	record.Files = RecordFiles(record.BinaryPaths)
+	return t.manager.Save(t.manifest)
+}
+
+// SetBuildType records the build type a tool or bundle was installed with
+func (t *Tracker) SetBuildType(name, buildType string) error {
+	record, exists := t.manifest.GetInstallation(name)
+	if !exists {
+		return fmt.Errorf("%s is not tracked", name)
+	}
+	record.BuildType = buildType
+	return t.manager.Save(t.manifest)
+}
+
+// Untrack removes a tool from the manifest and from the dependents of its
+// dependencies, without touching its files
+func (t *Tracker) Untrack(toolName string) error {
+	if _, exists := t.manifest.Installations[toolName]; !exists {
+		return fmt.Errorf("tool %s is not tracked", toolName)
+	}
+
+	delete(t.manifest.Installations, toolName)
+	for _, dep := range t.manifest.Dependencies {
+		var dependents []string
+		for _, dependent := range dep.Dependents {
+			if dependent != toolName {
+				dependents = append(dependents, dependent)
+			}
+		}
+		dep.Dependents = dependents
+	}
+	return t.manager.Save(t.manifest)
+}
+
+// RestoreInstallation puts back the record of a tool that was untracked, such
+// as when an uninstall is undone
+func (t *Tracker) RestoreInstallation(toolName string, record *InstallationRecord) error {
+	if _, exists := t.manifest.Installations[toolName]; exists {
+		return fmt.Errorf("tool %s is already tracked", toolName)
+	}
+
+	t.manifest.AddInstallation(toolName, record)
+	for _, dep := range record.Dependencies {
+		if err := t.trackDependency(dep, toolName, record.Method); err != nil {
+			return fmt.Errorf("failed to track dependency %s: %w", dep, err)
+		}
+	}
+	return t.manager.Save(t.manifest)
+}
+
 // Helper function to check if slice contains string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
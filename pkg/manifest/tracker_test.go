@@ -998,4 +998,141 @@ func BenchmarkTracker_IsInstalled(b *testing.B) {
 		toolName := "tool-" + string(rune(i%100))
 		_ = tracker.IsInstalled(toolName)
 	}
-}
\ No newline at end of file
+}
+func TestTracker_AddConfigFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", tempDir)
+
+	tracker, err := NewTracker()
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+
+	if err := tracker.AddConfigFiles("bat", []string{"/home/user/.config/bat/config"}); err == nil {
+		t.Error("AddConfigFiles() should fail for untracked tools")
+	}
+
+	config := TrackingConfig{Method: MethodSourceBuild, Version: "0.24.0", ConfigFiles: []string{"/home/user/.config/bat/config"}}
+	if err := tracker.TrackInstallation("bat", config); err != nil {
+		t.Fatalf("TrackInstallation() error = %v", err)
+	}
+	if err := tracker.AddConfigFiles("bat", []string{"/home/user/.config/bat/config", "/home/user/.config/bat/themes"}); err != nil {
+		t.Fatalf("AddConfigFiles() error = %v", err)
+	}
+
+	// The change is saved and files are not recorded twice
+	reloaded, err := NewTracker()
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	record, _ := reloaded.GetInstallation("bat")
+	if len(record.ConfigFiles) != 2 {
+		t.Errorf("expected 2 config files, got %v", record.ConfigFiles)
+	}
+}
+
+func TestTracker_UpdateInstallation(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	tracker, err := NewTracker()
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	if err := tracker.UpdateInstallation("fd", "9.0.0", nil); err == nil {
+		t.Error("UpdateInstallation() should fail for untracked tools")
+	}
+
+	moved := filepath.Join(tempDir, "bin", "fd")
+	os.MkdirAll(filepath.Dir(moved), 0755)
+	os.WriteFile(moved, []byte("fd"), 0755)
+	config := TrackingConfig{Method: MethodCargoInstall, Version: "8.7.0", BinaryPaths: []string{"/gone/fd"}}
+	if err := tracker.TrackInstallation("fd", config); err != nil {
+		t.Fatalf("TrackInstallation() error = %v", err)
+	}
+	if err := tracker.UpdateInstallation("fd", "", []string{moved}); err != nil {
+		t.Fatalf("UpdateInstallation() error = %v", err)
+	}
+
+	reloaded, err := NewTracker()
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	record, _ := reloaded.GetInstallation("fd")
+	if record.Version != "8.7.0" || len(record.BinaryPaths) != 1 || record.BinaryPaths[0] != moved {
+		t.Errorf("expected the version kept and the binary moved, got %s %v", record.Version, record.BinaryPaths)
+	}
+	if len(record.Files) != 1 || record.Files[0].Path != moved {
+		t.Errorf("expected the moved binary to be recorded, got %+v", record.Files)
+	}
+}
+
+func TestTracker_Untrack(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	tracker, err := NewTracker()
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	for _, name := range []string{"fd", "ripgrep"} {
+		config := TrackingConfig{Method: MethodCargoInstall, Version: "1.0.0", Dependencies: []string{"rust"}}
+		if err := tracker.TrackInstallation(name, config); err != nil {
+			t.Fatalf("TrackInstallation() error = %v", err)
+		}
+	}
+	if err := tracker.Untrack("fd"); err != nil {
+		t.Fatalf("Untrack() error = %v", err)
+	}
+	if err := tracker.Untrack("fd"); err == nil {
+		t.Error("Untrack() should fail for untracked tools")
+	}
+
+	reloaded, err := NewTracker()
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	if reloaded.IsInstalled("fd") || !reloaded.IsInstalled("ripgrep") {
+		t.Error("expected only fd to be untracked")
+	}
+	if dependents := reloaded.GetDependents("rust"); len(dependents) != 1 || dependents[0] != "ripgrep" {
+		t.Errorf("expected ripgrep as the only dependent of rust, got %v", dependents)
+	}
+}
+
+func TestTracker_RestoreInstallation(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	tracker, err := NewTracker()
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	config := TrackingConfig{Method: MethodSourceBuild, Version: "1.0.0", Dependencies: []string{"rust"}}
+	if err := tracker.TrackInstallation("fd", config); err != nil {
+		t.Fatalf("TrackInstallation() error = %v", err)
+	}
+	record, _ := tracker.GetInstallation("fd")
+	if err := tracker.RestoreInstallation("fd", record); err == nil {
+		t.Error("RestoreInstallation() should fail for tracked tools")
+	}
+	if err := tracker.Untrack("fd"); err != nil {
+		t.Fatalf("Untrack() error = %v", err)
+	}
+	if err := tracker.RestoreInstallation("fd", record); err != nil {
+		t.Fatalf("RestoreInstallation() error = %v", err)
+	}
+
+	reloaded, err := NewTracker()
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	if restored, found := reloaded.GetInstallation("fd"); !found || restored.Version != "1.0.0" {
+		t.Errorf("expected fd to be tracked again, got %+v", restored)
+	}
+	if dependents := reloaded.GetDependents("rust"); len(dependents) != 1 || dependents[0] != "fd" {
+		t.Errorf("expected fd as a dependent of rust again, got %v", dependents)
+	}
+}
@@ -0,0 +1,311 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gearbox/pkg/manifest"
+)
+
+// Ways to adopt a tool
+const (
+	AdoptAuto        = "auto"         // Managed, unless the system package manager or a manual download installed it
+	AdoptManaged     = "managed"      // Tracked like a gearbox installation, so uninstall removes it
+	AdoptPreExisting = "pre-existing" // Tracked as pre-existing, so uninstall never removes it
+)
+
+// AdoptOptions controls 'adopt'
+type AdoptOptions struct {
+	Tools  []string // Catalog tools to look for, all when empty
+	All    bool     // Adopt every tool found without asking
+	As     string   // AdoptAuto, AdoptManaged or AdoptPreExisting
+	DryRun bool
+}
+
+// AdoptionCandidate is a catalog tool installed outside gearbox
+type AdoptionCandidate struct {
+	Tool    ToolConfig
+	Path    string
+	Version string
+	Method  manifest.InstallationMethod
+	Package string // System package owning the binary
+}
+
+// managedByDefault reports whether the tool is adopted as managed unless the
+// user says otherwise. Gearbox does not take over what the system package
+// manager or a manual download installed.
+func (c AdoptionCandidate) managedByDefault() bool {
+	return c.Method != manifest.MethodSystemPackage && c.Method != manifest.MethodManualDownload
+}
+
+// installLocations are the directories language package managers install
+// binaries into
+type installLocations struct {
+	cargoBin string
+	goBin    string
+	pipxBin  string
+	npmBin   string
+	prefix   string // INSTALL_PREFIX/bin, where the installation scripts put source builds
+}
+
+// detectInstallLocations finds the install locations of this user
+func detectInstallLocations() installLocations {
+	home := os.Getenv("HOME")
+	locations := installLocations{
+		cargoBin: filepath.Join(home, ".cargo", "bin"),
+		goBin:    os.Getenv("GOBIN"),
+		pipxBin:  filepath.Join(home, ".local", "bin"),
+		prefix:   filepath.Join(installPrefix(), "bin"),
+	}
+	if cargoHome := os.Getenv("CARGO_HOME"); cargoHome != "" {
+		locations.cargoBin = filepath.Join(cargoHome, "bin")
+	}
+	if locations.goBin == "" {
+		gopath := filepath.Join(home, "go")
+		if env := filepath.SplitList(os.Getenv("GOPATH")); len(env) > 0 && env[0] != "" {
+			gopath = env[0]
+		}
+		locations.goBin = filepath.Join(gopath, "bin")
+	}
+	if _, err := exec.LookPath("npm"); err == nil {
+		if output, err := exec.Command("npm", "prefix", "-g").Output(); err == nil {
+			locations.npmBin = filepath.Join(strings.TrimSpace(string(output)), "bin")
+		}
+	}
+	return locations
+}
+
+// dirs returns the locations searched for binaries that are not on PATH
+func (l installLocations) dirs() []string {
+	var dirs []string
+	for _, dir := range []string{l.cargoBin, l.goBin, l.pipxBin, l.npmBin, l.prefix} {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// findBinary looks for a binary on PATH, then in the install locations
+func (l installLocations) findBinary(name string) (string, bool) {
+	if path, err := exec.LookPath(name); err == nil {
+		if abs, err := filepath.Abs(path); err == nil {
+			return abs, true
+		}
+		return path, true
+	}
+	for _, dir := range l.dirs() {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// detectMethod guesses how a binary was installed from where it lives.
+// owner returns the system package owning a file, empty for none.
+func (l installLocations) detectMethod(path string, owner func(string) string) (manifest.InstallationMethod, string) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		resolved = path
+	}
+	dir := filepath.Dir(path)
+
+	switch {
+	case dir == l.cargoBin:
+		return manifest.MethodCargoInstall, ""
+	case dir == l.goBin:
+		return manifest.MethodGoInstall, ""
+	case strings.Contains(resolved, "/pipx/venvs/"):
+		return manifest.MethodPipx, ""
+	case strings.Contains(resolved, "/node_modules/"):
+		// npm links its binaries into node_modules, and its global prefix
+		// may be /usr, so the bin directory alone says nothing
+		return manifest.MethodNpmGlobal, ""
+	}
+	for _, candidate := range []string{path, resolved} {
+		if pkg := owner(candidate); pkg != "" {
+			return manifest.MethodSystemPackage, pkg
+		}
+	}
+	if dir == l.prefix {
+		return manifest.MethodSourceBuild, ""
+	}
+	return manifest.MethodManualDownload, ""
+}
+
+// FindAdoptable looks for the catalog tools that are installed but not
+// tracked in the manifest, on PATH and in the cargo, go, pipx and npm install
+// locations, and probes their version and install method
+func (o *Orchestrator) FindAdoptable(toolNames []string) ([]AdoptionCandidate, error) {
+	tools := o.configMgr.GetConfig().Tools
+	if len(toolNames) > 0 {
+		tools = nil
+		for _, name := range toolNames {
+			tool, found := o.findTool(name)
+			if !found {
+				return nil, fmt.Errorf("tool not found: %s", name)
+			}
+			tools = append(tools, tool)
+		}
+	}
+
+	m := manifest.NewManifest()
+	if manager := manifest.NewManager(); manager.Exists() {
+		loaded, err := manager.Load()
+		if err != nil {
+			return nil, err
+		}
+		m = loaded
+	}
+
+	owner := func(string) string { return "" }
+	if pm, err := detectPackageManager(); err == nil {
+		owner = pm.owningPackage
+	}
+	locations := detectInstallLocations()
+
+	var candidates []AdoptionCandidate
+	for _, tool := range tools {
+		// Nerd Fonts are fonts, not binaries
+		if tool.BinaryName == "" || tool.Name == "nerd-fonts" || m.IsInstalled(tool.Name) {
+			continue
+		}
+		path, found := locations.findBinary(tool.BinaryName)
+		if !found {
+			continue
+		}
+		method, pkg := locations.detectMethod(path, owner)
+		candidates = append(candidates, AdoptionCandidate{
+			Tool:    tool,
+			Path:    path,
+			Version: toolVersionAt(tool, path),
+			Method:  method,
+			Package: pkg,
+		})
+	}
+	return candidates, nil
+}
+
+// Adopt records catalog tools installed outside gearbox in the manifest,
+// asking for each whether gearbox manages it or it stays pre-existing
+func (o *Orchestrator) Adopt(opts AdoptOptions) error {
+	switch opts.As {
+	case "", AdoptAuto, AdoptManaged, AdoptPreExisting:
+	default:
+		return fmt.Errorf("invalid --as %q (use %s, %s or %s)", opts.As, AdoptAuto, AdoptManaged, AdoptPreExisting)
+	}
+
+	candidates, err := o.FindAdoptable(opts.Tools)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		fmt.Printf("✅ No untracked catalog tools found\n")
+		return nil
+	}
+
+	fmt.Printf("🔎 Found %d catalog tools installed outside gearbox\n\n", len(candidates))
+	for _, c := range candidates {
+		source := string(c.Method)
+		if c.Package != "" {
+			source += ": " + c.Package
+		}
+		fmt.Printf("%-15s %-12s %-28s %s\n", c.Tool.Name, c.Version, source, c.Path)
+	}
+	fmt.Println()
+	if opts.DryRun {
+		fmt.Printf("Run without --dry-run to adopt them\n")
+		return nil
+	}
+
+	tracker, err := manifest.NewTracker()
+	if err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	var managed, preExisting int
+	for _, c := range candidates {
+		adoptManaged := c.managedByDefault()
+		switch opts.As {
+		case AdoptManaged:
+			adoptManaged = true
+		case AdoptPreExisting:
+			adoptManaged = false
+		}
+
+		if !opts.All {
+			answer, ok := askAdoption(c, adoptManaged)
+			if !ok {
+				fmt.Printf("   Skipped %s\n", c.Tool.Name)
+				continue
+			}
+			adoptManaged = answer
+		}
+
+		if err := adoptTool(tracker, c, adoptManaged); err != nil {
+			fmt.Printf("❌ %s: %v\n", c.Tool.Name, err)
+			continue
+		}
+		if adoptManaged {
+			managed++
+			fmt.Printf("✅ %s adopted as managed (%s)\n", c.Tool.Name, c.Method)
+		} else {
+			preExisting++
+			fmt.Printf("✅ %s recorded as pre-existing\n", c.Tool.Name)
+		}
+	}
+
+	fmt.Printf("\n📈 Adopted %d tools: %d managed, %d pre-existing\n", managed+preExisting, managed, preExisting)
+	return nil
+}
+
+// askAdoption asks how to adopt a tool. It returns whether to adopt it as
+// managed, and false when the tool is skipped.
+func askAdoption(c AdoptionCandidate, managedByDefault bool) (bool, bool) {
+	choices := "[M/p/s]"
+	if !managedByDefault {
+		choices = "[m/P/s]"
+	}
+	fmt.Printf("Adopt %s as [m]anaged, [p]re-existing or [s]kip? %s: ", c.Tool.Name, choices)
+	var response string
+	fmt.Scanln(&response)
+	switch strings.ToLower(response) {
+	case "":
+		return managedByDefault, true
+	case "m", "managed":
+		return true, true
+	case "p", "pre-existing":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// adoptTool records a tool in the manifest. Managed tools get the detected
+// install method and the checksums of their binary, so uninstall and drift
+// detection treat them like tools gearbox installed.
+func adoptTool(tracker *manifest.Tracker, c AdoptionCandidate, managed bool) error {
+	if !managed {
+		return tracker.TrackPreExisting(c.Tool.Name, c.Path, c.Version)
+	}
+
+	config := manifest.TrackingConfig{
+		Method:              c.Method,
+		Version:             c.Version,
+		BinaryPaths:         []string{c.Path},
+		SourceRepo:          c.Tool.Repository,
+		UserRequested:       true,
+		InstallationContext: []string{"adopted"},
+		Environment:         c.Tool.Env,
+		Files:               manifest.RecordFiles([]string{c.Path}),
+	}
+	if c.Package != "" {
+		config.SystemPackages = []string{c.Package}
+	}
+	return tracker.TrackInstallation(c.Tool.Name, config)
+}
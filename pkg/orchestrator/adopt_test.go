@@ -0,0 +1,102 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gearbox/pkg/manifest"
+)
+
+func TestDetectMethodFromInstallLocation(t *testing.T) {
+	home := t.TempDir()
+	locations := installLocations{
+		cargoBin: filepath.Join(home, ".cargo", "bin"),
+		goBin:    filepath.Join(home, "go", "bin"),
+		pipxBin:  filepath.Join(home, ".local", "bin"),
+		npmBin:   filepath.Join(home, ".npm-global", "bin"),
+		prefix:   "/usr/local/bin",
+	}
+
+	// pipx and npm link their binaries into a bin directory
+	venv := filepath.Join(home, ".local", "share", "pipx", "venvs", "ruff", "bin", "ruff")
+	modules := filepath.Join(home, "lib", "node_modules", "pnpm", "bin", "pnpm.cjs")
+	for _, target := range []string{venv, modules} {
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(target, nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.MkdirAll(locations.pipxBin, 0755)
+	os.MkdirAll(filepath.Join(home, "bin"), 0755)
+	if err := os.Symlink(venv, filepath.Join(locations.pipxBin, "ruff")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(modules, filepath.Join(home, "bin", "pnpm")); err != nil {
+		t.Fatal(err)
+	}
+
+	owner := func(path string) string {
+		if path == "/usr/bin/rg" {
+			return "ripgrep"
+		}
+		return ""
+	}
+	for _, tt := range []struct {
+		path   string
+		method manifest.InstallationMethod
+		pkg    string
+	}{
+		{filepath.Join(locations.cargoBin, "fd"), manifest.MethodCargoInstall, ""},
+		{filepath.Join(locations.goBin, "lazygit"), manifest.MethodGoInstall, ""},
+		{filepath.Join(locations.pipxBin, "ruff"), manifest.MethodPipx, ""},
+		{filepath.Join(home, "bin", "pnpm"), manifest.MethodNpmGlobal, ""},
+		{"/usr/bin/rg", manifest.MethodSystemPackage, "ripgrep"},
+		{"/usr/local/bin/yazi", manifest.MethodSourceBuild, ""},
+		{filepath.Join(home, "bin", "jq"), manifest.MethodManualDownload, ""},
+	} {
+		method, pkg := locations.detectMethod(tt.path, owner)
+		if method != tt.method || pkg != tt.pkg {
+			t.Errorf("%s: expected %s %q, got %s %q", tt.path, tt.method, tt.pkg, method, pkg)
+		}
+	}
+}
+
+func TestFindAdoptableSkipsTrackedTools(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	bin := filepath.Join(home, "bin")
+	os.MkdirAll(bin, 0755)
+	for _, name := range []string{"gearbox-tracked", "gearbox-untracked"} {
+		script := "#!/bin/sh\necho '" + name + " 1.2.3'\n"
+		if err := os.WriteFile(filepath.Join(bin, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bin+string(filepath.ListSeparator)+os.Getenv("PATH"))
+
+	m := manifest.NewManifest()
+	m.AddInstallation("gearbox-tracked", &manifest.InstallationRecord{Method: manifest.MethodSourceBuild})
+	if err := manifest.NewManager().Save(m); err != nil {
+		t.Fatal(err)
+	}
+
+	o := &Orchestrator{configMgr: &ConfigManager{config: Config{Tools: []ToolConfig{
+		{Name: "gearbox-tracked", BinaryName: "gearbox-tracked", TestCommand: "--version"},
+		{Name: "gearbox-untracked", BinaryName: "gearbox-untracked", TestCommand: "--version"},
+		{Name: "gearbox-absent", BinaryName: "gearbox-absent", TestCommand: "--version"},
+	}}}}
+	candidates, err := o.FindAdoptable(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 1 {
+		t.Fatalf("expected only the untracked tool, got %+v", candidates)
+	}
+	c := candidates[0]
+	if c.Tool.Name != "gearbox-untracked" || c.Path != filepath.Join(bin, "gearbox-untracked") || c.Version != "1.2.3" || c.Method != manifest.MethodManualDownload {
+		t.Errorf("unexpected candidate %+v", c)
+	}
+}
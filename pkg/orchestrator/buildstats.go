@@ -0,0 +1,196 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"gearbox/pkg/manifest"
+)
+
+// BuildStatsSchemaVersion defines the current build statistics file version
+const BuildStatsSchemaVersion = "1.0"
+
+// buildStatsFile records measurements of past builds in ~/.gearbox
+const buildStatsFile = "build-stats.json"
+
+// maxDurationSamples is how many recent build times estimates are based on
+const maxDurationSamples = 5
+
+// BuildRecord holds what was measured for the last build of a tool and build type
+type BuildRecord struct {
+	Disk         DiskEstimate    `json:"disk"`
+	PeakMemoryMB int             `json:"peak_memory_mb,omitempty"` // Largest process of the build
+	Seconds      float64         `json:"seconds,omitempty"`        // Build time
+	Jobs         int             `json:"jobs,omitempty"`           // Build jobs the build ran with
+	Durations    []BuildDuration `json:"durations,omitempty"`      // Recent build times, oldest first
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// BuildDuration is the time one build took with the jobs it ran with
+type BuildDuration struct {
+	Seconds float64 `json:"seconds"`
+	Jobs    int     `json:"jobs"`
+}
+
+// BuildStats stores measurements of past builds so that later plans use
+// real numbers instead of the estimates in tools.json
+type BuildStats struct {
+	SchemaVersion string                             `json:"schema_version"`
+	Tools         map[string]map[string]*BuildRecord `json:"tools"` // Tool -> build type -> record
+
+	path string
+	mu   sync.RWMutex
+}
+
+// buildStatsPath returns the location of the build statistics file
+func buildStatsPath() string {
+	return filepath.Join(os.Getenv("HOME"), manifest.ManifestDir, buildStatsFile)
+}
+
+// loadBuildStats reads the build statistics in path. Missing or unreadable
+// files start empty, since the statistics only improve estimates.
+func loadBuildStats(path string) *BuildStats {
+	stats := &BuildStats{
+		SchemaVersion: BuildStatsSchemaVersion,
+		Tools:         make(map[string]map[string]*BuildRecord),
+		path:          path,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return stats
+	}
+
+	var loaded BuildStats
+	if err := json.Unmarshal(data, &loaded); err != nil || loaded.SchemaVersion != BuildStatsSchemaVersion || loaded.Tools == nil {
+		return stats
+	}
+	stats.Tools = loaded.Tools
+	return stats
+}
+
+// lookup returns the record of a tool and build type
+func (s *BuildStats) lookup(toolName, buildType string) (BuildRecord, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, found := s.Tools[toolName][buildType]
+	if !found || record == nil {
+		return BuildRecord{}, false
+	}
+	copied := *record
+	copied.Durations = append([]BuildDuration{}, record.Durations...)
+	return copied, true
+}
+
+// record returns the record of a tool and build type, creating it if needed.
+// The caller holds the lock.
+func (s *BuildStats) record(toolName, buildType string) *BuildRecord {
+	if s.Tools[toolName] == nil {
+		s.Tools[toolName] = make(map[string]*BuildRecord)
+	}
+	record := s.Tools[toolName][buildType]
+	if record == nil {
+		record = &BuildRecord{}
+		s.Tools[toolName][buildType] = record
+	}
+	record.UpdatedAt = time.Now()
+	return record
+}
+
+// recordDisk stores the disk usage measured after a build. Sizes that could
+// not be measured keep their previous value.
+func (s *BuildStats) recordDisk(toolName, buildType string, disk DiskEstimate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := s.record(toolName, buildType)
+	if disk.SourceMB > 0 {
+		record.Disk.SourceMB = disk.SourceMB
+	}
+	if disk.BuildMB > 0 {
+		record.Disk.BuildMB = disk.BuildMB
+	}
+	if disk.InstallMB > 0 {
+		record.Disk.InstallMB = disk.InstallMB
+	}
+}
+
+// recordMemory stores the peak memory measured during a build
+func (s *BuildStats) recordMemory(toolName, buildType string, peakMB int) {
+	if peakMB <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.record(toolName, buildType).PeakMemoryMB = peakMB
+}
+
+// recordDuration stores how long a build took with the jobs it was given,
+// keeping the most recent builds
+func (s *BuildStats) recordDuration(toolName, buildType string, duration time.Duration, jobs int) {
+	if duration <= 0 || jobs <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := s.record(toolName, buildType)
+	if len(record.Durations) == 0 && record.Seconds > 0 {
+		// Files written before the history kept only the last build
+		record.Durations = []BuildDuration{{Seconds: record.Seconds, Jobs: record.Jobs}}
+	}
+	record.Seconds = duration.Seconds()
+	record.Jobs = jobs
+	record.Durations = append(record.Durations, BuildDuration{Seconds: record.Seconds, Jobs: jobs})
+	if len(record.Durations) > maxDurationSamples {
+		record.Durations = record.Durations[len(record.Durations)-maxDurationSamples:]
+	}
+}
+
+// expectedDuration returns the build time with a number of jobs: the median
+// of the recent builds scaled to the jobs, so that one slow build, such as
+// one with a cold cache, does not throw off the estimate
+func (r BuildRecord) expectedDuration(jobs int) (time.Duration, bool) {
+	samples := r.Durations
+	if len(samples) == 0 && r.Seconds > 0 {
+		samples = []BuildDuration{{Seconds: r.Seconds, Jobs: r.Jobs}}
+	}
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	durations := make([]time.Duration, len(samples))
+	for i, sample := range samples {
+		durations[i] = scaleDuration(sample.Seconds, sample.Jobs, jobs)
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	middle := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return ((durations[middle-1] + durations[middle]) / 2).Round(time.Second), true
+	}
+	return durations[middle], true
+}
+
+// save writes the build statistics
+func (s *BuildStats) save() error {
+	s.mu.RLock()
+	data, err := json.MarshalIndent(s, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode build statistics: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create build statistics directory: %w", err)
+	}
+	return os.WriteFile(s.path, append(data, '\n'), 0644)
+}
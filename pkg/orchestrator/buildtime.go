@@ -0,0 +1,148 @@
+package orchestrator
+
+import (
+	"fmt"
+	"time"
+)
+
+// serialBuildFraction is the share of a build that does not get faster with
+// more jobs, such as configuring, linking and installing
+const serialBuildFraction = 0.3
+
+// defaultBuildMinutes is the build time when neither tools.json nor earlier
+// builds say otherwise
+var defaultBuildMinutes = map[string]float64{
+	"minimal":  1,
+	"standard": 2,
+	"maximum":  4,
+}
+
+// buildTypes lists the build types from fastest to slowest
+var buildTypes = []string{"minimal", "standard", "maximum"}
+
+// plannedBuild is a build in the simulated schedule
+type plannedBuild struct {
+	finish     time.Duration // Time from now until the build ends
+	allocation buildAllocation
+}
+
+// parallelScale returns how the build time changes when a build measured
+// with one number of jobs runs with another
+func parallelScale(fromJobs, toJobs int) float64 {
+	return serialBuildFraction + (1-serialBuildFraction)*float64(max(fromJobs, 1))/float64(max(toJobs, 1))
+}
+
+// estimateDuration returns how long a tool takes to build with a build type
+// and number of jobs: measured from its recent builds, or seeded from
+// tools.json by tool or language. The second result reports whether the
+// estimate was measured.
+func (o *Orchestrator) estimateDuration(tool ToolConfig, buildType string, jobs int) (time.Duration, bool) {
+	if o.buildStats != nil {
+		if record, found := o.buildStats.lookup(tool.Name, buildType); found {
+			if duration, measured := record.expectedDuration(jobs); measured {
+				return duration, true
+			}
+		}
+	}
+
+	minutes, found := tool.BuildMinutes[buildType]
+	if !found {
+		if language, found := o.configMgr.GetConfig().Languages[tool.Language]; found {
+			minutes = language.BuildMinutes[buildType]
+		}
+	}
+	if minutes == 0 {
+		minutes = defaultBuildMinutes[buildType]
+		if minutes == 0 {
+			minutes = 2 // default
+		}
+	}
+
+	// Seeds assume the cores the build keeps busy
+	return scaleDuration(minutes*60, o.resourceWeight(tool, buildType).CPUs, jobs), false
+}
+
+// scaleDuration converts seconds measured with some jobs to a duration with others
+func scaleDuration(seconds float64, fromJobs, toJobs int) time.Duration {
+	return time.Duration(seconds * parallelScale(fromJobs, toJobs) * float64(time.Second)).Round(time.Second)
+}
+
+// estimateSchedule simulates the scheduler to estimate when the tools and
+// the running builds are done. Builds start in order as soon as the memory
+// and cores they need are free, as during installations.
+func (o *Orchestrator) estimateSchedule(scheduler *resourceScheduler, tools []ToolConfig, buildType string, running []plannedBuild) time.Duration {
+	running = append([]plannedBuild{}, running...)
+	for _, build := range running {
+		scheduler.hold(build.allocation)
+	}
+
+	var now time.Duration
+	pending := tools
+	for len(pending) > 0 || len(running) > 0 {
+		var waiting []ToolConfig
+		for _, tool := range pending {
+			allocation, ok := scheduler.reserve(o.resourceWeight(tool, buildType))
+			if !ok {
+				waiting = append(waiting, tool)
+				continue
+			}
+			duration, _ := o.estimateDuration(tool, buildType, allocation.Jobs)
+			running = append(running, plannedBuild{finish: now + duration, allocation: allocation})
+		}
+		pending = waiting
+
+		if len(running) == 0 {
+			break
+		}
+
+		next := 0
+		for i, build := range running {
+			if build.finish < running[next].finish {
+				next = i
+			}
+		}
+		now = running[next].finish
+		scheduler.release(running[next].allocation)
+		running = append(running[:next], running[next+1:]...)
+	}
+	return now
+}
+
+// EstimateBuildTime returns how long a tool takes to build with a build type
+// while the given number of builds share the machine. The second result
+// reports whether the estimate was measured.
+func (o *Orchestrator) EstimateBuildTime(tool ToolConfig, buildType string, concurrent int) (time.Duration, bool) {
+	allocation, _ := o.newBuildScheduler().reserve(o.resourceWeight(tool, buildType))
+	return o.estimateDuration(tool, buildType, max(1, allocation.Jobs/max(concurrent, 1)))
+}
+
+// planTime adds the estimated installation time to a plan. With compare set,
+// the estimates of all build types are added to help choose between them.
+func (o *Orchestrator) planTime(plan *InstallPlan, tools []ToolConfig, compare bool) {
+	for _, tool := range tools {
+		if _, found := o.EstimateBuildTime(tool, o.options.BuildType, 1); found {
+			plan.MeasuredBuilds++
+		}
+	}
+	plan.EstimatedSeconds = o.estimateSchedule(o.newBuildScheduler(), tools, o.options.BuildType, nil).Seconds()
+
+	if !compare {
+		return
+	}
+	plan.ByBuildType = make(map[string]float64)
+	for _, buildType := range buildTypes {
+		plan.ByBuildType[buildType] = o.estimateSchedule(o.newBuildScheduler(), tools, buildType, nil).Seconds()
+	}
+}
+
+// FormatEstimate returns a rounded human-readable build time
+func FormatEstimate(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+	if d = d.Round(time.Minute); d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	d = d.Round(5 * time.Minute)
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
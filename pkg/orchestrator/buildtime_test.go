@@ -0,0 +1,120 @@
+package orchestrator
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEstimateDuration(t *testing.T) {
+	o := &Orchestrator{
+		configMgr: &ConfigManager{config: Config{
+			Languages: map[string]LanguageConfig{
+				"rust": {
+					Resources:    map[string]ResourceWeight{"standard": {MemoryMB: 800, CPUs: 8}},
+					BuildMinutes: map[string]float64{"standard": 10},
+				},
+			},
+		}},
+		buildStats: loadBuildStats(filepath.Join(t.TempDir(), buildStatsFile)),
+	}
+	fd := ToolConfig{Name: "fd", Language: "rust"}
+
+	// Seeds assume the seeded cores
+	if got, measured := o.estimateDuration(fd, "standard", 8); got != 10*time.Minute || measured {
+		t.Errorf("seed with 8 jobs: got %v (measured %v)", got, measured)
+	}
+	// Half the jobs: the serial part stays, the parallel part doubles
+	if got, _ := o.estimateDuration(fd, "standard", 4); got != 17*time.Minute {
+		t.Errorf("seed with 4 jobs: got %v", got)
+	}
+	if got, _ := o.estimateDuration(fd, "maximum", 1); got != 4*time.Minute {
+		t.Errorf("fallback: got %v", got)
+	}
+
+	// The last build replaces the seed and is scaled by its jobs
+	o.buildStats.recordDuration("fd", "standard", 6*time.Minute, 2)
+	if got, measured := o.estimateDuration(fd, "standard", 2); got != 6*time.Minute || !measured {
+		t.Errorf("measured with 2 jobs: got %v (measured %v)", got, measured)
+	}
+	if got, _ := o.estimateDuration(fd, "standard", 4); got != 234*time.Second {
+		t.Errorf("measured with 4 jobs: got %v", got)
+	}
+
+	// One slow build does not move the median of the recent builds
+	o.buildStats.recordDuration("fd", "standard", 8*time.Minute, 2)
+	o.buildStats.recordDuration("fd", "standard", 30*time.Minute, 2)
+	if got, _ := o.estimateDuration(fd, "standard", 2); got != 8*time.Minute {
+		t.Errorf("median of 6, 8 and 30 minutes: got %v", got)
+	}
+	for i := 0; i < maxDurationSamples; i++ {
+		o.buildStats.recordDuration("fd", "standard", 5*time.Minute, 2)
+	}
+	record, _ := o.buildStats.lookup("fd", "standard")
+	if len(record.Durations) != maxDurationSamples {
+		t.Errorf("expected the last %d builds, got %d", maxDurationSamples, len(record.Durations))
+	}
+	if got, _ := o.estimateDuration(fd, "standard", 2); got != 5*time.Minute {
+		t.Errorf("older builds should age out: got %v", got)
+	}
+}
+
+func TestEstimateDurationFromLastBuildOnly(t *testing.T) {
+	// Statistics written before the history have the last build alone
+	record := BuildRecord{Seconds: 360, Jobs: 2}
+	if got, measured := record.expectedDuration(2); got != 6*time.Minute || !measured {
+		t.Errorf("got %v (measured %v)", got, measured)
+	}
+
+	stats := loadBuildStats(filepath.Join(t.TempDir(), buildStatsFile))
+	stats.Tools["fd"] = map[string]*BuildRecord{"standard": {Seconds: 360, Jobs: 2}}
+	stats.recordDuration("fd", "standard", 10*time.Minute, 2)
+	if got, _ := stats.Tools["fd"]["standard"].expectedDuration(2); got != 8*time.Minute {
+		t.Errorf("expected the earlier build to count, got %v", got)
+	}
+}
+
+func TestEstimateSchedule(t *testing.T) {
+	o := &Orchestrator{
+		configMgr:  &ConfigManager{config: Config{}},
+		buildStats: loadBuildStats(filepath.Join(t.TempDir(), buildStatsFile)),
+	}
+
+	// Single-job builds of 10, 4 and 6 minutes
+	var tools []ToolConfig
+	for i, minutes := range []float64{10, 4, 6} {
+		tools = append(tools, ToolConfig{
+			Name:         string(rune('a' + i)),
+			BuildMinutes: map[string]float64{"standard": minutes},
+			Resources:    map[string]ResourceWeight{"standard": {MemoryMB: 100, CPUs: 1}},
+		})
+	}
+
+	// One at a time they add up
+	if got := o.estimateSchedule(newResourceScheduler(4096, 4, 1), tools, "standard", nil); got != 20*time.Minute {
+		t.Errorf("sequential: got %v", got)
+	}
+	// Side by side the longest decides
+	if got := o.estimateSchedule(newResourceScheduler(4096, 4, 4), tools, "standard", nil); got != 10*time.Minute {
+		t.Errorf("parallel: got %v", got)
+	}
+	// A running build delays the next one when only one fits
+	running := []plannedBuild{{finish: 5 * time.Minute, allocation: buildAllocation{Jobs: 1, MemoryMB: 100}}}
+	if got := o.estimateSchedule(newResourceScheduler(4096, 4, 1), tools[:1], "standard", running); got != 15*time.Minute {
+		t.Errorf("with running build: got %v", got)
+	}
+}
+
+func TestFormatEstimate(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Second:                "<1m",
+		90 * time.Second:                "2m",
+		59*time.Minute + 50*time.Second: "1h00m",
+		97 * time.Minute:                "1h35m",
+	}
+	for d, want := range tests {
+		if got := FormatEstimate(d); got != want {
+			t.Errorf("FormatEstimate(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
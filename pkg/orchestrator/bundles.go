@@ -289,4 +289,77 @@ func (o *Orchestrator) ShowBundle(bundleName string) error {
 	
 	fmt.Println(info)
 	return nil
-}
\ No newline at end of file
+}
+// ValidatePackageManagerCoverage checks that every bundle declaring system
+// packages can be installed with each supported package manager, either from
+// an explicit package_managers list or by translating its canonical names
+// through the mapping table, and that no bundle references an unknown manager.
+func (bc *BundleConfiguration) ValidatePackageManagerCoverage(mapping *PackageMapping) []error {
+	var errs []error
+
+	for _, bundle := range bc.Bundles {
+		if len(bundle.SystemPackages) == 0 && len(bundle.PackageManagers) == 0 {
+			continue
+		}
+
+		unmapped := mapping.Unmapped(bundle.SystemPackages)
+		for _, manager := range SupportedPackageManagers {
+			if _, exists := bundle.PackageManagers[manager]; exists || len(unmapped) == 0 {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("bundle %s: no %s mapping for %s", bundle.Name, manager, strings.Join(unmapped, ", ")))
+		}
+
+		var managers []string
+		for manager := range bundle.PackageManagers {
+			managers = append(managers, manager)
+		}
+		sort.Strings(managers)
+		for _, manager := range managers {
+			if !contains(SupportedPackageManagers, manager) {
+				errs = append(errs, fmt.Errorf("bundle %s: unsupported package manager %s", bundle.Name, manager))
+			}
+		}
+	}
+
+	return errs
+}
+
+// ValidateBundles validates the bundle configuration and package mapping and prints a report
+func (o *Orchestrator) ValidateBundles() error {
+	bundleConfig, err := o.loadBundles()
+	if err != nil {
+		return fmt.Errorf("failed to load bundles: %w", err)
+	}
+
+	fmt.Printf("🔍 Validating Bundle Configuration\n")
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+
+	var errs []error
+	for _, bundle := range bundleConfig.Bundles {
+		visited := make(map[string]bool)
+		if _, err := o.expandBundle(bundle.Name, bundleConfig.Bundles, visited); err != nil {
+			errs = append(errs, fmt.Errorf("bundle %s: %w", bundle.Name, err))
+		}
+	}
+	errs = append(errs, bundleConfig.ValidatePackageManagerCoverage(o.packageMapping)...)
+	errs = append(errs, o.packageMapping.Validate()...)
+
+	// Tool dependencies use canonical names too
+	for _, tool := range o.configMgr.GetConfig().Tools {
+		for _, name := range o.packageMapping.Unmapped(o.packageMapping.SystemDependencies(tool.Dependencies)) {
+			errs = append(errs, fmt.Errorf("tool %s: dependency %s has no package mapping", tool.Name, name))
+		}
+	}
+
+	if len(errs) == 0 {
+		fmt.Printf("✅ %d bundles valid, %d packages mapped (package managers: %s)\n",
+			len(bundleConfig.Bundles), len(o.packageMapping.Packages), strings.Join(SupportedPackageManagers, ", "))
+		return nil
+	}
+
+	for _, err := range errs {
+		fmt.Printf("❌ %v\n", err)
+	}
+	return fmt.Errorf("bundle validation failed with %d errors", len(errs))
+}
@@ -227,4 +227,34 @@ func TestIsBundle(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestBundleConfigurationCoversPackageManagers(t *testing.T) {
+	o := &Orchestrator{repoDir: filepath.Join("..", "..")}
+	
+	bundleConfig, err := o.loadBundles()
+	if err != nil {
+		t.Fatalf("failed to load bundles.json: %v", err)
+	}
+	
+	mapping, err := loadPackageMapping(o.repoDir)
+	if err != nil {
+		t.Fatalf("failed to load packages.json: %v", err)
+	}
+	
+	for _, err := range bundleConfig.ValidatePackageManagerCoverage(mapping) {
+		t.Error(err)
+	}
+	for _, err := range mapping.Validate() {
+		t.Error(err)
+	}
+	
+	config, err := loadConfig(filepath.Join(o.repoDir, "config", "tools.json"))
+	if err != nil {
+		t.Fatalf("failed to load tools.json: %v", err)
+	}
+	for _, tool := range config.Tools {
+		for _, name := range mapping.Unmapped(mapping.SystemDependencies(tool.Dependencies)) {
+			t.Errorf("tool %s: dependency %s has no package mapping", tool.Name, name)
+		}
+	}
+}
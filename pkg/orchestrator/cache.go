@@ -0,0 +1,345 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gearbox/pkg/fsutil"
+)
+
+// defaultCacheDir is used when CACHE_DIR is not set in ~/.gearboxrc
+const defaultCacheDir = "~/tools/cache"
+
+// DefaultCachePruneDays is how long unused repositories are kept by 'cache prune'
+const DefaultCachePruneDays = 30
+
+// SourceCache is a shared cache of bare repositories below CACHE_DIR.
+// Installation scripts clone from it instead of the network, so reinstalls
+// and build type switches only fetch new commits.
+type SourceCache struct {
+	Dir   string
+	repos map[string]string // Upstream URL -> bare repository updated in this run
+}
+
+// CachedRepository describes a bare repository in the source cache
+type CachedRepository struct {
+	URL      string
+	Path     string
+	Tools    []string
+	Size     int64
+	LastUsed time.Time
+}
+
+// PruneOptions controls which repositories 'cache prune' removes
+type PruneOptions struct {
+	Days   int  // Remove repositories unused for this many days
+	All    bool // Remove every repository
+	DryRun bool
+}
+
+// CacheDir returns the source cache directory: $GEARBOX_CACHE_DIR, CACHE_DIR
+// from ~/.gearboxrc, or ~/tools/cache
+func CacheDir() string {
+	dir := os.Getenv("GEARBOX_CACHE_DIR")
+	if dir == "" {
+		dir = UserConfigValue("CACHE_DIR", defaultCacheDir)
+	}
+	return expandHome(dir)
+}
+
+// cacheEnabled reports whether CACHE_ENABLED is not turned off in ~/.gearboxrc
+func cacheEnabled() bool {
+	return UserConfigValue("CACHE_ENABLED", "true") != "false"
+}
+
+// newSourceCache returns the source cache in dir
+func newSourceCache(dir string) *SourceCache {
+	return &SourceCache{Dir: dir, repos: make(map[string]string)}
+}
+
+// usesRepository reports whether a tool is built from its git repository
+func usesRepository(tool ToolConfig) bool {
+	return tool.Repository != "" && (tool.Mirror == nil || !tool.Mirror.NoRepository)
+}
+
+// syncBareRepository clones a bare mirror of a repository, or fetches new
+// commits when it already exists
+func syncBareRepository(repoURL, repoPath string, env []string) error {
+	if _, err := os.Stat(repoPath); err == nil {
+		cmd := exec.Command("git", "--git-dir", repoPath, "remote", "update", "--prune")
+		cmd.Env = append(os.Environ(), env...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git failed for %s: %s", repoURL, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(repoPath), 0755); err != nil {
+		return err
+	}
+
+	// Clone next to the target so that interrupted clones are not mistaken for complete ones
+	tmp := repoPath + ".tmp"
+	os.RemoveAll(tmp)
+	cmd := exec.Command("git", "clone", "--quiet", "--mirror", repoURL, tmp)
+	cmd.Env = append(os.Environ(), env...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		os.RemoveAll(tmp)
+		return fmt.Errorf("git failed for %s: %s", repoURL, strings.TrimSpace(string(output)))
+	}
+	return os.Rename(tmp, repoPath)
+}
+
+// urlRewrite makes git fetch URLs starting with InsteadOf from Base
+type urlRewrite struct {
+	Base      string
+	InsteadOf string
+}
+
+// gitRewriteEnv returns git configuration environment variables for URL
+// rewrites. Git uses the longest matching insteadOf prefix.
+func gitRewriteEnv(rewrites []urlRewrite) []string {
+	env := []string{fmt.Sprintf("GIT_CONFIG_COUNT=%d", len(rewrites))}
+	for i, rewrite := range rewrites {
+		env = append(env,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=url.%s.insteadOf", i, rewrite.Base),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, rewrite.InsteadOf))
+	}
+	return env
+}
+
+// urlRewrites points the repositories updated in this run at the cache
+func (c *SourceCache) urlRewrites() []urlRewrite {
+	var urls []string
+	for repoURL := range c.repos {
+		urls = append(urls, repoURL)
+	}
+	sort.Strings(urls)
+
+	var rewrites []urlRewrite
+	for _, repoURL := range urls {
+		rewrites = append(rewrites, urlRewrite{Base: c.repos[repoURL], InsteadOf: repoURL})
+	}
+	return rewrites
+}
+
+// toolEnv returns the environment for a tool's installation script
+func (o *Orchestrator) toolEnv(tool ToolConfig) []string {
+	env := o.scriptEnv()
+	if o.sourceCache != nil {
+		if repoPath, found := o.sourceCache.repos[tool.Repository]; found {
+			env = append(env, "GEARBOX_SOURCE_CACHE="+repoPath)
+		}
+	}
+	return env
+}
+
+// updateSourceCache clones or fetches the repositories of the tools into the
+// source cache. Repositories that fail are cloned by the scripts as before.
+func (o *Orchestrator) updateSourceCache(tools []ToolConfig) {
+	var urls []string
+	for _, tool := range tools {
+		if usesRepository(tool) && !contains(urls, tool.Repository) {
+			urls = append(urls, tool.Repository)
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	o.reportf("🗄️  Updating source cache (%d repositories)...\n", len(urls))
+
+	// Fetch through the offline mirror when one is used
+	var env []string
+	if o.mirror != nil {
+		env = append(o.mirror.Env(), gitRewriteEnv(o.mirror.urlRewrites())...)
+	}
+
+	errs := make([]error, len(urls))
+	semaphore := make(chan struct{}, max(o.options.MaxParallelJobs, 1))
+	var wg sync.WaitGroup
+	for i, repoURL := range urls {
+		wg.Add(1)
+		go func(i int, repoURL string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			repoPath := filepath.Join(o.sourceCache.Dir, repositoryPath(repoURL))
+			if errs[i] = syncBareRepository(repoURL, repoPath, env); errs[i] == nil {
+				now := time.Now()
+				os.Chtimes(repoPath, now, now)
+			}
+		}(i, repoURL)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, repoURL := range urls {
+		if errs[i] != nil {
+			failed = append(failed, repoURL)
+			if o.options.Verbose {
+				o.reportf("⚠️  %v\n", errs[i])
+			}
+			continue
+		}
+		o.sourceCache.repos[repoURL] = filepath.Join(o.sourceCache.Dir, repositoryPath(repoURL))
+	}
+
+	if len(failed) > 0 {
+		o.reportf("⚠️  Not cached, cloning directly: %s\n", strings.Join(failed, ", "))
+	}
+	o.reportf("✅ Source cache ready (%d/%d repositories)\n\n", len(urls)-len(failed), len(urls))
+}
+
+// listCachedRepositories returns the bare repositories in a source cache
+func listCachedRepositories(dir string) ([]CachedRepository, error) {
+	root := filepath.Join(dir, "git")
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var repos []CachedRepository
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() || !strings.HasSuffix(p, ".git") {
+			return nil
+		}
+
+		repo := CachedRepository{Path: p, Size: fsutil.DirSize(p), LastUsed: info.ModTime()}
+		if output, err := exec.Command("git", "--git-dir", p, "config", "remote.origin.url").Output(); err == nil {
+			repo.URL = strings.TrimSpace(string(output))
+		}
+		repos = append(repos, repo)
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source cache: %w", err)
+	}
+	return repos, nil
+}
+
+// cachedRepositories lists the source cache with the tools using each repository
+func (o *Orchestrator) cachedRepositories() ([]CachedRepository, error) {
+	repos, err := listCachedRepositories(CacheDir())
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range repos {
+		for _, tool := range o.configMgr.GetConfig().Tools {
+			if tool.Repository != "" && tool.Repository == repos[i].URL {
+				repos[i].Tools = append(repos[i].Tools, tool.Name)
+			}
+		}
+	}
+	return repos, nil
+}
+
+// CacheStatus shows the repositories in the source cache
+func (o *Orchestrator) CacheStatus() error {
+	repos, err := o.cachedRepositories()
+	if err != nil {
+		return err
+	}
+
+	o.reportf("🗄️  Source cache: %s\n", CacheDir())
+	if !cacheEnabled() {
+		o.reportf("⚠️  Disabled (CACHE_ENABLED=false in ~/.gearboxrc)\n")
+	}
+	o.reportf("\n")
+
+	if len(repos) == 0 {
+		o.reportf("No cached repositories\n")
+		return nil
+	}
+
+	sort.Slice(repos, func(i, j int) bool {
+		return repos[i].Size > repos[j].Size
+	})
+
+	var total int64
+	o.reportf("%-20s %10s  %-12s %s\n", "TOOL", "SIZE", "LAST USED", "REPOSITORY")
+	for _, repo := range repos {
+		tools := strings.Join(repo.Tools, ",")
+		if tools == "" {
+			tools = "-"
+		}
+		o.reportf("%-20s %10s  %-12s %s\n", tools, formatBytes(repo.Size), formatAge(repo.LastUsed), repo.URL)
+		total += repo.Size
+	}
+	o.reportf("\nTotal: %d repositories, %s\n", len(repos), formatBytes(total))
+	return nil
+}
+
+// selectPrunable returns the repositories that prune removes: unused for the
+// given number of days or no longer used by any tool
+func selectPrunable(repos []CachedRepository, opts PruneOptions, now time.Time) []CachedRepository {
+	var prunable []CachedRepository
+	cutoff := now.AddDate(0, 0, -opts.Days)
+	for _, repo := range repos {
+		if opts.All || len(repo.Tools) == 0 || repo.LastUsed.Before(cutoff) {
+			prunable = append(prunable, repo)
+		}
+	}
+	return prunable
+}
+
+// PruneCache removes repositories from the source cache
+func (o *Orchestrator) PruneCache(opts PruneOptions) error {
+	repos, err := o.cachedRepositories()
+	if err != nil {
+		return err
+	}
+
+	prunable := selectPrunable(repos, opts, time.Now())
+	if len(prunable) == 0 {
+		o.reportf("✅ Nothing to prune (%d repositories in %s)\n", len(repos), CacheDir())
+		return nil
+	}
+
+	var freed int64
+	for _, repo := range prunable {
+		name := repo.URL
+		if name == "" {
+			name = repo.Path
+		}
+		if opts.DryRun {
+			o.reportf("Would remove %s (%s, last used %s)\n", name, formatBytes(repo.Size), formatAge(repo.LastUsed))
+		} else {
+			if err := os.RemoveAll(repo.Path); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", repo.Path, err)
+			}
+			o.reportf("🗑️  Removed %s (%s)\n", name, formatBytes(repo.Size))
+		}
+		freed += repo.Size
+	}
+
+	if opts.DryRun {
+		o.reportf("\nWould free %s from %d repositories\n", formatBytes(freed), len(prunable))
+	} else {
+		o.reportf("\n✅ Freed %s from %d repositories\n", formatBytes(freed), len(prunable))
+	}
+	return nil
+}
+
+// formatAge describes how long ago a time was in days
+func formatAge(t time.Time) string {
+	days := int(time.Since(t).Hours() / 24)
+	switch {
+	case days <= 0:
+		return "today"
+	case days == 1:
+		return "1 day ago"
+	default:
+		return fmt.Sprintf("%d days ago", days)
+	}
+}
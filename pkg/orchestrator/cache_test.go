@@ -0,0 +1,117 @@
+package orchestrator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheDirFromUserConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GEARBOX_CACHE_DIR", "")
+
+	if got, want := CacheDir(), filepath.Join(home, "tools", "cache"); got != want {
+		t.Errorf("default: expected %s, got %s", want, got)
+	}
+
+	rc := "# Gearbox configuration\nCACHE_DIR=\"~/src-cache\"\nCACHE_ENABLED='false'\n"
+	if err := os.WriteFile(filepath.Join(home, ".gearboxrc"), []byte(rc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := CacheDir(), filepath.Join(home, "src-cache"); got != want {
+		t.Errorf("rc: expected %s, got %s", want, got)
+	}
+	if cacheEnabled() {
+		t.Errorf("expected CACHE_ENABLED=false to disable the cache")
+	}
+
+	t.Setenv("GEARBOX_CACHE_DIR", "/var/cache/gearbox")
+	if got := CacheDir(); got != "/var/cache/gearbox" {
+		t.Errorf("env: expected /var/cache/gearbox, got %s", got)
+	}
+}
+
+func TestSelectPrunable(t *testing.T) {
+	now := time.Now()
+	repos := []CachedRepository{
+		{URL: "recent", Tools: []string{"fd"}, LastUsed: now.AddDate(0, 0, -2)},
+		{URL: "stale", Tools: []string{"bat"}, LastUsed: now.AddDate(0, 0, -45)},
+		{URL: "unused", LastUsed: now},
+	}
+
+	tests := []struct {
+		name string
+		opts PruneOptions
+		want []string
+	}{
+		{"default", PruneOptions{Days: DefaultCachePruneDays}, []string{"stale", "unused"}},
+		{"one day", PruneOptions{Days: 1}, []string{"recent", "stale", "unused"}},
+		{"all", PruneOptions{Days: 365, All: true}, []string{"recent", "stale", "unused"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, repo := range selectPrunable(repos, tt.opts, now) {
+				got = append(got, repo.URL)
+			}
+			if formatList(got) != formatList(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSourceCacheServesClones(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	// A local repository stands in for the upstream
+	upstream := filepath.Join(t.TempDir(), "upstream")
+	for _, args := range [][]string{
+		{"init", "--quiet", upstream},
+		{"-C", upstream, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "initial"},
+	} {
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s", args, output)
+		}
+	}
+
+	repoURL := "file://" + upstream
+	tool := ToolConfig{Name: "tool", Repository: repoURL}
+	o := &Orchestrator{
+		sourceCache: newSourceCache(t.TempDir()),
+		options:     InstallationOptions{MaxParallelJobs: 2},
+	}
+	o.updateSourceCache([]ToolConfig{tool})
+
+	repoPath, found := o.sourceCache.repos[repoURL]
+	if !found {
+		t.Fatalf("repository was not cached")
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "HEAD")); err != nil {
+		t.Fatalf("bare repository missing: %v", err)
+	}
+
+	// Scripts clone the upstream URL from the cache even when it is gone
+	if err := os.RemoveAll(upstream); err != nil {
+		t.Fatal(err)
+	}
+	clone := exec.Command("git", "clone", "--quiet", repoURL, filepath.Join(t.TempDir(), "clone"))
+	clone.Env = append(os.Environ(), o.toolEnv(tool)...)
+	if output, err := clone.CombinedOutput(); err != nil {
+		t.Errorf("clone through source cache failed: %s", output)
+	}
+
+	repos, err := listCachedRepositories(o.sourceCache.Dir)
+	if err != nil {
+		t.Fatalf("listCachedRepositories failed: %v", err)
+	}
+	if len(repos) != 1 || repos[0].URL != repoURL || repos[0].Size == 0 {
+		t.Errorf("unexpected cache listing: %+v", repos)
+	}
+}
@@ -1,16 +1,24 @@
 package orchestrator
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"gearbox/pkg/health"
+	"gearbox/pkg/output"
+	"gearbox/pkg/testreport"
 	"gearbox/pkg/uninstall"
 )
 
 // installCmd creates the install command
 func installCmd() *cobra.Command {
 	var opts InstallationOptions
+	var events bool
+	var reports []string
 
 	cmd := &cobra.Command{
 		Use:   "install [tools...]",
@@ -18,23 +26,36 @@ func installCmd() *cobra.Command {
 		Long: `Install one or more tools with dependency resolution, parallel execution,
 and comprehensive progress tracking. If no tools are specified, all tools will be installed.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			orchestrator, err := NewOrchestratorBuilder(opts).Build()
-			if err != nil {
-				return fmt.Errorf("failed to initialize orchestrator: %w", err)
+			if targets, _ := testreport.ParseTargets(reports); events && testreport.UsesStdout(targets) {
+				return fmt.Errorf("--events and a --report without a file cannot both use stdout")
 			}
 
-			var toolsToInstall []string
-			if len(args) == 0 {
-				// Install all tools
-				config := orchestrator.configMgr.GetConfig()
-				for _, tool := range config.Tools {
-					toolsToInstall = append(toolsToInstall, tool.Name)
+			return runWithReports(reports, func(out io.Writer) (testreport.Suite, error) {
+				reporter := NewTextReporter(out, opts.Verbose)
+				if events {
+					reporter = NewJSONReporter(os.Stdout)
+				}
+				recorder := newSuiteRecorder(reporter)
+
+				orchestrator, err := NewOrchestratorBuilder(opts).WithReporter(recorder).Build()
+				if err != nil {
+					return testreport.Suite{}, fmt.Errorf("failed to initialize orchestrator: %w", err)
 				}
-			} else {
-				toolsToInstall = args
-			}
 
-			return orchestrator.InstallTools(toolsToInstall)
+				var toolsToInstall []string
+				if len(args) == 0 {
+					// Install all tools
+					config := orchestrator.configMgr.GetConfig()
+					for _, tool := range config.Tools {
+						toolsToInstall = append(toolsToInstall, tool.Name)
+					}
+				} else {
+					toolsToInstall = args
+				}
+
+				err = orchestrator.InstallTools(toolsToInstall)
+				return recorder.suite, err
+			})
 		},
 	}
 
@@ -47,6 +68,13 @@ and comprehensive progress tracking. If no tools are specified, all tools will b
 	cmd.Flags().IntVarP(&opts.MaxParallelJobs, "jobs", "j", 0, "Maximum parallel jobs (0 = auto-detect)")
 	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Enable verbose output")
 	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be installed without executing")
+	cmd.Flags().StringVar(&opts.Mirror, "mirror", os.Getenv("GEARBOX_MIRROR"), "Install without network access from an offline mirror (default: $GEARBOX_MIRROR)")
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "Clone sources directly instead of through the shared source cache")
+	cmd.Flags().BoolVar(&opts.SkipDiskCheck, "skip-disk-check", false, "Install even when the estimated disk space is not available")
+	cmd.Flags().BoolVar(&opts.NoConfig, "no-config", false, "Do not install default configuration files")
+	cmd.Flags().BoolVar(&opts.SystemUpgrade, "system-upgrade", false, "Upgrade the system to install missing packages with pacman")
+	cmd.Flags().BoolVar(&events, "events", false, "Write installation events to stdout as newline-delimited JSON")
+	addReportFlag(cmd, &reports)
 
 	// Nerd-fonts specific options
 	cmd.Flags().StringVar(&opts.Fonts, "fonts", "", "Install specific fonts (comma-separated, e.g. 'FiraCode,JetBrainsMono')")
@@ -75,11 +103,23 @@ Use 'list bundles' to list all available bundles.`,
 				return fmt.Errorf("failed to initialize orchestrator: %w", err)
 			}
 			
+			format, _ := output.ParseFormat(outputFormat)
+
 			// Check if user wants to list bundles
 			if len(args) > 0 && args[0] == "bundles" {
+				if format.Structured() {
+					list, err := orchestrator.BundleList()
+					if err != nil {
+						return err
+					}
+					return output.Write(os.Stdout, format, list)
+				}
 				return orchestrator.ListBundles(verbose)
 			}
 
+			if format.Structured() {
+				return output.Write(os.Stdout, format, orchestrator.ToolList(category))
+			}
 			return orchestrator.ListTools(category, verbose)
 		},
 	}
@@ -105,6 +145,13 @@ func showCmd() *cobra.Command {
 				return fmt.Errorf("failed to initialize orchestrator: %w", err)
 			}
 			
+			if format, _ := output.ParseFormat(outputFormat); format.Structured() {
+				details, err := orchestrator.BundleDetails(args[1])
+				if err != nil {
+					return err
+				}
+				return output.Write(os.Stdout, format, details)
+			}
 			return orchestrator.ShowBundle(args[1])
 		},
 	}
@@ -123,6 +170,10 @@ func statusCmd() *cobra.Command {
 				return fmt.Errorf("failed to initialize orchestrator: %w", err)
 			}
 
+			if drift, _ := cmd.Flags().GetBool("drift"); drift {
+				return orchestrator.ShowDrift(args)
+			}
+
 			manifestOnly, _ := cmd.Flags().GetBool("manifest-only")
 			unified, _ := cmd.Flags().GetBool("unified")
 			
@@ -135,55 +186,399 @@ func statusCmd() *cobra.Command {
 	cmd.Flags().Bool("all", false, "Show status for all tools (default)")
 	cmd.Flags().Bool("installed", false, "Show only installed tools")
 	cmd.Flags().Bool("missing", false, "Show only missing tools")
+	cmd.Flags().Bool("drift", false, "Show tracked tools whose binaries or versions differ from the manifest")
 	
 	return cmd
 }
 
 // verifyCmd creates the verify command
 func verifyCmd() *cobra.Command {
-	return &cobra.Command{
+	var reports []string
+	var integrity bool
+
+	cmd := &cobra.Command{
 		Use:   "verify [tools...]",
 		Short: "Verify tool installations",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			orchestrator, err := NewOrchestratorBuilder(InstallationOptions{}).Build()
-			if err != nil {
-				return fmt.Errorf("failed to initialize orchestrator: %w", err)
-			}
+			// Failed verifications are not usage errors
+			cmd.SilenceUsage = true
+			return runWithReports(reports, func(out io.Writer) (testreport.Suite, error) {
+				orchestrator, err := NewOrchestratorBuilder(InstallationOptions{}).WithReporter(NewTextReporter(out, false)).Build()
+				if err != nil {
+					return testreport.Suite{}, fmt.Errorf("failed to initialize orchestrator: %w", err)
+				}
 
-			return orchestrator.VerifyTools(args)
+				if integrity {
+					return orchestrator.VerifyIntegrity(args)
+				}
+				return orchestrator.VerifyTools(args)
+			})
 		},
 	}
+
+	cmd.Flags().BoolVar(&integrity, "integrity", false, "Compare the binaries of tracked tools with the checksums in the manifest")
+	addReportFlag(cmd, &reports)
+	return cmd
 }
 
 // doctorCmd creates the doctor command
 func doctorCmd() *cobra.Command {
+	var reports, busyTools []string
+	var fix, yes, verbose bool
+
 	cmd := &cobra.Command{
 		Use:   "doctor [tool]",
 		Short: "Run health checks and diagnostics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 && len(reports) > 0 {
+				return fmt.Errorf("tool-specific diagnostics do not support --report")
+			}
+			format, _ := output.ParseFormat(outputFormat)
+			if fix && format.Structured() {
+				return fmt.Errorf("--fix only supports table output")
+			}
+
+			return runWithReports(reports, func(out io.Writer) (testreport.Suite, error) {
+				orchestrator, err := NewOrchestratorBuilder(InstallationOptions{Verbose: verbose, BusyTools: busyTools}).WithReporter(NewTextReporter(out, verbose)).Build()
+				if err != nil {
+					return testreport.Suite{}, fmt.Errorf("failed to initialize orchestrator: %w", err)
+				}
+
+				if len(args) > 0 {
+					if format.Structured() {
+						return testreport.Suite{}, fmt.Errorf("tool-specific diagnostics only support table output")
+					}
+					return testreport.Suite{}, orchestrator.RunDoctor(args)
+				}
+
+				registry := orchestrator.HealthChecks()
+				results := registry.Run(context.Background(), health.DefaultTimeout)
+				report := newDoctorReport(results)
+				if format.Structured() {
+					return doctorSuite(report), output.Write(out, format, report)
+				}
+				orchestrator.showDoctorReport(report)
+
+				if !fix {
+					if fixable := report.fixable(); fixable > 0 {
+						orchestrator.reportf("\n🔧 %d problems can be fixed with 'gearbox doctor --fix'\n", fixable)
+					}
+					return doctorSuite(report), nil
+				}
+
+				confirm := func(question string) bool { return confirmFix(out, question) }
+				if yes {
+					confirm = func(string) bool { return true }
+				}
+				fixed, offered := orchestrator.fixHealthProblems(registry, results, confirm)
+				if offered == 0 && fixed == 0 {
+					orchestrator.reportf("\n🔧 No problems with an automatic fix\n")
+				} else {
+					orchestrator.reportf("\n🔧 Fixed %d problems\n", fixed)
+				}
+				return doctorSuite(newDoctorReport(results)), nil
+			})
+		},
+	}
+	
+	cmd.Flags().BoolVar(&fix, "fix", false, "Offer to fix the detected problems, then check again")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Apply fixes without asking")
+	cmd.Flags().BoolVar(&verbose, "verbose", false, "Show the output of fixes")
+	addBusyToolsFlag(cmd, &busyTools)
+	addReportFlag(cmd, &reports)
+	return cmd
+}
+
+// confirmFix asks whether to apply a fix
+func confirmFix(out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(response)
+	return response == "y" || response == "yes"
+}
+
+// validateCmd creates the validate command
+func validateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validate bundle configuration and package mappings",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			orchestrator, err := NewOrchestratorBuilder(InstallationOptions{}).Build()
 			if err != nil {
 				return fmt.Errorf("failed to initialize orchestrator: %w", err)
 			}
 
-			return orchestrator.RunDoctor(args)
+			return orchestrator.ValidateBundles()
 		},
 	}
-	
-	cmd.Flags().Bool("fix", false, "Attempt to fix detected issues automatically")
+}
+
+// exportCmd creates the export command
+func exportCmd() *cobra.Command {
+	var opts InstallationOptions
+	var exportOpts ExportOptions
+
+	cmd := &cobra.Command{
+		Use:   "export [tools/bundles...]",
+		Short: "Export the installed set or a Dockerfile, script or devcontainer feature",
+		Long: `Export the installed set recorded in the manifest as JSON for 'import', or a set
+of tools and bundles as a self-contained artifact containing the system packages
+for the target distribution, the common dependencies and the tool installation
+steps in resolved order.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Artifacts without a file go to stdout, so messages go to stderr
+			orchestrator, err := NewOrchestratorBuilder(opts).WithReporter(NewTextReporter(os.Stderr, false)).Build()
+			if err != nil {
+				return fmt.Errorf("failed to initialize orchestrator: %w", err)
+			}
+
+			return orchestrator.Export(args, exportOpts)
+		},
+	}
+
+	cmd.Flags().StringVar(&exportOpts.Format, "format", ExportJSON, "Export format (json, dockerfile, script, devcontainer)")
+	cmd.Flags().StringVar(&exportOpts.PackageManager, "package-manager", "apt", fmt.Sprintf("Target package manager (%s)", strings.Join(SupportedPackageManagers, ", ")))
+	cmd.Flags().StringVar(&exportOpts.BaseImage, "base-image", "", "Base image for Dockerfiles (default depends on package manager)")
+	cmd.Flags().StringVar(&exportOpts.Source, "source", "", "Git URL to clone gearbox from instead of copying this repository")
+	cmd.Flags().StringVarP(&exportOpts.Output, "file", "f", "", "Output file, or directory for devcontainer (default: stdout)")
+	cmd.Flags().BoolVar(&exportOpts.FromManifest, "from-manifest", false, "Export the tools and bundles recorded in the manifest")
+
+	cmd.Flags().StringVarP(&opts.BuildType, "build-type", "b", "standard", "Build type (minimal, standard, maximum)")
+	cmd.Flags().BoolVar(&opts.SkipCommonDeps, "skip-common-deps", false, "Skip common dependency installation")
+	cmd.Flags().BoolVar(&opts.RunTests, "run-tests", false, "Run test suites for validation")
+	cmd.Flags().BoolVar(&opts.NoShell, "no-shell", false, "Skip shell integration setup")
+
+	return cmd
+}
+
+// importCmd creates the import command
+func importCmd() *cobra.Command {
+	var opts InstallationOptions
+	var events bool
+
+	cmd := &cobra.Command{
+		Use:   "import <environment.json>",
+		Short: "Install the tools and bundles from an exported environment",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			builder := NewOrchestratorBuilder(opts)
+			if events {
+				builder.WithReporter(NewJSONReporter(os.Stdout))
+			}
+			orchestrator, err := builder.Build()
+			if err != nil {
+				return fmt.Errorf("failed to initialize orchestrator: %w", err)
+			}
+
+			return orchestrator.ImportEnvironment(args[0])
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.SkipCommonDeps, "skip-common-deps", false, "Skip common dependency installation")
+	cmd.Flags().BoolVar(&opts.RunTests, "run-tests", false, "Run test suites for validation")
+	cmd.Flags().BoolVar(&opts.NoShell, "no-shell", false, "Skip shell integration setup")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Reinstall tools that are already installed")
+	cmd.Flags().IntVarP(&opts.MaxParallelJobs, "jobs", "j", 0, "Maximum parallel jobs (0 = auto-detect)")
+	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Enable verbose output")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the import plan without installing")
+	cmd.Flags().BoolVar(&events, "events", false, "Write the import plan and installation events to stdout as newline-delimited JSON")
+	cmd.Flags().StringVar(&opts.Mirror, "mirror", os.Getenv("GEARBOX_MIRROR"), "Install without network access from an offline mirror (default: $GEARBOX_MIRROR)")
+
+	return cmd
+}
+
+// mirrorCmd creates the mirror command
+func mirrorCmd() *cobra.Command {
+	var mirrorOpts MirrorOptions
+
+	cmd := &cobra.Command{
+		Use:   "mirror <dir> [tools/bundles...]",
+		Short: "Download everything needed for offline installs into a directory",
+		Long: `Create or update an offline mirror with bare git mirrors of the tool repositories,
+their crates and Go modules, release assets, font archives and the Rust and Go
+toolchains. Without tools or bundles every tool is mirrored.
+
+Copy the directory to an air-gapped machine and install with 'install --mirror <dir>'.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			orchestrator, err := NewOrchestratorBuilder(InstallationOptions{}).Build()
+			if err != nil {
+				return fmt.Errorf("failed to initialize orchestrator: %w", err)
+			}
+
+			return orchestrator.CreateMirror(args[0], args[1:], mirrorOpts)
+		},
+	}
+
+	cmd.Flags().StringVar(&mirrorOpts.GoVersion, "go-version", defaultMirrorGoVersion, "Go toolchain version")
+	cmd.Flags().StringVar(&mirrorOpts.RustVersion, "rust-version", "stable", "Rust toolchain version")
+	cmd.Flags().BoolVar(&mirrorOpts.NoReleases, "no-releases", false, "Skip prebuilt release assets (used by minimal builds)")
+
+	return cmd
+}
+
+// cacheCmd creates the cache command
+func cacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the shared source cache",
+		Long: `Installations clone tool repositories into a shared cache of bare repositories
+below CACHE_DIR (default ~/tools/cache) and fetch only new commits on later runs.
+Installation scripts clone from the cache instead of the network.`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show the cached repositories and their size",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			orchestrator, err := NewOrchestratorBuilder(InstallationOptions{}).Build()
+			if err != nil {
+				return fmt.Errorf("failed to initialize orchestrator: %w", err)
+			}
+
+			return orchestrator.CacheStatus()
+		},
+	})
+
+	var pruneOpts PruneOptions
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove unused repositories from the source cache",
+		Long: `Remove repositories that have not been used for a number of days or that no
+configured tool uses anymore.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			orchestrator, err := NewOrchestratorBuilder(InstallationOptions{}).Build()
+			if err != nil {
+				return fmt.Errorf("failed to initialize orchestrator: %w", err)
+			}
+
+			return orchestrator.PruneCache(pruneOpts)
+		},
+	}
+	pruneCmd.Flags().IntVar(&pruneOpts.Days, "days", DefaultCachePruneDays, "Remove repositories unused for this many days")
+	pruneCmd.Flags().BoolVar(&pruneOpts.All, "all", false, "Remove every cached repository")
+	pruneCmd.Flags().BoolVar(&pruneOpts.DryRun, "dry-run", false, "Show what would be removed")
+	cmd.AddCommand(pruneCmd)
+
+	return cmd
+}
+
+// gcCmd creates the gc command
+func gcCmd() *cobra.Command {
+	opts := GCOptions{KeepBackups: DefaultGCKeepBackups}
+	var busyTools []string
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove orphaned build directories, cached sources, backups and logs",
+		Long: `Cross-reference the build directory, the source and build caches, backup
+copies, logs and manifest backups with the manifest and the configured tools,
+list the orphans with their sizes and remove them.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			orchestrator, err := NewOrchestratorBuilder(InstallationOptions{BusyTools: busyTools}).Build()
+			if err != nil {
+				return fmt.Errorf("failed to initialize orchestrator: %w", err)
+			}
+
+			return orchestrator.CollectGarbage(opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Days, "days", DefaultGCDays, "Keep orphans changed within this many days")
+	cmd.Flags().Int64Var(&opts.MinSizeMB, "min-size-mb", 0, "Keep orphans smaller than this many megabytes")
+	cmd.Flags().IntVar(&opts.KeepBackups, "keep-backups", DefaultGCKeepBackups, "Number of manifest backups to keep")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be removed")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Remove without asking")
+	addBusyToolsFlag(cmd, &busyTools)
+	return cmd
+}
+
+// addBusyToolsFlag adds the flag the gearbox CLI uses to pass the tools with
+// a queued or running daemon job, whose checkouts must be kept
+func addBusyToolsFlag(cmd *cobra.Command, tools *[]string) {
+	cmd.Flags().StringSliceVar(tools, "busy-tools", nil, "Tools and bundles with a queued or running daemon job")
+	cmd.Flags().MarkHidden("busy-tools")
+}
+
+// adoptCmd creates the adopt command
+func adoptCmd() *cobra.Command {
+	opts := AdoptOptions{As: AdoptAuto}
+
+	cmd := &cobra.Command{
+		Use:   "adopt [tools...]",
+		Short: "Record catalog tools installed outside gearbox in the manifest",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			orchestrator, err := NewOrchestratorBuilder(InstallationOptions{}).Build()
+			if err != nil {
+				return fmt.Errorf("failed to initialize orchestrator: %w", err)
+			}
+
+			opts.Tools = args
+			return orchestrator.Adopt(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Adopt every tool found without asking")
+	cmd.Flags().StringVar(&opts.As, "as", AdoptAuto, "Adopt tools as managed, pre-existing or auto (by install method)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the tools found without adopting them")
+	return cmd
+}
+
+// syncCmd creates the sync command
+func syncCmd() *cobra.Command {
+	var opts SyncOptions
+
+	cmd := &cobra.Command{
+		Use:   "sync [tools...]",
+		Short: "Reconcile the manifest with the tools on the system",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			orchestrator, err := NewOrchestratorBuilder(InstallationOptions{}).Build()
+			if err != nil {
+				return fmt.Errorf("failed to initialize orchestrator: %w", err)
+			}
+
+			opts.Tools = args
+			return orchestrator.Sync(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Policy, "policy", SyncAsk, "Resolve every mismatch with update, reinstall, untrack or ignore instead of asking")
 	return cmd
 }
 
 // uninstallCmd creates the uninstall command
 func uninstallCmd() *cobra.Command {
 	var opts uninstall.RemovalOptions
+	var undo, listTrash bool
 
 	cmd := &cobra.Command{
 		Use:   "uninstall [tools...]",
 		Short: "Uninstall tools with safe removal",
 		Long: `Uninstall one or more tools with dependency analysis and safe removal.
-Analyzes dependencies and provides a removal plan before execution.`,
+Analyzes dependencies and provides a removal plan before execution.
+
+Removed files go to the trash in ~/.gearbox/trash unless --purge is given.
+--undo restores the files and manifest records of the last removal, or of the
+one with the given ID, and --trash lists the removals in the trash.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if undo {
+				if len(args) > 1 {
+					return fmt.Errorf("--undo takes at most one removal ID")
+				}
+				id := ""
+				if len(args) == 1 {
+					id = args[0]
+				}
+				return undoRemoval(id)
+			}
+			if listTrash {
+				return showTrash()
+			}
+			if len(args) == 0 && opts.Purge {
+				return emptyTrash(opts.DryRun)
+			}
 			if len(args) == 0 {
 				return fmt.Errorf("no tools specified for removal")
 			}
@@ -228,6 +623,9 @@ Analyzes dependencies and provides a removal plan before execution.`,
 
 			// Show results
 			fmt.Printf("\n%s", result.Summary())
+			if !opts.DryRun {
+				autoPurgeTrash()
+			}
 			return nil
 		},
 	}
@@ -235,11 +633,14 @@ Analyzes dependencies and provides a removal plan before execution.`,
 	// Removal options
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Force removal even if there are dependents")
 	cmd.Flags().BoolVar(&opts.Cascade, "cascade", false, "Remove unused dependencies")
-	cmd.Flags().BoolVar(&opts.RemoveConfig, "remove-config", false, "Remove configuration files")
+	cmd.Flags().BoolVar(&opts.RemoveConfig, "remove-config", false, "Remove configuration files and caches")
 	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be removed without executing")
 	cmd.Flags().BoolVar(&opts.Backup, "backup", true, "Create backup before removal")
 	cmd.Flags().StringVar(&opts.BackupSuffix, "backup-suffix", "", "Suffix for backup files")
 	cmd.Flags().BoolVar(&opts.RemoveBundleContents, "bundle-contents", false, "Remove all tools in bundle, not just bundle tracking")
+	cmd.Flags().BoolVar(&opts.Purge, "purge", false, "Delete files instead of moving them to the trash; without tools, empty the trash")
+	cmd.Flags().BoolVar(&undo, "undo", false, "Restore the last removal, or the one with the given ID, from the trash")
+	cmd.Flags().BoolVar(&listTrash, "trash", false, "List the removals in the trash")
 
 	return cmd
 }
@@ -284,6 +685,10 @@ Shows dependency analysis and safety warnings.`,
 				return fmt.Errorf("failed to plan removal: %w", err)
 			}
 
+			if format, _ := output.ParseFormat(outputFormat); format.Structured() {
+				return output.Write(os.Stdout, format, removalPlanReport(plan, engine))
+			}
+
 			// Show detailed plan
 			return showDetailedRemovalPlan(plan, engine)
 		},
@@ -335,6 +740,9 @@ Use with caution - this bypasses safety prompts.`,
 
 			// Show results
 			fmt.Printf("\n%s", result.Summary())
+			if !opts.DryRun {
+				autoPurgeTrash()
+			}
 			return nil
 		},
 	}
@@ -342,10 +750,11 @@ Use with caution - this bypasses safety prompts.`,
 	// Removal options
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Force removal even if there are dependents")
 	cmd.Flags().BoolVar(&opts.Cascade, "cascade", false, "Remove unused dependencies")
-	cmd.Flags().BoolVar(&opts.RemoveConfig, "remove-config", false, "Remove configuration files")
+	cmd.Flags().BoolVar(&opts.RemoveConfig, "remove-config", false, "Remove configuration files and caches")
 	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be removed without executing")
 	cmd.Flags().BoolVar(&opts.Backup, "backup", true, "Create backup before removal")
 	cmd.Flags().StringVar(&opts.BackupSuffix, "backup-suffix", "", "Suffix for backup files")
+	cmd.Flags().BoolVar(&opts.Purge, "purge", false, "Delete files instead of moving them to the trash")
 
 	return cmd
 }
\ No newline at end of file
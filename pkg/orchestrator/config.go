@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	
 	"gearbox/pkg/errors"
 )
@@ -74,4 +75,32 @@ func loadConfigStreaming(data []byte) (Config, error) {
 	}
 
 	return config, nil
-}
\ No newline at end of file
+}
+// UserConfigValue returns a setting from ~/.gearboxrc, or the default when unset
+func UserConfigValue(key, defaultValue string) string {
+	data, err := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".gearboxrc"))
+	if err != nil {
+		return defaultValue
+	}
+
+	value := defaultValue
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) == 2 && strings.TrimSpace(parts[0]) == key {
+			value = strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+		}
+	}
+	return value
+}
+
+// expandHome expands a leading ~ to the home directory
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		return filepath.Join(os.Getenv("HOME"), strings.TrimPrefix(path, "~"))
+	}
+	return os.ExpandEnv(path)
+}
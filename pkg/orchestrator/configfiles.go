@@ -0,0 +1,45 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gearbox/pkg/configfiles"
+	"gearbox/pkg/manifest"
+)
+
+// installConfigFiles installs the default configuration files of a tool and
+// returns the files gearbox manages. Files the user changed are kept and the
+// differences are reported.
+func (o *Orchestrator) installConfigFiles(tool ToolConfig) []string {
+	if o.options.NoConfig || len(tool.ConfigTemplates) == 0 {
+		return nil
+	}
+
+	gearboxDir := filepath.Join(os.Getenv("HOME"), manifest.ManifestDir)
+	installer := configfiles.NewInstaller(filepath.Join(o.repoDir, "config", "templates"), gearboxDir, tool.Name)
+	results, err := installer.Install(tool.ConfigTemplates)
+	if err != nil {
+		o.reportf("⚠️  %s: failed to install configuration: %v\n", tool.Name, err)
+	}
+
+	var managed []string
+	for _, result := range results {
+		switch result.Action {
+		case configfiles.ActionCreated:
+			o.reportf("📝 %s: created %s\n", tool.Name, result.Target)
+		case configfiles.ActionUpdated:
+			o.reportf("📝 %s: updated %s (backup: %s)\n", tool.Name, result.Target, result.Backup)
+		case configfiles.ActionKept:
+			o.reportf("📝 %s: kept your changes to %s, the template differs:\n", tool.Name, result.Target)
+			for _, line := range strings.Split(strings.TrimSuffix(result.Diff, "\n"), "\n") {
+				o.reportf("   %s\n", line)
+			}
+		}
+		if result.Managed() {
+			managed = append(managed, result.Target)
+		}
+	}
+	return managed
+}
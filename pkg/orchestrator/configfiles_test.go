@@ -0,0 +1,53 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gearbox/pkg/configfiles"
+)
+
+func TestInstallConfigFilesReportsAndReturnsManagedFiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	repo := t.TempDir()
+	templates := filepath.Join(repo, "config", "templates", "bat")
+	if err := os.MkdirAll(templates, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(templates, "config"), []byte("--theme=\"TwoDark\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &eventRecorder{}
+	o := &Orchestrator{repoDir: repo, reporter: recorder}
+	tool := ToolConfig{Name: "bat", ConfigTemplates: []configfiles.Template{
+		{Source: "bat/config", Target: "{{.ConfigHome}}/bat/config"},
+	}}
+
+	target := filepath.Join(home, ".config", "bat", "config")
+	files := o.installConfigFiles(tool)
+	if len(files) != 1 || files[0] != target {
+		t.Fatalf("unexpected managed files %v", files)
+	}
+	if len(recorder.events) != 1 || !strings.Contains(recorder.events[0].Message, "created "+target) {
+		t.Errorf("unexpected events %+v", recorder.events)
+	}
+
+	// Files the user changed are kept and not managed
+	if err := os.WriteFile(target, []byte("--theme=\"Nord\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if files := o.installConfigFiles(tool); len(files) != 0 {
+		t.Errorf("expected the changed file to stay unmanaged, got %v", files)
+	}
+
+	o.options.NoConfig = true
+	if files := o.installConfigFiles(tool); files != nil {
+		t.Errorf("expected --no-config to skip configuration, got %v", files)
+	}
+}
@@ -0,0 +1,305 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"gearbox/pkg/fsutil"
+)
+
+// diskHeadroomMB is kept free on top of the estimates on every filesystem
+const diskHeadroomMB = 1024
+
+// diskLowMB triggers a warning when free space drops below it during installations
+const diskLowMB = 2048
+
+// diskCriticalMB stops starting new builds when free space drops below it
+const diskCriticalMB = 512
+
+// diskMonitorInterval is how often free space is checked during installations
+const diskMonitorInterval = 10 * time.Second
+
+// buildArtifactDirs are the directories of a checkout counted as build output
+var buildArtifactDirs = map[string]bool{
+	"target":       true, // cargo
+	"build":        true,
+	"_build":       true,
+	"dist":         true,
+	"node_modules": true,
+	".venv":        true,
+}
+
+// DiskEstimate is the disk space a build needs, in megabytes
+type DiskEstimate struct {
+	SourceMB  int64 `json:"source_mb"`  // Source checkout
+	BuildMB   int64 `json:"build_mb"`   // Build artifacts next to the source
+	InstallMB int64 `json:"install_mb"` // Installed files below the prefix
+}
+
+// DiskRequirement is the space needed on one filesystem
+type DiskRequirement struct {
+	Paths      []string `json:"paths"`
+	RequiredMB int64    `json:"required_mb"`
+	FreeMB     int64    `json:"free_mb"`
+	device     uint64
+}
+
+// toolsBuildDir returns the directory installation scripts build in
+func toolsBuildDir() string {
+	return filepath.Join(os.Getenv("HOME"), "tools", "build")
+}
+
+// installPrefix returns INSTALL_PREFIX from ~/.gearboxrc
+func installPrefix() string {
+	return expandHome(UserConfigValue("INSTALL_PREFIX", "/usr/local"))
+}
+
+// bytesToMB converts bytes to megabytes, rounding up
+func bytesToMB(size int64) int64 {
+	return (size + 1024*1024 - 1) / (1024 * 1024)
+}
+
+// formatMB returns a human-readable size for megabytes
+func formatMB(mb int64) string {
+	return formatBytes(mb * 1024 * 1024)
+}
+
+// estimateDisk returns the disk space a tool needs with the current build
+// type: measured from its last build, or seeded from tools.json by tool or
+// language. The second result reports whether the estimate was measured.
+func (o *Orchestrator) estimateDisk(tool ToolConfig) (DiskEstimate, bool) {
+	buildType := o.options.BuildType
+	if o.buildStats != nil {
+		if record, found := o.buildStats.lookup(tool.Name, buildType); found && record.Disk != (DiskEstimate{}) {
+			return record.Disk, true
+		}
+	}
+
+	if estimate, found := tool.Disk[buildType]; found {
+		return estimate, false
+	}
+	if language, found := o.configMgr.GetConfig().Languages[tool.Language]; found {
+		return language.Disk[buildType], false
+	}
+	return DiskEstimate{}, false
+}
+
+// measureBuild measures the disk usage of a finished build: the checkout in
+// the build directory and the installed binary
+func measureBuild(tool ToolConfig, buildDir string) DiskEstimate {
+	var sourceBytes, buildBytes int64
+	checkout := filepath.Join(buildDir, tool.Name)
+	if entries, err := os.ReadDir(checkout); err == nil {
+		for _, entry := range entries {
+			size := fsutil.DirSize(filepath.Join(checkout, entry.Name()))
+			if entry.IsDir() && buildArtifactDirs[entry.Name()] {
+				buildBytes += size
+			} else {
+				sourceBytes += size
+			}
+		}
+	}
+
+	var installBytes int64
+	if tool.BinaryName != "" {
+		if path, err := exec.LookPath(tool.BinaryName); err == nil {
+			if info, err := os.Stat(path); err == nil {
+				installBytes = info.Size()
+			}
+		}
+	}
+
+	return DiskEstimate{
+		SourceMB:  bytesToMB(sourceBytes),
+		BuildMB:   bytesToMB(buildBytes),
+		InstallMB: bytesToMB(installBytes),
+	}
+}
+
+// recordBuild stores the measured disk usage, peak memory and build time of
+// a successful build
+func (o *Orchestrator) recordBuild(tool ToolConfig, peakMB, jobs int, duration time.Duration) {
+	if o.buildStats == nil {
+		return
+	}
+	o.buildStats.recordDisk(tool.Name, o.options.BuildType, measureBuild(tool, toolsBuildDir()))
+	o.buildStats.recordMemory(tool.Name, o.options.BuildType, peakMB)
+	o.buildStats.recordDuration(tool.Name, o.options.BuildType, duration, jobs)
+}
+
+// filesystemSpace returns the device and the free megabytes of the
+// filesystem containing path, or of its nearest existing parent
+func filesystemSpace(path string) (uint64, int64, error) {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			break
+		}
+		path = parent
+	}
+
+	var stat syscall.Stat_t
+	if err := syscall.Stat(path, &stat); err != nil {
+		return 0, 0, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err != nil {
+		return 0, 0, fmt.Errorf("failed to read free space of %s: %w", path, err)
+	}
+	return uint64(stat.Dev), int64(fs.Bavail) * int64(fs.Bsize) / (1024 * 1024), nil
+}
+
+// diskRequirements sums the estimates of the tools per filesystem: sources
+// and build artifacts in the build directory, installed files below the prefix
+func (o *Orchestrator) diskRequirements(tools []ToolConfig, buildDir, prefix string) ([]DiskRequirement, error) {
+	var buildMB, installMB int64
+	for _, tool := range tools {
+		estimate, _ := o.estimateDisk(tool)
+		buildMB += estimate.SourceMB + estimate.BuildMB
+		installMB += estimate.InstallMB
+	}
+
+	var requirements []DiskRequirement
+	for _, target := range []struct {
+		path string
+		mb   int64
+	}{{buildDir, buildMB}, {prefix, installMB}} {
+		device, freeMB, err := filesystemSpace(target.path)
+		if err != nil {
+			return nil, err
+		}
+
+		merged := false
+		for i := range requirements {
+			if requirements[i].device == device {
+				requirements[i].Paths = append(requirements[i].Paths, target.path)
+				requirements[i].RequiredMB += target.mb
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			requirements = append(requirements, DiskRequirement{
+				Paths:      []string{target.path},
+				RequiredMB: target.mb,
+				FreeMB:     freeMB,
+				device:     device,
+			})
+		}
+	}
+	return requirements, nil
+}
+
+// planDiskSpace adds the estimated disk usage per filesystem to a plan
+func (o *Orchestrator) planDiskSpace(plan *InstallPlan, tools []ToolConfig) {
+	requirements, err := o.diskRequirements(tools, toolsBuildDir(), installPrefix())
+	if err != nil {
+		if o.options.Verbose {
+			o.reportf("⚠️  Disk space not estimated: %v\n", err)
+		}
+		return
+	}
+	plan.Disk = requirements
+}
+
+// checkDiskSpace refuses to start installations when the build or prefix
+// filesystems lack room for the estimated disk usage
+func (o *Orchestrator) checkDiskSpace(tools []ToolConfig) error {
+	requirements, err := o.diskRequirements(tools, toolsBuildDir(), installPrefix())
+	if err != nil {
+		// Do not block installations when free space cannot be determined
+		if o.options.Verbose {
+			o.reportf("⚠️  Disk space not checked: %v\n", err)
+		}
+		return nil
+	}
+
+	var short []string
+	for _, req := range requirements {
+		if req.FreeMB < req.RequiredMB+diskHeadroomMB {
+			short = append(short, fmt.Sprintf("%s: ~%s needed, %s free", strings.Join(req.Paths, ", "), formatMB(req.RequiredMB+diskHeadroomMB), formatMB(req.FreeMB)))
+		}
+	}
+	if len(short) == 0 {
+		return nil
+	}
+
+	o.reportf("❌ Not enough disk space\n")
+	for _, line := range short {
+		o.reportf("  • %s\n", line)
+	}
+	o.showLargestEstimates(tools)
+	o.reportf("\nFree space with 'gearbox doctor cleanup --all', install fewer tools, or skip this check with --skip-disk-check\n")
+	return fmt.Errorf("not enough disk space for %d tools", len(tools))
+}
+
+// showLargestEstimates lists the tools that need the most disk space
+func (o *Orchestrator) showLargestEstimates(tools []ToolConfig) {
+	type toolEstimate struct {
+		name     string
+		mb       int64
+		measured bool
+	}
+	var estimates []toolEstimate
+	for _, tool := range tools {
+		estimate, measured := o.estimateDisk(tool)
+		estimates = append(estimates, toolEstimate{tool.Name, estimate.SourceMB + estimate.BuildMB + estimate.InstallMB, measured})
+	}
+	sort.Slice(estimates, func(i, j int) bool {
+		return estimates[i].mb > estimates[j].mb
+	})
+
+	o.reportf("\nLargest builds:\n")
+	for _, estimate := range estimates[:min(5, len(estimates))] {
+		source := "estimated"
+		if estimate.measured {
+			source = "measured"
+		}
+		o.reportf("  %-15s ~%s (%s)\n", estimate.name, formatMB(estimate.mb), source)
+	}
+}
+
+// monitorDiskSpace checks the free space of the build directory while
+// installations run. Below diskCriticalMB no further builds are started.
+// Closing the returned channel stops the monitor.
+func (o *Orchestrator) monitorDiskSpace() chan struct{} {
+	stop := make(chan struct{})
+	buildDir := toolsBuildDir()
+
+	go func() {
+		ticker := time.NewTicker(diskMonitorInterval)
+		defer ticker.Stop()
+
+		warned := false
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				_, freeMB, err := filesystemSpace(buildDir)
+				if err != nil {
+					continue
+				}
+				if freeMB < diskCriticalMB {
+					if !o.diskSpaceLow.Swap(true) {
+						o.reportf("\n🛑 Only %s free in %s, not starting further builds (run 'gearbox doctor cleanup --all')\n", formatMB(freeMB), buildDir)
+					}
+				} else if freeMB < diskLowMB && !warned {
+					warned = true
+					o.reportf("\n⚠️  Low disk space: %s free in %s\n", formatMB(freeMB), buildDir)
+				}
+			}
+		}
+	}()
+
+	return stop
+}
@@ -0,0 +1,109 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEstimateDiskPrecedence(t *testing.T) {
+	o := &Orchestrator{
+		configMgr: &ConfigManager{config: Config{
+			Languages: map[string]LanguageConfig{
+				"rust": {Disk: map[string]DiskEstimate{"standard": {SourceMB: 10, BuildMB: 100, InstallMB: 1}}},
+			},
+		}},
+		buildStats: loadBuildStats(filepath.Join(t.TempDir(), buildStatsFile)),
+		options:    InstallationOptions{BuildType: "standard"},
+	}
+
+	language := ToolConfig{Name: "fd", Language: "rust"}
+	seeded := ToolConfig{Name: "uv", Language: "rust", Disk: map[string]DiskEstimate{"standard": {BuildMB: 3000}}}
+	unknown := ToolConfig{Name: "bun", Language: "javascript"}
+
+	if got, measured := o.estimateDisk(language); got.BuildMB != 100 || measured {
+		t.Errorf("language default: got %+v (measured %v)", got, measured)
+	}
+	if got, _ := o.estimateDisk(seeded); got.BuildMB != 3000 {
+		t.Errorf("tool seed: got %+v", got)
+	}
+	if got, _ := o.estimateDisk(unknown); got != (DiskEstimate{}) {
+		t.Errorf("unknown language: got %+v", got)
+	}
+
+	// Measurements replace the seeds, but unmeasured sizes keep earlier values
+	o.buildStats.recordDisk("uv", "standard", DiskEstimate{SourceMB: 150, BuildMB: 4200})
+	o.buildStats.recordDisk("uv", "standard", DiskEstimate{InstallMB: 40})
+	if got, measured := o.estimateDisk(seeded); got != (DiskEstimate{SourceMB: 150, BuildMB: 4200, InstallMB: 40}) || !measured {
+		t.Errorf("measured: got %+v (measured %v)", got, measured)
+	}
+
+	// Measurements are per build type
+	o.options.BuildType = "minimal"
+	if _, measured := o.estimateDisk(seeded); measured {
+		t.Errorf("minimal build must not use the standard measurement")
+	}
+
+	if err := o.buildStats.save(); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	reloaded := loadBuildStats(o.buildStats.path)
+	if record, found := reloaded.lookup("uv", "standard"); !found || record.Disk.BuildMB != 4200 {
+		t.Errorf("reloaded: got %+v (found %v)", record, found)
+	}
+}
+
+func TestMeasureBuild(t *testing.T) {
+	buildDir := t.TempDir()
+	checkout := filepath.Join(buildDir, "tool")
+	for dir, size := range map[string]int{"src": 3 * 1024 * 1024, "target": 5 * 1024 * 1024} {
+		if err := os.MkdirAll(filepath.Join(checkout, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(checkout, dir, "data"), make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := measureBuild(ToolConfig{Name: "tool"}, buildDir)
+	if got.SourceMB != 3 || got.BuildMB != 5 || got.InstallMB != 0 {
+		t.Errorf("expected 3 MB source and 5 MB build, got %+v", got)
+	}
+}
+
+func TestDiskRequirements(t *testing.T) {
+	o := &Orchestrator{
+		configMgr: &ConfigManager{config: Config{}},
+		options:   InstallationOptions{BuildType: "standard"},
+	}
+	tools := []ToolConfig{
+		{Name: "a", Disk: map[string]DiskEstimate{"standard": {SourceMB: 10, BuildMB: 20, InstallMB: 1}}},
+		{Name: "b", Disk: map[string]DiskEstimate{"standard": {SourceMB: 5, BuildMB: 5, InstallMB: 2}}},
+	}
+
+	// Directories on the same filesystem share one requirement, and missing
+	// directories are checked through their parent
+	root := t.TempDir()
+	requirements, err := o.diskRequirements(tools, filepath.Join(root, "tools", "build"), filepath.Join(root, "prefix"))
+	if err != nil {
+		t.Fatalf("diskRequirements failed: %v", err)
+	}
+	if len(requirements) != 1 {
+		t.Fatalf("expected one filesystem, got %+v", requirements)
+	}
+	if requirements[0].RequiredMB != 43 || len(requirements[0].Paths) != 2 {
+		t.Errorf("expected 43 MB for both paths, got %+v", requirements[0])
+	}
+
+	// More than any disk offers fails the check with a cleanup suggestion
+	t.Setenv("HOME", root)
+	huge := []ToolConfig{{Name: "huge", Disk: map[string]DiskEstimate{"standard": {BuildMB: 1 << 40}}}}
+	err = o.checkDiskSpace(huge)
+	if err == nil || !strings.Contains(err.Error(), "not enough disk space") {
+		t.Errorf("expected disk space error, got %v", err)
+	}
+	if err := o.checkDiskSpace(tools[:1]); err != nil {
+		t.Errorf("small installation should fit: %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gearbox/pkg/health"
+	"gearbox/pkg/output"
+)
+
+// Health check statuses
+const (
+	HealthPass = "pass"
+	HealthWarn = "warn"
+	HealthFail = "fail"
+)
+
+// HealthCheckResult is the outcome of one doctor check
+type HealthCheckResult struct {
+	Name       string        `json:"name"`
+	Category   string        `json:"category,omitempty"`
+	Status     string        `json:"status"` // pass, warn or fail
+	Message    string        `json:"message"`
+	Details    []string      `json:"details,omitempty"`
+	Suggestion string        `json:"suggestion,omitempty"` // One step per line
+	Fixable    bool          `json:"fixable,omitempty"`    // 'doctor --fix' can fix the problem
+	Duration   time.Duration `json:"-"`
+}
+
+// DoctorSummary counts the doctor checks by status
+type DoctorSummary struct {
+	Passed   int `json:"passed"`
+	Warnings int `json:"warnings"`
+	Failed   int `json:"failed"`
+}
+
+// DoctorReport is the structured output of 'doctor'
+type DoctorReport struct {
+	SchemaVersion string              `json:"schema_version"`
+	Checks        []HealthCheckResult `json:"checks"`
+	Summary       DoctorSummary       `json:"summary"`
+}
+
+// SystemHealthChecks returns the checks of the machine, shared by doctor and
+// the Health view of the TUI
+func SystemHealthChecks() *health.Registry {
+	registry := health.NewDefaultRegistry()
+	if online, found := registry.Get("internet"); found {
+		registry.Register(mirrorCheck(online))
+	}
+	registry.Register(diskSpaceCheck())
+	return registry
+}
+
+// HealthChecks returns the checks of doctor: the system checks and those of
+// the configuration
+func (o *Orchestrator) HealthChecks() *health.Registry {
+	registry := SystemHealthChecks()
+	for _, id := range []string{"rust", "go"} {
+		if check, found := registry.Get(id); found {
+			registry.Register(health.WithFix(check, o.toolchainFix))
+		}
+	}
+	registry.Register(o.binariesCheck())
+	registry.Register(fontCacheCheck())
+	registry.Register(o.buildDirsCheck())
+	registry.Register(health.NewCheck("configuration", health.CategoryGearbox, func(ctx context.Context) health.Result {
+		config := o.configMgr.GetConfig()
+		return health.Result{
+			Severity: health.SeverityOK,
+			Message:  fmt.Sprintf("%d tools and %d languages configured", len(config.Tools), len(config.Languages)),
+		}
+	}))
+	registry.Register(health.NewCheck("package-manager", health.CategorySystem, func(ctx context.Context) health.Result {
+		if o.packageMgr == nil {
+			return health.Result{
+				Severity:    health.SeverityWarning,
+				Message:     "no supported package manager detected",
+				Remediation: []string{"Install system dependencies manually before installing tools"},
+			}
+		}
+		return health.Result{Severity: health.SeverityOK, Message: o.packageMgr.Name}
+	}))
+	registry.Register(health.NewCheck("tools", health.CategoryGearbox, func(ctx context.Context) health.Result {
+		config := o.configMgr.GetConfig()
+		installed := 0
+		for _, tool := range config.Tools {
+			if isToolInstalled(tool) {
+				installed++
+			}
+		}
+		return health.Result{
+			Severity: health.SeverityOK,
+			Message:  fmt.Sprintf("%d of %d tools installed", installed, len(config.Tools)),
+		}
+	}))
+	return registry
+}
+
+// diskSpaceCheck reports the free space where installation scripts build
+func diskSpaceCheck() health.Check {
+	return health.NewCheck("disk-space", health.CategorySystem, func(ctx context.Context) health.Result {
+		buildDir := toolsBuildDir()
+		_, freeMB, err := filesystemSpace(buildDir)
+		if err != nil {
+			return health.Result{Severity: health.SeverityWarning, Message: err.Error()}
+		}
+
+		result := health.Result{Severity: health.SeverityOK, Message: fmt.Sprintf("%s free in %s", formatMB(freeMB), buildDir)}
+		if freeMB < diskCriticalMB {
+			result.Severity = health.SeverityCritical
+		} else if freeMB < diskLowMB {
+			result.Severity = health.SeverityWarning
+		}
+		if result.Severity != health.SeverityOK {
+			result.Remediation = []string{"Free space with 'gearbox doctor cleanup --all'"}
+		}
+		return result
+	})
+}
+
+// mirrorCheck replaces the internet check when installing from an offline
+// mirror, which does not need network access
+func mirrorCheck(online health.Check) health.Check {
+	return health.NewCheck(online.ID(), online.Category(), func(ctx context.Context) health.Result {
+		mirrorDir := os.Getenv("GEARBOX_MIRROR")
+		if mirrorDir == "" {
+			return online.Run(ctx)
+		}
+
+		mirror, err := OpenMirror(mirrorDir)
+		if err != nil {
+			return health.Result{
+				Severity: health.SeverityCritical,
+				Message:  "Offline mirror not found",
+				Remediation: []string{
+					fmt.Sprintf("Create it with: gearbox mirror %s", mirrorDir),
+					"Unset GEARBOX_MIRROR to install from the network",
+				},
+			}
+		}
+		return health.Result{
+			Severity: health.SeverityOK,
+			Message:  "Offline mode",
+			Details: []string{
+				fmt.Sprintf("Mirror: %s", mirror.Dir),
+				fmt.Sprintf("Tools: %d", len(mirror.Index.Tools)),
+			},
+		}
+	})
+}
+
+// DoctorReport runs the general health checks
+func (o *Orchestrator) DoctorReport() DoctorReport {
+	return newDoctorReport(o.HealthChecks().Run(context.Background(), health.DefaultTimeout))
+}
+
+// newDoctorReport creates the doctor output of health reports
+func newDoctorReport(reports []health.Report) DoctorReport {
+	report := DoctorReport{SchemaVersion: output.SchemaVersion}
+	for _, result := range reports {
+		report.add(healthCheckResult(result))
+	}
+	return report
+}
+
+// healthCheckResult converts a health report to its doctor output
+func healthCheckResult(report health.Report) HealthCheckResult {
+	status := HealthPass
+	switch report.Severity {
+	case health.SeverityWarning:
+		status = HealthWarn
+	case health.SeverityCritical:
+		status = HealthFail
+	}
+	return HealthCheckResult{
+		Name:       report.ID,
+		Category:   report.Category,
+		Status:     status,
+		Message:    report.Message,
+		Details:    report.Details,
+		Suggestion: strings.Join(report.Remediation, "\n"),
+		Fixable:    report.Fix != nil,
+		Duration:   report.Duration,
+	}
+}
+
+// fixable counts the problems 'doctor --fix' can fix
+func (r *DoctorReport) fixable() int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Fixable {
+			count++
+		}
+	}
+	return count
+}
+
+// add appends a check and counts it in the summary
+func (r *DoctorReport) add(check HealthCheckResult) {
+	r.Checks = append(r.Checks, check)
+	switch check.Status {
+	case HealthPass:
+		r.Summary.Passed++
+	case HealthWarn:
+		r.Summary.Warnings++
+	default:
+		r.Summary.Failed++
+	}
+}
+
+// showDoctorReport prints the general health checks
+func (o *Orchestrator) showDoctorReport(report DoctorReport) {
+	o.reportf("🔍 General Health Check\n")
+	o.reportf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+
+	for _, check := range report.Checks {
+		icon := "✅"
+		switch check.Status {
+		case HealthWarn:
+			icon = "⚠️ "
+		case HealthFail:
+			icon = "❌"
+		}
+		o.reportf("%s %-18s %s\n", icon, check.Name, check.Message)
+		if check.Suggestion != "" {
+			for _, step := range strings.Split(check.Suggestion, "\n") {
+				o.reportf("   💡 %s\n", step)
+			}
+		}
+	}
+
+	o.reportf("\n📈 Summary: %d passed, %d warnings, %d failed\n", report.Summary.Passed, report.Summary.Warnings, report.Summary.Failed)
+	o.reportf("\nFor tool-specific diagnostics, specify a tool name.\n")
+	o.reportf("Example: gearbox doctor nerd-fonts\n")
+}
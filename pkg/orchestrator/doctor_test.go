@@ -0,0 +1,51 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"gearbox/pkg/health"
+)
+
+func TestHealthChecksAddConfigurationChecks(t *testing.T) {
+	o := &Orchestrator{configMgr: &ConfigManager{config: Config{
+		Tools: []ToolConfig{{Name: "ripgrep"}, {Name: "fd"}},
+	}}}
+
+	registry := o.HealthChecks()
+	for _, id := range []string{"memory", "disk-space", "internet", "path", "configuration", "package-manager", "tools"} {
+		if _, found := registry.Get(id); !found {
+			t.Errorf("expected the %s check to be registered", id)
+		}
+	}
+
+	check, _ := registry.Get("package-manager")
+	if result := check.Run(context.Background()); result.Severity != health.SeverityWarning {
+		t.Errorf("expected a warning without a package manager, got %+v", result)
+	}
+}
+
+func TestMirrorCheckReplacesInternetCheck(t *testing.T) {
+	t.Setenv("GEARBOX_MIRROR", t.TempDir())
+
+	check, _ := SystemHealthChecks().Get("internet")
+	result := check.Run(context.Background())
+	if result.Severity != health.SeverityCritical || result.Message != "Offline mirror not found" {
+		t.Errorf("expected the missing mirror to be reported, got %+v", result)
+	}
+}
+
+func TestHealthCheckResultMapsSeverity(t *testing.T) {
+	check := healthCheckResult(health.Report{
+		ID:       "disk-space",
+		Category: health.CategorySystem,
+		Result: health.Result{
+			Severity:    health.SeverityCritical,
+			Message:     "200 MB free",
+			Remediation: []string{"Free space", "Remove old builds"},
+		},
+	})
+	if check.Name != "disk-space" || check.Status != HealthFail || check.Suggestion != "Free space\nRemove old builds" {
+		t.Errorf("unexpected doctor check %+v", check)
+	}
+}
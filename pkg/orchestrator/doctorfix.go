@@ -0,0 +1,241 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gearbox/pkg/health"
+	"gearbox/pkg/manifest"
+	"gearbox/pkg/uninstall"
+)
+
+// toolchainFix installs the language toolchains with the common dependencies
+// script, the way the first installation does
+func (o *Orchestrator) toolchainFix(result health.Result) *health.Fix {
+	return &health.Fix{
+		Description: "Install the Rust and Go toolchains with install-common-deps.sh",
+		Apply: func(ctx context.Context) error {
+			if err := o.installCommonDependencies(nil); err != nil {
+				return err
+			}
+			// The script puts them on PATH of new shells only
+			prependPath([]string{filepath.Join(os.Getenv("HOME"), ".cargo", "bin"), "/usr/local/go/bin"})
+			return nil
+		},
+	}
+}
+
+// binariesCheck reports tracked tools whose binaries are gone, and offers to
+// reinstall them
+func (o *Orchestrator) binariesCheck() health.Check {
+	return health.NewCheck("binaries", health.CategoryGearbox, func(ctx context.Context) health.Result {
+		manager := manifest.NewManager()
+		if !manager.Exists() {
+			return health.Result{Severity: health.SeverityOK, Message: "No installations tracked"}
+		}
+		m, err := manager.Load()
+		if err != nil {
+			return health.Result{Severity: health.SeverityWarning, Message: err.Error()}
+		}
+
+		var broken, reinstallable []string
+		result := health.Result{Severity: health.SeverityOK}
+		for name, record := range m.Installations {
+			if record.Method == manifest.MethodBundle || record.Method == manifest.MethodPreExisting {
+				continue
+			}
+			for _, path := range record.BinaryPaths {
+				if _, err := os.Stat(path); err != nil {
+					result.Details = append(result.Details, fmt.Sprintf("%s: %s is missing", name, path))
+					broken = append(broken, name)
+					break
+				}
+			}
+		}
+		sort.Strings(broken)
+		sort.Strings(result.Details)
+		if len(broken) == 0 {
+			result.Message = fmt.Sprintf("All binaries of %d tracked installations present", len(m.Installations))
+			return result
+		}
+
+		result.Severity = health.SeverityWarning
+		result.Message = fmt.Sprintf("Missing binaries of %s", strings.Join(broken, ", "))
+		result.Remediation = []string{fmt.Sprintf("Reinstall with 'gearbox install %s'", strings.Join(broken, " "))}
+		for _, name := range broken {
+			if _, found := o.findTool(name); found {
+				reinstallable = append(reinstallable, name)
+			}
+		}
+		if len(reinstallable) > 0 {
+			result.Fix = &health.Fix{
+				Description: fmt.Sprintf("Reinstall %s", strings.Join(reinstallable, ", ")),
+				Apply: func(ctx context.Context) error {
+					return o.reinstall(reinstallable)
+				},
+			}
+		}
+		return result
+	})
+}
+
+// fontCacheCheck reports a font cache older than the fonts installed in
+// ~/.local/share/fonts, such as Nerd Fonts, which applications then miss
+func fontCacheCheck() health.Check {
+	return health.NewCheck("font-cache", health.CategorySystem, func(ctx context.Context) health.Result {
+		home, _ := os.UserHomeDir()
+		fontsDir := filepath.Join(home, ".local", "share", "fonts")
+		fontsChanged := newestModTime(fontsDir)
+		if fontsChanged.IsZero() {
+			return health.Result{Severity: health.SeverityOK, Message: "No user fonts installed"}
+		}
+		if _, err := exec.LookPath("fc-cache"); err != nil {
+			return health.Result{
+				Severity:    health.SeverityWarning,
+				Message:     "fc-cache not found",
+				Remediation: []string{"Install fontconfig: sudo apt install fontconfig"},
+			}
+		}
+
+		cacheDir := filepath.Join(home, ".cache", "fontconfig")
+		if cacheChanged := newestModTime(cacheDir); cacheChanged.Before(fontsChanged) {
+			return health.Result{
+				Severity:    health.SeverityWarning,
+				Message:     "Font cache is older than the installed fonts",
+				Details:     []string{"Fonts: " + fontsDir, "Cache: " + cacheDir},
+				Remediation: []string{"Refresh the font cache: fc-cache -f"},
+				Fix: &health.Fix{
+					Description: "Refresh the font cache with fc-cache -f",
+					Apply: func(ctx context.Context) error {
+						if output, err := exec.CommandContext(ctx, "fc-cache", "-f").CombinedOutput(); err != nil {
+							return fmt.Errorf("fc-cache failed: %w: %s", err, strings.TrimSpace(string(output)))
+						}
+						return nil
+					},
+				},
+			}
+		}
+		return health.Result{Severity: health.SeverityOK, Message: "Font cache is up to date"}
+	})
+}
+
+// buildDirsCheck reports the checkouts 'gc' would remove from the build
+// directory, such as those of failed or removed installations. The fix moves
+// them to the trash.
+func (o *Orchestrator) buildDirsCheck() health.Check {
+	return health.NewCheck("build-dirs", health.CategoryGearbox, func(ctx context.Context) health.Result {
+		stale, err := o.StaleBuildDirs()
+		if err != nil {
+			return health.Result{Severity: health.SeverityWarning, Message: fmt.Sprintf("Could not read the manifest: %v", err)}
+		}
+		if len(stale) == 0 {
+			return health.Result{Severity: health.SeverityOK, Message: "No stale build directories"}
+		}
+
+		var total int64
+		result := health.Result{Severity: health.SeverityWarning}
+		for _, dir := range stale {
+			total += dir.Size
+			result.Details = append(result.Details, fmt.Sprintf("%s (%s, %s)", dir.Path, formatBytes(dir.Size), dir.Reason))
+		}
+		result.Message = fmt.Sprintf("%d build directories unused for %d days (%s)", len(stale), DefaultGCDays, formatBytes(total))
+		result.Remediation = []string{"Remove them with 'gearbox doctor --fix' or 'gearbox gc'"}
+		result.Fix = &health.Fix{
+			Description: fmt.Sprintf("Move %d build directories of tools that are not installed to the trash, freeing %s", len(stale), formatBytes(total)),
+			Apply: func(ctx context.Context) error {
+				entry := uninstall.NewTrash().NewEntry()
+				for _, dir := range stale {
+					if _, err := entry.Move(dir.Path); err != nil {
+						return fmt.Errorf("failed to move %s to the trash: %w", dir.Path, err)
+					}
+				}
+				return nil
+			},
+		}
+		return result
+	})
+}
+
+// fixHealthProblems offers the fixes of the checks that found problems. Each
+// fix says what it will do and runs when confirmed, then its check of the
+// registry runs again. The reports are updated with the outcome. It returns
+// the number of problems fixed and the number of fixes offered.
+func (o *Orchestrator) fixHealthProblems(registry *health.Registry, reports []health.Report, confirm func(question string) bool) (int, int) {
+	fixed, offered, applied := 0, 0, false
+	for i := range reports {
+		report := &reports[i]
+		if report.Severity == health.SeverityOK || report.Fix == nil {
+			continue
+		}
+		check, found := registry.Get(report.ID)
+		if !found {
+			continue
+		}
+
+		// An earlier fix may have fixed this problem too
+		if applied {
+			*report = health.RunCheck(context.Background(), check, health.DefaultTimeout)
+			if report.Severity == health.SeverityOK {
+				fixed++
+				o.reportf("✅ %s: %s\n", report.ID, report.Message)
+				continue
+			}
+			if report.Fix == nil {
+				continue
+			}
+		}
+
+		offered++
+		o.reportf("\n🔧 %s: %s\n", report.ID, report.Message)
+		o.reportf("   Fix: %s\n", report.Fix.Description)
+		if !confirm("   Apply this fix?") {
+			o.reportf("   Skipped\n")
+			continue
+		}
+		applied = true
+		if err := report.Fix.Apply(context.Background()); err != nil {
+			o.reportf("❌ %s: fix failed: %v\n", report.ID, err)
+			continue
+		}
+
+		*report = health.RunCheck(context.Background(), check, health.DefaultTimeout)
+		if report.Severity != health.SeverityOK {
+			o.reportf("⚠️  %s: still %s\n", report.ID, report.Message)
+			continue
+		}
+		fixed++
+		o.reportf("✅ %s: %s\n", report.ID, report.Message)
+	}
+	return fixed, offered
+}
+
+// newestModTime returns when the newest file below a directory changed, the
+// zero time when it holds no files
+func newestModTime(dir string) time.Time {
+	var newest time.Time
+	filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+		return nil
+	})
+	return newest
+}
+
+// prependPath adds directories to the front of PATH of this process, skipping
+// those already on it
+func prependPath(dirs []string) {
+	path := os.Getenv("PATH")
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if !strings.Contains(":"+path+":", ":"+dirs[i]+":") {
+			path = dirs[i] + ":" + path
+		}
+	}
+	os.Setenv("PATH", path)
+}
@@ -0,0 +1,112 @@
+package orchestrator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gearbox/pkg/health"
+	"gearbox/pkg/uninstall"
+)
+
+// fixableCheck reports a problem until its fix ran
+func fixableCheck(id string, fixed *bool) health.Check {
+	return health.NewCheck(id, health.CategorySystem, func(ctx context.Context) health.Result {
+		if *fixed {
+			return health.Result{Severity: health.SeverityOK, Message: "fixed"}
+		}
+		return health.Result{
+			Severity: health.SeverityWarning,
+			Message:  "broken",
+			Fix: &health.Fix{Description: "fix " + id, Apply: func(ctx context.Context) error {
+				*fixed = true
+				return nil
+			}},
+		}
+	})
+}
+
+func TestFixHealthProblemsAppliesConfirmedFixesAndChecksAgain(t *testing.T) {
+	var aFixed, bFixed bool
+	registry := health.NewRegistry(fixableCheck("a", &aFixed), fixableCheck("b", &bFixed))
+	reports := registry.Run(context.Background(), health.DefaultTimeout)
+
+	var asked []string
+	fixed, offered := (&Orchestrator{}).fixHealthProblems(registry, reports, func(question string) bool {
+		asked = append(asked, question)
+		return len(asked) == 1
+	})
+	if fixed != 1 || offered != 2 || len(asked) != 2 {
+		t.Fatalf("expected one of two fixes applied, got %d fixed, %d offered, asked %v", fixed, offered, asked)
+	}
+	if !aFixed || bFixed {
+		t.Errorf("expected only the confirmed fix to run")
+	}
+	if reports[0].Severity != health.SeverityOK || reports[0].Message != "fixed" {
+		t.Errorf("expected the check to run again after the fix, got %+v", reports[0])
+	}
+	if reports[1].Severity != health.SeverityWarning {
+		t.Errorf("expected the skipped problem to remain, got %+v", reports[1])
+	}
+}
+
+func TestFixHealthProblemsSkipsProblemsFixedByEarlierFixes(t *testing.T) {
+	fixed := false
+	registry := health.NewRegistry(fixableCheck("rust", &fixed), fixableCheck("go", &fixed))
+	reports := registry.Run(context.Background(), health.DefaultTimeout)
+
+	asked := 0
+	count, offered := (&Orchestrator{}).fixHealthProblems(registry, reports, func(string) bool {
+		asked++
+		return true
+	})
+	if asked != 1 || offered != 1 || count != 2 {
+		t.Errorf("expected one fix to fix both problems, got %d asked, %d offered, %d fixed", asked, offered, count)
+	}
+}
+
+func TestBuildDirsCheckMovesOldCheckoutsOfToolsNotInstalledToTheTrash(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	buildDir := filepath.Join(home, "tools", "build")
+	for _, dir := range []string{"gearbox-missing-tool", "gearbox-recent-tool", "gearbox-busy-tool", "other-project"} {
+		writeFile(t, filepath.Join(buildDir, dir, "Makefile"), "all:")
+		if dir != "gearbox-recent-tool" {
+			ageTree(t, filepath.Join(buildDir, dir), time.Now().AddDate(0, 0, -DefaultGCDays-1))
+		}
+	}
+
+	o := &Orchestrator{
+		options: InstallationOptions{BusyTools: []string{"gearbox-busy-tool"}},
+		configMgr: &ConfigManager{config: Config{Tools: []ToolConfig{
+			{Name: "gearbox-missing-tool", BinaryName: "gearbox-missing-tool"},
+			{Name: "gearbox-recent-tool", BinaryName: "gearbox-recent-tool"},
+			{Name: "gearbox-busy-tool", BinaryName: "gearbox-busy-tool"},
+		}}},
+	}
+	result := o.buildDirsCheck().Run(context.Background())
+	if result.Severity != health.SeverityWarning || result.Fix == nil || len(result.Details) != 1 {
+		t.Fatalf("expected only the old checkout to be reported with a fix, got %+v", result)
+	}
+	if err := result.Fix.Apply(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(buildDir, "gearbox-missing-tool")); !os.IsNotExist(err) {
+		t.Error("expected the stale checkout to be removed")
+	}
+	for _, dir := range []string{"gearbox-recent-tool", "gearbox-busy-tool", "other-project"} {
+		if _, err := os.Stat(filepath.Join(buildDir, dir)); err != nil {
+			t.Errorf("expected %s to be kept", dir)
+		}
+	}
+
+	entries, err := uninstall.NewTrash().List()
+	if err != nil || len(entries) != 1 || len(entries[0].Files) != 1 || entries[0].Files[0].Original != filepath.Join(buildDir, "gearbox-missing-tool") {
+		t.Errorf("expected the stale checkout in the trash, got %v (%v)", entries, err)
+	}
+	if result := o.buildDirsCheck().Run(context.Background()); result.Severity != health.SeverityOK {
+		t.Errorf("expected no stale checkouts after the fix, got %+v", result)
+	}
+}
@@ -0,0 +1,204 @@
+package orchestrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType names the kinds of events an installation reports
+type EventType string
+
+const (
+	// EventPlan describes the tools about to be installed
+	EventPlan EventType = "plan"
+	// EventMessage is an informational line such as a warning or a step
+	EventMessage EventType = "message"
+	// EventToolStarted is sent when the build of a tool starts
+	EventToolStarted EventType = "tool_started"
+	// EventOutput is one line written by an installation script
+	EventOutput EventType = "output"
+	// EventProgress is sent when installations start and after each finishes
+	EventProgress EventType = "progress"
+	// EventToolFinished carries the result of one tool
+	EventToolFinished EventType = "tool_finished"
+	// EventSummary carries the results of all tools
+	EventSummary EventType = "summary"
+)
+
+// Event is one step of an installation. Only the field of its type is set.
+type Event struct {
+	Type     EventType       `json:"type"`
+	Time     time.Time       `json:"time"`
+	Tool     string          `json:"tool,omitempty"`
+	Message  string          `json:"message,omitempty"` // message and output events
+	Plan     *InstallPlan    `json:"plan,omitempty"`
+	Build    *BuildStart     `json:"build,omitempty"`
+	Progress *Progress       `json:"progress,omitempty"`
+	Result   *ToolResult     `json:"result,omitempty"`
+	Summary  *InstallSummary `json:"summary,omitempty"`
+}
+
+// InstallPlan describes an installation before it starts
+type InstallPlan struct {
+	DryRun           bool                `json:"dry_run"`
+	BuildType        string              `json:"build_type"`
+	ParallelJobs     int                 `json:"parallel_jobs"`
+	MemoryMB         int64               `json:"memory_mb"`
+	CPUs             int                 `json:"cpus"`
+	SkipCommonDeps   bool                `json:"skip_common_deps"`
+	RunTests         bool                `json:"run_tests"`
+	ShellIntegration bool                `json:"shell_integration"`
+	Bundles          map[string][]string `json:"bundles,omitempty"`      // Tools requested through each bundle
+	DirectTools      []string            `json:"direct_tools,omitempty"` // Tools requested by name
+	Suggestions      []string            `json:"suggestions,omitempty"`  // Related tools worth adding
+	PackageManager   string              `json:"package_manager,omitempty"`
+	SystemPackages   []string            `json:"system_packages,omitempty"`
+	Tools            []PlannedTool       `json:"tools"` // In installation order
+	Disk             []DiskRequirement   `json:"disk,omitempty"`
+	EstimatedSeconds float64             `json:"estimated_seconds"`
+	MeasuredBuilds   int                 `json:"measured_builds"`         // Tools with a measured build time
+	ByBuildType      map[string]float64  `json:"by_build_type,omitempty"` // Estimated seconds per build type, dry runs only
+}
+
+// PlannedTool is one tool of an installation plan
+type PlannedTool struct {
+	Name             string  `json:"name"`
+	Language         string  `json:"language"`
+	BuildFlag        string  `json:"build_flag,omitempty"`
+	DiskMB           int64   `json:"disk_mb"`
+	EstimatedSeconds float64 `json:"estimated_seconds"`
+}
+
+// BuildStart describes the resources a build was started with
+type BuildStart struct {
+	Jobs             int     `json:"jobs"`
+	MemoryMB         int     `json:"memory_mb"`
+	EstimatedSeconds float64 `json:"estimated_seconds"`
+}
+
+// Progress counts the finished installations
+type Progress struct {
+	Completed  int     `json:"completed"`
+	Total      int     `json:"total"`
+	ETASeconds float64 `json:"eta_seconds"` // Expected time until all builds finish
+}
+
+// ToolResult is the outcome of installing one tool
+type ToolResult struct {
+	Tool            string  `json:"tool"`
+	Description     string  `json:"description,omitempty"`
+	Success         bool    `json:"success"`
+	Error           string  `json:"error,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// InstallSummary counts the results of an installation
+type InstallSummary struct {
+	Results      []ToolResult `json:"results"` // Successful first, then by name
+	Successful   int          `json:"successful"`
+	Failed       int          `json:"failed"`
+	TotalSeconds float64      `json:"total_seconds"`
+}
+
+// Reporter receives the events of an installation. Builds run in parallel,
+// so Report must be safe for concurrent use.
+type Reporter interface {
+	Report(event Event)
+}
+
+// ReporterFunc adapts a function to the Reporter interface
+type ReporterFunc func(event Event)
+
+// Report calls f(event)
+func (f ReporterFunc) Report(event Event) {
+	f(event)
+}
+
+// report stamps an event and passes it to the reporter
+func (o *Orchestrator) report(event Event) {
+	if o.reporter == nil {
+		return
+	}
+	event.Time = time.Now()
+	o.reporter.Report(event)
+}
+
+// reportf reports a message. Messages keep the line breaks of the text output.
+func (o *Orchestrator) reportf(format string, args ...interface{}) {
+	o.report(Event{Type: EventMessage, Message: fmt.Sprintf(format, args...)})
+}
+
+// toolResult converts an installation result for reporting
+func toolResult(result InstallationResult) ToolResult {
+	converted := ToolResult{
+		Tool:            result.Tool.Name,
+		Description:     result.Tool.Description,
+		Success:         result.Success,
+		DurationSeconds: result.Duration.Seconds(),
+	}
+	if result.Error != nil {
+		converted.Error = result.Error.Error()
+	}
+	return converted
+}
+
+// jsonReporter writes events as newline-delimited JSON
+type jsonReporter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONReporter returns a reporter that writes one JSON object per event
+// and line, for scripts and frontends that follow an installation
+func NewJSONReporter(w io.Writer) Reporter {
+	return &jsonReporter{encoder: json.NewEncoder(w)}
+}
+
+// Report writes the event as one line
+func (r *jsonReporter) Report(event Event) {
+	// Messages are formatted for the terminal; events carry the bare text
+	if event.Type == EventMessage {
+		if event.Message = strings.TrimSpace(event.Message); event.Message == "" {
+			return
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.encoder.Encode(event)
+}
+
+// lineReporter reports the lines written by an installation script as
+// output events
+type lineReporter struct {
+	o       *Orchestrator
+	tool    string
+	pending bytes.Buffer
+}
+
+// Write reports every complete line and keeps the rest for the next write
+func (w *lineReporter) Write(p []byte) (int, error) {
+	w.pending.Write(p)
+	for {
+		line, err := w.pending.ReadString('\n')
+		if err != nil {
+			// Incomplete line, wait for the rest
+			w.pending.WriteString(line)
+			return len(p), nil
+		}
+		w.o.report(Event{Type: EventOutput, Tool: w.tool, Message: strings.TrimRight(line, "\r\n")})
+	}
+}
+
+// Flush reports a last line without a line break
+func (w *lineReporter) Flush() {
+	if w.pending.Len() > 0 {
+		w.o.report(Event{Type: EventOutput, Tool: w.tool, Message: w.pending.String()})
+		w.pending.Reset()
+	}
+}
@@ -0,0 +1,141 @@
+package orchestrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// eventRecorder collects the events of an orchestrator
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) Report(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func TestJSONReporterWritesOneEventPerLine(t *testing.T) {
+	var out bytes.Buffer
+	o := &Orchestrator{reporter: NewJSONReporter(&out)}
+
+	o.reportf("\n📦 Installing common dependencies...\n")
+	o.reportf("\n")
+	o.report(Event{Type: EventToolFinished, Tool: "fd", Result: &ToolResult{Tool: "fd", Success: true}})
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines without the empty message, got %d:\n%s", len(lines), out.String())
+	}
+
+	var message, finished Event
+	if err := json.Unmarshal([]byte(lines[0]), &message); err != nil {
+		t.Fatalf("invalid event: %v", err)
+	}
+	if message.Type != EventMessage || message.Message != "📦 Installing common dependencies..." {
+		t.Errorf("expected the trimmed message, got %+v", message)
+	}
+	if message.Time.IsZero() {
+		t.Errorf("expected events to be stamped")
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &finished); err != nil {
+		t.Fatalf("invalid event: %v", err)
+	}
+	if finished.Type != EventToolFinished || finished.Result == nil || !finished.Result.Success {
+		t.Errorf("unexpected tool_finished event %+v", finished)
+	}
+}
+
+func TestLineReporterReportsCompleteLines(t *testing.T) {
+	recorder := &eventRecorder{}
+	lines := &lineReporter{o: &Orchestrator{reporter: recorder}, tool: "fd"}
+
+	lines.Write([]byte("Cloning...\nBuil"))
+	lines.Write([]byte("ding\r\n"))
+	lines.Write([]byte("done"))
+	if len(recorder.events) != 2 {
+		t.Fatalf("expected 2 complete lines before flushing, got %+v", recorder.events)
+	}
+	lines.Flush()
+
+	var got []string
+	for _, event := range recorder.events {
+		if event.Type != EventOutput || event.Tool != "fd" {
+			t.Errorf("unexpected event %+v", event)
+		}
+		got = append(got, event.Message)
+	}
+	if strings.Join(got, "|") != "Cloning...|Building|done" {
+		t.Errorf("unexpected lines %q", got)
+	}
+}
+
+func TestInstallToolsDryRunReportsPlan(t *testing.T) {
+	recorder := &eventRecorder{}
+	o := &Orchestrator{
+		configMgr: &ConfigManager{config: Config{Tools: []ToolConfig{
+			{Name: "fd", Language: "rust", BuildTypes: map[string]string{"standard": "-r"}},
+			{Name: "fzf", Language: "go"},
+		}}},
+		bundleConfig: &BundleConfiguration{},
+		options:      InstallationOptions{BuildType: "standard", MaxParallelJobs: 2, DryRun: true},
+		reporter:     recorder,
+	}
+
+	if err := o.InstallTools([]string{"fd", "fzf"}); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+
+	var plan *InstallPlan
+	for _, event := range recorder.events {
+		switch event.Type {
+		case EventPlan:
+			plan = event.Plan
+		case EventToolStarted, EventSummary:
+			t.Errorf("dry run reported %s", event.Type)
+		}
+	}
+	if plan == nil {
+		t.Fatalf("expected a plan event, got %+v", recorder.events)
+	}
+	if !plan.DryRun || len(plan.Tools) != 2 || plan.Tools[0].Name != "fzf" || plan.Tools[1].BuildFlag != "-r" {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+	if len(plan.ByBuildType) != len(buildTypes) {
+		t.Errorf("expected dry runs to compare build types, got %v", plan.ByBuildType)
+	}
+}
+
+func TestTextReporterShowsSummary(t *testing.T) {
+	var out bytes.Buffer
+	reporter := NewTextReporter(&out, false)
+
+	reporter.Report(Event{Type: EventOutput, Tool: "fd", Message: "hidden unless verbose"})
+	reporter.Report(Event{Type: EventSummary, Summary: &InstallSummary{
+		Results: []ToolResult{
+			{Tool: "fd", Description: "Fast find", Success: true, DurationSeconds: 12},
+			{Tool: "ffmpeg", Error: "exit status 1", DurationSeconds: 30},
+		},
+		Successful:   1,
+		Failed:       1,
+		TotalSeconds: 42,
+	}})
+
+	text := out.String()
+	if strings.Contains(text, "hidden unless verbose") {
+		t.Errorf("script output shown without verbose:\n%s", text)
+	}
+	for _, want := range []string{"✅ fd", "❌ ffmpeg", "exit status 1", "Successful: 1", "Average Duration: 21.0s"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "All tools installed successfully") {
+		t.Errorf("reported success despite a failure:\n%s", text)
+	}
+}
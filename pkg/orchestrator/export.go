@@ -0,0 +1,521 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gearbox/pkg/manifest"
+)
+
+// Supported export formats
+const (
+	ExportJSON         = "json"
+	ExportDockerfile   = "dockerfile"
+	ExportScript       = "script"
+	ExportDevcontainer = "devcontainer"
+)
+
+// ExportOptions configures how an environment is exported
+type ExportOptions struct {
+	Format         string // json, dockerfile, script or devcontainer
+	PackageManager string // Target distribution's package manager (default: apt)
+	BaseImage      string // Base image for Dockerfiles (default depends on package manager)
+	Source         string // Git URL to clone gearbox from instead of copying this repository
+	Output         string // Output file, or directory for devcontainer; stdout when empty
+	FromManifest   bool   // Export the tools and bundles recorded in the manifest
+}
+
+// defaultBaseImages maps each package manager to a base image that uses it
+var defaultBaseImages = map[string]string{
+	"apt":    "debian:bookworm",
+	"yum":    "rockylinux:9",
+	"dnf":    "fedora:latest",
+	"pacman": "archlinux:latest",
+	"zypper": "opensuse/tumbleweed",
+	"apk":    "alpine:latest",
+}
+
+// exportPrerequisites are the canonical packages every exported environment
+// needs to run the installation scripts as an unprivileged user
+var exportPrerequisites = []string{"bash", "sudo", "git", "ca-certificates", "curl", "wget"}
+
+// exportPlan is the resolved content of an exported environment
+type exportPlan struct {
+	Names          []string
+	Manager        *PackageManager
+	SystemPackages []string
+	CommonDeps     bool
+	Steps          []exportStep
+}
+
+// exportStep is a single tool installation in resolved order
+type exportStep struct {
+	Tool   string
+	Script string // Relative to the repository root
+	Args   []string
+}
+
+// Export resolves the given tools and bundles exactly like an installation
+// would and writes them out as a self-contained artifact
+func (o *Orchestrator) Export(names []string, opts ExportOptions) error {
+	if opts.Format == ExportJSON {
+		if len(names) > 0 {
+			return fmt.Errorf("json export captures the installed set from the manifest and takes no tool arguments")
+		}
+		return o.exportEnvironment(opts.Output)
+	}
+
+	if opts.FromManifest {
+		manifestNames, err := o.manifestSelection()
+		if err != nil {
+			return err
+		}
+		names = append(names, manifestNames...)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no tools or bundles specified for export")
+	}
+
+	if opts.PackageManager == "" {
+		opts.PackageManager = "apt"
+	}
+	if opts.BaseImage == "" {
+		opts.BaseImage = defaultBaseImages[opts.PackageManager]
+	}
+
+	plan, err := o.buildExportPlan(names, opts.PackageManager)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case ExportDockerfile:
+		return o.writeExport(opts.Output, o.renderDockerfile(plan, opts))
+	case ExportScript:
+		return o.writeExport(opts.Output, o.renderScript(plan, opts))
+	case ExportDevcontainer:
+		return o.writeDevcontainerFeature(plan, opts)
+	default:
+		return fmt.Errorf("unsupported export format: %s (supported: %s, %s, %s, %s)",
+			opts.Format, ExportJSON, ExportDockerfile, ExportScript, ExportDevcontainer)
+	}
+}
+
+// exportEnvironment writes the installed set recorded in the manifest as a
+// portable environment file for 'gearbox import'
+func (o *Orchestrator) exportEnvironment(output string) error {
+	manifestData, err := manifest.NewManager().Load()
+	if err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest.ExportEnvironment(manifestData), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode environment: %w", err)
+	}
+
+	return o.writeExport(output, string(data)+"\n")
+}
+
+// manifestSelection returns the tools and bundles recorded in the manifest,
+// skipping pre-existing tools that gearbox did not install
+func (o *Orchestrator) manifestSelection() ([]string, error) {
+	manifestData, err := manifest.NewManager().Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	var names []string
+	for name, record := range manifestData.Installations {
+		if record.Method == manifest.MethodPreExisting {
+			continue
+		}
+		if strings.HasSuffix(name, "_bundle") {
+			name = strings.TrimSuffix(name, "_bundle")
+		}
+		if _, found := o.findTool(name); !found && !o.isBundle(name, o.bundleConfig.Bundles) {
+			o.reportf("⚠️  Skipping %s: not a known tool or bundle\n", name)
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// buildExportPlan expands bundles, resolves the installation order and
+// collects the system packages for the target package manager
+func (o *Orchestrator) buildExportPlan(names []string, managerName string) (*exportPlan, error) {
+	mgr, err := findPackageManager(managerName)
+	if err != nil {
+		return nil, err
+	}
+
+	expandedToolNames, err := o.expandBundlesAndTools(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand bundles: %w", err)
+	}
+
+	var tools []ToolConfig
+	for _, name := range expandedToolNames {
+		tool, found := o.findTool(name)
+		if !found {
+			return nil, fmt.Errorf("tool not found: %s", name)
+		}
+		tools = append(tools, tool)
+	}
+
+	installOrder, err := o.resolveDependencies(tools)
+	if err != nil {
+		return nil, fmt.Errorf("dependency resolution failed: %w", err)
+	}
+
+	plan := &exportPlan{
+		Names:      names,
+		Manager:    mgr,
+		CommonDeps: !o.options.SkipCommonDeps,
+	}
+
+	// System packages: prerequisites, bundle packages, then common and tool dependencies
+	packages := o.packageMapping.Translate(exportPrerequisites, mgr.Name)
+	for _, name := range names {
+		if o.isBundle(name, o.bundleConfig.Bundles) {
+			visited := make(map[string]bool)
+			bundlePackages, err := o.expandSystemPackages(name, o.bundleConfig.Bundles, visited, mgr.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to expand system packages from bundle %s: %w", name, err)
+			}
+			packages = append(packages, bundlePackages...)
+		}
+	}
+	if plan.CommonDeps {
+		var canonical []string
+		if o.packageMapping != nil {
+			canonical = append(canonical, o.packageMapping.CommonDependencies...)
+		}
+		for _, tool := range installOrder {
+			canonical = append(canonical, o.packageMapping.SystemDependencies(tool.Dependencies)...)
+		}
+		packages = append(packages, o.packageMapping.Translate(canonical, mgr.Name)...)
+	}
+
+	// Remove duplicates while preserving order
+	seen := make(map[string]bool)
+	for _, pkg := range packages {
+		if !seen[pkg] {
+			seen[pkg] = true
+			plan.SystemPackages = append(plan.SystemPackages, pkg)
+		}
+	}
+
+	for _, tool := range installOrder {
+		scriptPath := o.findToolScript(tool.Name)
+		if _, err := os.Stat(scriptPath); err != nil {
+			return nil, fmt.Errorf("installation script not found for %s: %s", tool.Name, scriptPath)
+		}
+		relPath, err := filepath.Rel(o.repoDir, scriptPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve script path for %s: %w", tool.Name, err)
+		}
+
+		plan.Steps = append(plan.Steps, exportStep{
+			Tool:   tool.Name,
+			Script: filepath.ToSlash(relPath),
+			Args:   o.scriptFlags(tool),
+		})
+	}
+
+	return plan, nil
+}
+
+// exportHeader returns the comment lines describing an exported artifact
+func (o *Orchestrator) exportHeader(plan *exportPlan) []string {
+	var tools []string
+	for _, step := range plan.Steps {
+		tools = append(tools, step.Tool)
+	}
+
+	buildType := o.options.BuildType
+	if buildType == "" {
+		buildType = "standard"
+	}
+
+	return []string{
+		fmt.Sprintf("# Generated by gearbox export: %s", strings.Join(plan.Names, " ")),
+		fmt.Sprintf("# Package manager: %s, build type: %s", plan.Manager.Name, buildType),
+		fmt.Sprintf("# Tools (%d): %s", len(tools), strings.Join(tools, ", ")),
+	}
+}
+
+// systemPackageCommands returns the shell commands that refresh the package
+// lists and install the plan's system packages
+func systemPackageCommands(plan *exportPlan, sudo string) []string {
+	if len(plan.SystemPackages) == 0 {
+		return nil
+	}
+
+	var commands []string
+	if len(plan.Manager.UpdateCmd) > 0 {
+		commands = append(commands, sudo+shellJoin(plan.Manager.UpdateCmd))
+	}
+	install := append(append([]string{}, plan.Manager.InstallCmd...), plan.SystemPackages...)
+	commands = append(commands, sudo+shellJoin(install))
+	return commands
+}
+
+// toolCommands returns the shell commands that install common dependencies
+// and every tool in resolved order, relative to gearboxDir
+func toolCommands(plan *exportPlan, gearboxDir string) []string {
+	var commands []string
+	if plan.CommonDeps {
+		commands = append(commands, fmt.Sprintf("bash %s/scripts/installation/common/install-common-deps.sh --skip-system-packages", gearboxDir))
+	}
+	for _, step := range plan.Steps {
+		commands = append(commands, fmt.Sprintf("bash %s/%s %s", gearboxDir, step.Script, shellJoin(step.Args)))
+	}
+	return commands
+}
+
+// toolchainPath is the PATH used after the common dependencies install Rust and Go
+const toolchainPath = "$HOME/.cargo/bin:/usr/local/go/bin:$HOME/.local/bin:$PATH"
+
+// renderDockerfile renders the plan as a Dockerfile
+func (o *Orchestrator) renderDockerfile(plan *exportPlan, opts ExportOptions) string {
+	var b strings.Builder
+
+	for _, line := range o.exportHeader(plan) {
+		b.WriteString(line + "\n")
+	}
+	if opts.Source == "" {
+		b.WriteString("# Build with the gearbox repository as context:\n")
+		b.WriteString("#   docker build -f <this file> -t <image> <path to gearbox>\n")
+	}
+	fmt.Fprintf(&b, "FROM %s\n\n", opts.BaseImage)
+
+	for _, env := range plan.Manager.Env {
+		fmt.Fprintf(&b, "ENV %s\n", env)
+	}
+	if commands := systemPackageCommands(plan, ""); len(commands) > 0 {
+		fmt.Fprintf(&b, "RUN %s\n\n", strings.Join(commands, " && \\\n    "))
+	}
+
+	// The installation scripts refuse to run as root
+	if plan.Manager.Name == "apk" {
+		b.WriteString("RUN adduser -D -s /bin/bash gearbox && \\\n")
+	} else {
+		b.WriteString("RUN useradd -m -s /bin/bash gearbox && \\\n")
+	}
+	b.WriteString("    echo 'gearbox ALL=(ALL) NOPASSWD:ALL' > /etc/sudoers.d/gearbox\n")
+
+	if opts.Source != "" {
+		fmt.Fprintf(&b, "RUN git clone --depth 1 %s /opt/gearbox && chown -R gearbox:gearbox /opt/gearbox\n", shellQuote(opts.Source))
+	} else {
+		b.WriteString("COPY --chown=gearbox:gearbox scripts /opt/gearbox/scripts\n")
+		b.WriteString("COPY --chown=gearbox:gearbox config /opt/gearbox/config\n")
+	}
+
+	b.WriteString("\nUSER gearbox\n")
+	b.WriteString("RUN mkdir -p /home/gearbox/tools/build\n")
+	b.WriteString("WORKDIR /home/gearbox/tools/build\n")
+	fmt.Fprintf(&b, "ENV PATH=%s\n\n", strings.ReplaceAll(toolchainPath, "$HOME", "/home/gearbox"))
+
+	for _, command := range toolCommands(plan, "/opt/gearbox") {
+		fmt.Fprintf(&b, "RUN %s\n", command)
+	}
+
+	return b.String()
+}
+
+// renderScript renders the plan as a standalone bash script
+func (o *Orchestrator) renderScript(plan *exportPlan, opts ExportOptions) string {
+	var b strings.Builder
+
+	b.WriteString("#!/bin/bash\n\n")
+	for _, line := range o.exportHeader(plan) {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\nset -e  # Exit on any error\n\n")
+
+	if opts.Source != "" {
+		b.WriteString("GEARBOX_DIR=\"${GEARBOX_DIR:-$HOME/.gearbox/src}\"\n")
+		b.WriteString("if [[ ! -d \"$GEARBOX_DIR/scripts\" ]]; then\n")
+		fmt.Fprintf(&b, "    git clone --depth 1 %s \"$GEARBOX_DIR\"\n", shellQuote(opts.Source))
+		b.WriteString("fi\n\n")
+	} else {
+		fmt.Fprintf(&b, "GEARBOX_DIR=\"${GEARBOX_DIR:-%s}\"\n\n", o.repoDir)
+	}
+
+	if commands := systemPackageCommands(plan, "sudo -E "); len(commands) > 0 {
+		fmt.Fprintf(&b, "echo \"📦 Installing system packages (%s)...\"\n", plan.Manager.Name)
+		for _, env := range plan.Manager.Env {
+			fmt.Fprintf(&b, "export %s\n", env)
+		}
+		for _, command := range commands {
+			b.WriteString(command + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("mkdir -p \"$HOME/tools/build\"\n")
+	b.WriteString("cd \"$HOME/tools/build\"\n")
+	fmt.Fprintf(&b, "export PATH=\"%s\"\n\n", toolchainPath)
+
+	b.WriteString("echo \"🚀 Installing tools...\"\n")
+	for _, command := range toolCommands(plan, "\"$GEARBOX_DIR\"") {
+		b.WriteString(command + "\n")
+	}
+	b.WriteString("\necho \"✅ Environment ready\"\n")
+
+	return b.String()
+}
+
+// writeDevcontainerFeature writes the plan as a devcontainer feature directory
+func (o *Orchestrator) writeDevcontainerFeature(plan *exportPlan, opts ExportOptions) error {
+	if opts.Output == "" {
+		return fmt.Errorf("devcontainer export requires --file <directory>")
+	}
+	if err := os.MkdirAll(opts.Output, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	feature := map[string]interface{}{
+		"id":          "gearbox",
+		"version":     "1.0.0",
+		"name":        "Gearbox tools",
+		"description": fmt.Sprintf("Tools installed by gearbox: %s", strings.Join(plan.Names, ", ")),
+		"installsAfter": []string{
+			"ghcr.io/devcontainers/features/common-utils",
+		},
+	}
+	featureJSON, err := json.MarshalIndent(feature, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode devcontainer-feature.json: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(opts.Output, "devcontainer-feature.json"), append(featureJSON, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write devcontainer-feature.json: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("#!/bin/bash\n\n")
+	for _, line := range o.exportHeader(plan) {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\nset -e  # Exit on any error\n\n")
+	b.WriteString("FEATURE_DIR=\"$(cd \"$(dirname \"${BASH_SOURCE[0]}\")\" && pwd)\"\n")
+	b.WriteString("USERNAME=\"${_REMOTE_USER:-vscode}\"\n")
+	b.WriteString("if [[ \"$USERNAME\" == \"root\" ]]; then\n")
+	b.WriteString("    echo \"gearbox installation scripts must run as a non-root remote user\" >&2\n")
+	b.WriteString("    exit 1\n")
+	b.WriteString("fi\n\n")
+
+	for _, env := range plan.Manager.Env {
+		fmt.Fprintf(&b, "export %s\n", env)
+	}
+	for _, command := range systemPackageCommands(plan, "") {
+		b.WriteString(command + "\n")
+	}
+	b.WriteString("echo \"$USERNAME ALL=(ALL) NOPASSWD:ALL\" > /etc/sudoers.d/gearbox\n\n")
+
+	if opts.Source != "" {
+		fmt.Fprintf(&b, "git clone --depth 1 %s /opt/gearbox\n", shellQuote(opts.Source))
+	} else {
+		b.WriteString("mkdir -p /opt/gearbox\n")
+		b.WriteString("cp -r \"$FEATURE_DIR/gearbox/.\" /opt/gearbox/\n")
+	}
+	b.WriteString("chown -R \"$USERNAME\" /opt/gearbox\n\n")
+
+	b.WriteString("su - \"$USERNAME\" -s /bin/bash <<'EOF'\n")
+	b.WriteString("set -e\n")
+	b.WriteString("mkdir -p \"$HOME/tools/build\"\n")
+	b.WriteString("cd \"$HOME/tools/build\"\n")
+	fmt.Fprintf(&b, "export PATH=\"%s\"\n", toolchainPath)
+	for _, command := range toolCommands(plan, "/opt/gearbox") {
+		b.WriteString(command + "\n")
+	}
+	b.WriteString("EOF\n")
+
+	if err := os.WriteFile(filepath.Join(opts.Output, "install.sh"), []byte(b.String()), 0755); err != nil {
+		return fmt.Errorf("failed to write install.sh: %w", err)
+	}
+
+	// Bundle the scripts so the feature does not need network access to gearbox
+	if opts.Source == "" {
+		for _, dir := range []string{"scripts", "config"} {
+			if err := copyTree(filepath.Join(o.repoDir, dir), filepath.Join(opts.Output, "gearbox", dir)); err != nil {
+				return fmt.Errorf("failed to copy %s: %w", dir, err)
+			}
+		}
+	}
+
+	o.reportf("✅ Exported devcontainer feature to %s\n", opts.Output)
+	return nil
+}
+
+// writeExport writes an exported artifact to a file, or stdout when path is empty
+func (o *Orchestrator) writeExport(path, content string) error {
+	if path == "" {
+		_, err := io.WriteString(os.Stdout, content)
+		return err
+	}
+
+	mode := os.FileMode(0644)
+	if strings.HasPrefix(content, "#!") {
+		mode = 0755
+	}
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	o.reportf("✅ Exported to %s\n", path)
+	return nil
+}
+
+// copyTree recursively copies a directory, preserving file modes
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, relPath)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+// shellJoin quotes and joins arguments into a shell command line
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote quotes an argument for the shell when it contains special characters
+func shellQuote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_.,:/=+@%") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
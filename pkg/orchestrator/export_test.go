@@ -0,0 +1,141 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newRepoOrchestrator builds an orchestrator against the repository's real configuration
+func newRepoOrchestrator(t *testing.T, options InstallationOptions) *Orchestrator {
+	repoRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("failed to resolve repository root: %v", err)
+	}
+
+	o, err := NewOrchestratorBuilder(options).
+		WithRepoDir(repoRoot).
+		WithConfigPath(filepath.Join(repoRoot, "config", "tools.json")).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build orchestrator: %v", err)
+	}
+	return o
+}
+
+func TestBuildExportPlan(t *testing.T) {
+	o := newRepoOrchestrator(t, InstallationOptions{BuildType: "minimal"})
+
+	plan, err := o.buildExportPlan([]string{"minimal"}, "pacman")
+	if err != nil {
+		t.Fatalf("failed to build export plan: %v", err)
+	}
+
+	// Same order as an installation: Go tools first, then Rust
+	var order []string
+	for _, step := range plan.Steps {
+		order = append(order, step.Tool)
+	}
+	if strings.Join(order, ",") != "fzf,fd,ripgrep" {
+		t.Errorf("expected resolved order fzf,fd,ripgrep, got %v", order)
+	}
+
+	for _, step := range plan.Steps {
+		if !strings.HasPrefix(step.Script, "scripts/installation/categories/") {
+			t.Errorf("expected repository-relative script path, got %s", step.Script)
+		}
+		if len(step.Args) == 0 || step.Args[0] != "--minimal" {
+			t.Errorf("expected build type flag --minimal for %s, got %v", step.Tool, step.Args)
+		}
+	}
+
+	// Common dependencies are translated for the target package manager
+	if !contains(plan.SystemPackages, "base-devel") || contains(plan.SystemPackages, "build-essential") {
+		t.Errorf("expected translated pacman packages, got %v", plan.SystemPackages)
+	}
+}
+
+func TestBuildExportPlanRejectsUnknownInputs(t *testing.T) {
+	o := newRepoOrchestrator(t, InstallationOptions{})
+
+	if _, err := o.buildExportPlan([]string{"fd"}, "brew"); err == nil {
+		t.Error("expected error for unsupported package manager")
+	}
+	if _, err := o.buildExportPlan([]string{"no-such-tool"}, "apt"); err == nil {
+		t.Error("expected error for unknown tool")
+	}
+}
+
+func TestRenderExports(t *testing.T) {
+	o := newRepoOrchestrator(t, InstallationOptions{BuildType: "standard"})
+
+	plan, err := o.buildExportPlan([]string{"fd"}, "apk")
+	if err != nil {
+		t.Fatalf("failed to build export plan: %v", err)
+	}
+
+	dockerfile := o.renderDockerfile(plan, ExportOptions{BaseImage: "alpine:latest", Source: "https://example.com/gearbox.git"})
+	for _, expected := range []string{
+		"FROM alpine:latest",
+		"apk add --no-cache",
+		"build-base",
+		"adduser -D",
+		"git clone --depth 1 https://example.com/gearbox.git /opt/gearbox",
+		"USER gearbox",
+		"RUN bash /opt/gearbox/scripts/installation/categories/core/install-fd.sh --standard --skip-deps --force",
+	} {
+		if !strings.Contains(dockerfile, expected) {
+			t.Errorf("expected Dockerfile to contain %q:\n%s", expected, dockerfile)
+		}
+	}
+
+	script := o.renderScript(plan, ExportOptions{})
+	if !strings.HasPrefix(script, "#!/bin/bash") {
+		t.Error("expected script to start with a bash shebang")
+	}
+	commonDeps := strings.Index(script, "install-common-deps.sh --skip-system-packages")
+	fd := strings.Index(script, "install-fd.sh")
+	if commonDeps < 0 || fd < 0 || commonDeps > fd {
+		t.Errorf("expected common dependencies before tool steps:\n%s", script)
+	}
+}
+
+func TestWriteDevcontainerFeature(t *testing.T) {
+	o := newRepoOrchestrator(t, InstallationOptions{})
+	outputDir := filepath.Join(t.TempDir(), "feature")
+
+	err := o.Export([]string{"fd"}, ExportOptions{Format: ExportDevcontainer, Output: outputDir})
+	if err != nil {
+		t.Fatalf("failed to export devcontainer feature: %v", err)
+	}
+
+	for _, file := range []string{
+		"devcontainer-feature.json",
+		"install.sh",
+		filepath.Join("gearbox", "scripts", "installation", "categories", "core", "install-fd.sh"),
+	} {
+		if _, err := os.Stat(filepath.Join(outputDir, file)); err != nil {
+			t.Errorf("expected %s in feature directory: %v", file, err)
+		}
+	}
+
+	if err := o.Export([]string{"fd"}, ExportOptions{Format: ExportDevcontainer}); err == nil {
+		t.Error("expected error when devcontainer output directory is missing")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"build-essential":    "build-essential",
+		"@Development Tools": "'@Development Tools'",
+		"it's":               `'it'\''s'`,
+		"":                   "''",
+	}
+
+	for input, expected := range tests {
+		if result := shellQuote(input); result != expected {
+			t.Errorf("shellQuote(%q) = %q, expected %q", input, result, expected)
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gearbox/pkg/fsutil"
+	"gearbox/pkg/manifest"
+	"gearbox/pkg/shellinit"
+)
+
+// DefaultGCDays is how long 'gc' keeps orphans after they last changed, so
+// that builds in progress and recent backups survive
+const DefaultGCDays = 7
+
+// DefaultGCKeepBackups is the number of manifest backups 'gc' always keeps
+const DefaultGCKeepBackups = 5
+
+// tuiLogFile is where the TUI logs
+const tuiLogFile = "/tmp/gearbox-tui.log"
+
+// Kinds of garbage, in the order they are listed
+const (
+	GarbageBuildDir       = "build-dir"
+	GarbageSourceCache    = "source-cache"
+	GarbageCachedBuild    = "cached-build"
+	GarbageBackup         = "backup"
+	GarbageLog            = "log"
+	GarbageManifestBackup = "manifest-backup"
+)
+
+var garbageKinds = []string{GarbageBuildDir, GarbageSourceCache, GarbageCachedBuild, GarbageBackup, GarbageLog, GarbageManifestBackup}
+
+// GCOptions controls what 'gc' removes
+type GCOptions struct {
+	Days        int   // Keep orphans changed within this many days
+	MinSizeMB   int64 // Keep orphans smaller than this
+	KeepBackups int   // Manifest backups to keep regardless of their age
+	DryRun      bool
+	Yes         bool // Remove without asking
+}
+
+// Garbage is a file or directory gearbox left behind that nothing uses
+type Garbage struct {
+	Kind    string
+	Path    string
+	Reason  string
+	Size    int64
+	ModTime time.Time
+}
+
+// FindGarbage cross-references the build directory, the source and build
+// caches, backup copies, logs and manifest backups with the manifest and the
+// configured tools, and returns the orphans the retention policy of the
+// options allows to remove
+func (o *Orchestrator) FindGarbage(opts GCOptions) ([]Garbage, error) {
+	m, err := gcManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := o.orphanedBuildDirs(m)
+	orphanedRepos, err := o.orphanedRepositories()
+	if err != nil {
+		return nil, err
+	}
+	candidates = append(candidates, orphanedRepos...)
+	candidates = append(candidates, o.orphanedBuilds()...)
+	candidates = append(candidates, backupCopies(m)...)
+	candidates = append(candidates, logFiles()...)
+	candidates = append(candidates, manifestBackups(opts.KeepBackups)...)
+
+	return selectGarbage(candidates, opts, time.Now()), nil
+}
+
+// StaleBuildDirs returns the orphaned checkouts of the build directory that
+// 'gc' removes by default, those unchanged for DefaultGCDays
+func (o *Orchestrator) StaleBuildDirs() ([]Garbage, error) {
+	m, err := gcManifest()
+	if err != nil {
+		return nil, err
+	}
+	return selectGarbage(o.orphanedBuildDirs(m), GCOptions{Days: DefaultGCDays}, time.Now()), nil
+}
+
+// gcManifest loads the manifest, an empty one when nothing is installed
+func gcManifest() (*manifest.InstallationManifest, error) {
+	if manager := manifest.NewManager(); manager.Exists() {
+		return manager.Load()
+	}
+	return manifest.NewManifest(), nil
+}
+
+// selectGarbage applies the age and size thresholds
+func selectGarbage(candidates []Garbage, opts GCOptions, now time.Time) []Garbage {
+	cutoff := now.AddDate(0, 0, -opts.Days)
+	var garbage []Garbage
+	for _, item := range candidates {
+		if item.ModTime.After(cutoff) || item.Size < opts.MinSizeMB*1024*1024 {
+			continue
+		}
+		garbage = append(garbage, item)
+	}
+	return garbage
+}
+
+// orphanedBuildDirs returns checkouts in the build directory that no
+// installation uses: those of configured tools that are not installed, such
+// as uninstalled tools and failed builds, and those of renamed tools. A
+// checkout recorded in the manifest is kept, as are checkouts of other
+// projects and of tools the daemon is about to build.
+func (o *Orchestrator) orphanedBuildDirs(m *manifest.InstallationManifest) []Garbage {
+	entries, err := os.ReadDir(toolsBuildDir())
+	if err != nil {
+		return nil
+	}
+
+	recorded := make(map[string]bool)
+	for _, record := range m.Installations {
+		if record.BuildDir != "" {
+			recorded[filepath.Clean(record.BuildDir)] = true
+		}
+	}
+
+	busy := o.busyTools()
+	var garbage []Garbage
+	for _, entry := range entries {
+		path := filepath.Join(toolsBuildDir(), entry.Name())
+		if !entry.IsDir() || recorded[path] {
+			continue
+		}
+
+		var reason string
+		if tool, found := o.findTool(entry.Name()); found {
+			if isToolInstalled(tool) || contains(busy, tool.Name) {
+				continue
+			}
+			reason = fmt.Sprintf("%s is not installed", tool.Name)
+		} else if tool, found := o.toolForCheckout(path); found {
+			if contains(busy, tool.Name) {
+				continue
+			}
+			reason = fmt.Sprintf("checkout of %s under an old name", tool.Name)
+		} else {
+			continue
+		}
+		garbage = append(garbage, Garbage{Kind: GarbageBuildDir, Path: path, Reason: reason, Size: fsutil.DirSize(path), ModTime: modTime(path)})
+	}
+	return garbage
+}
+
+// busyTools returns the tools with a queued or running daemon job, with
+// their bundles expanded
+func (o *Orchestrator) busyTools() []string {
+	if len(o.options.BusyTools) == 0 {
+		return nil
+	}
+	tools, err := o.expandBundlesAndTools(o.options.BusyTools)
+	if err != nil {
+		return o.options.BusyTools
+	}
+	return tools
+}
+
+// toolForCheckout returns the configured tool built from the repository a
+// checkout was cloned from
+func (o *Orchestrator) toolForCheckout(dir string) (ToolConfig, bool) {
+	output, err := exec.Command("git", "-C", dir, "config", "remote.origin.url").Output()
+	if err != nil {
+		return ToolConfig{}, false
+	}
+	url := strings.TrimSpace(string(output))
+	for _, tool := range o.configMgr.GetConfig().Tools {
+		if tool.Repository != "" && tool.Repository == url {
+			return tool, true
+		}
+	}
+	return ToolConfig{}, false
+}
+
+// orphanedRepositories returns the repositories of the source cache that no
+// configured tool is built from
+func (o *Orchestrator) orphanedRepositories() ([]Garbage, error) {
+	repos, err := o.cachedRepositories()
+	if err != nil {
+		return nil, err
+	}
+
+	var garbage []Garbage
+	for _, repo := range repos {
+		if len(repo.Tools) == 0 {
+			garbage = append(garbage, Garbage{Kind: GarbageSourceCache, Path: repo.Path, Reason: "no configured tool uses it", Size: repo.Size, ModTime: repo.LastUsed})
+		}
+	}
+	return garbage, nil
+}
+
+// orphanedBuilds returns the binaries stashed in the build cache below
+// CACHE_DIR/builds for tools that are no longer configured
+func (o *Orchestrator) orphanedBuilds() []Garbage {
+	dir := filepath.Join(CacheDir(), "builds")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var garbage []Garbage
+	for _, entry := range entries {
+		if _, found := o.findTool(entry.Name()); found {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		garbage = append(garbage, Garbage{Kind: GarbageCachedBuild, Path: path, Reason: entry.Name() + " is not configured", Size: fsutil.DirSize(path), ModTime: modTime(path)})
+	}
+	return garbage
+}
+
+// backupCopies returns the copies gearbox kept next to files it replaced:
+// binaries and configuration files of tracked tools, and the rc files
+// 'shell-init --migrate' changed
+func backupCopies(m *manifest.InstallationManifest) []Garbage {
+	var originals []string
+	for _, record := range m.Installations {
+		originals = append(originals, record.BinaryPaths...)
+		originals = append(originals, record.ConfigFiles...)
+	}
+	home, _ := os.UserHomeDir()
+	for _, file := range shellinit.RCFiles(home) {
+		originals = append(originals, file.Path)
+	}
+
+	seen := make(map[string]bool)
+	var garbage []Garbage
+	for _, original := range originals {
+		matches, _ := filepath.Glob(original + ".backup-*")
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err != nil || seen[path] {
+				continue
+			}
+			seen[path] = true
+			garbage = append(garbage, Garbage{Kind: GarbageBackup, Path: path, Reason: "backup of " + original, Size: info.Size(), ModTime: info.ModTime()})
+		}
+	}
+	return garbage
+}
+
+// logFiles returns the logs of the TUI, including its debug builds
+func logFiles() []Garbage {
+	home, _ := os.UserHomeDir()
+	paths, _ := filepath.Glob(filepath.Join(home, ".cache", "gearbox", "*.log"))
+	paths = append(paths, tuiLogFile)
+
+	var garbage []Garbage
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			garbage = append(garbage, Garbage{Kind: GarbageLog, Path: path, Reason: "log file", Size: info.Size(), ModTime: info.ModTime()})
+		}
+	}
+	return garbage
+}
+
+// manifestBackups returns the manifest backups except the newest ones
+func manifestBackups(keep int) []Garbage {
+	home, _ := os.UserHomeDir()
+	dir := filepath.Join(home, manifest.ManifestDir, manifest.BackupDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var garbage []Garbage
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+			garbage = append(garbage, Garbage{Kind: GarbageManifestBackup, Path: filepath.Join(dir, entry.Name()), Size: info.Size(), ModTime: info.ModTime()})
+		}
+	}
+	sort.Slice(garbage, func(i, j int) bool {
+		return garbage[i].ModTime.After(garbage[j].ModTime)
+	})
+	if keep >= len(garbage) {
+		return nil
+	}
+	for i := range garbage[keep:] {
+		garbage[keep+i].Reason = fmt.Sprintf("older than the newest %d backups", keep)
+	}
+	return garbage[keep:]
+}
+
+// CollectGarbage lists the orphans found by FindGarbage with their sizes and
+// removes them after confirmation
+func (o *Orchestrator) CollectGarbage(opts GCOptions) error {
+	garbage, err := o.FindGarbage(opts)
+	if err != nil {
+		return err
+	}
+	if len(garbage) == 0 {
+		fmt.Printf("✅ No garbage older than %d days\n", opts.Days)
+		return nil
+	}
+
+	var total int64
+	for _, kind := range garbageKinds {
+		first := true
+		for _, item := range garbage {
+			if item.Kind != kind {
+				continue
+			}
+			if first {
+				fmt.Printf("\n%s:\n", kind)
+				first = false
+			}
+			fmt.Printf("  %10s  %-12s %s (%s)\n", formatBytes(item.Size), formatAge(item.ModTime), item.Path, item.Reason)
+			total += item.Size
+		}
+	}
+	fmt.Println()
+
+	if opts.DryRun {
+		fmt.Printf("Would free %s from %d items\n", formatBytes(total), len(garbage))
+		return nil
+	}
+	if !opts.Yes {
+		fmt.Printf("Remove %d items, freeing %s? [y/N]: ", len(garbage), formatBytes(total))
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println("Garbage collection cancelled")
+			return nil
+		}
+	}
+
+	var freed int64
+	removed := 0
+	for _, item := range garbage {
+		if err := os.RemoveAll(item.Path); err != nil {
+			fmt.Printf("❌ Failed to remove %s: %v\n", item.Path, err)
+			continue
+		}
+		freed += item.Size
+		removed++
+	}
+	fmt.Printf("✅ Freed %s from %d items\n", formatBytes(freed), removed)
+	if removed < len(garbage) {
+		return fmt.Errorf("failed to remove %d items", len(garbage)-removed)
+	}
+	return nil
+}
+
+// modTime returns when a file or anything below a directory last changed
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	if newest := newestModTime(path); newest.After(info.ModTime()) {
+		return newest
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,149 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gearbox/pkg/manifest"
+)
+
+// ageTree sets the modification time of a file or directory tree
+func ageTree(t *testing.T, root string, when time.Time) {
+	t.Helper()
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err == nil {
+			os.Chtimes(path, when, when)
+		}
+		return nil
+	})
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindGarbageCrossReferencesTheManifest(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GEARBOX_CACHE_DIR", filepath.Join(home, "cache"))
+	old := time.Now().AddDate(0, 0, -30)
+
+	buildDir := filepath.Join(home, "tools", "build")
+	for _, dir := range []string{"gearbox-removed-tool", "gearbox-recorded-tool", "other-project"} {
+		writeFile(t, filepath.Join(buildDir, dir, "README"), dir)
+	}
+	binary := filepath.Join(home, "bin", "recorded")
+	writeFile(t, binary, "new")
+	writeFile(t, binary+".backup-20240101-120000", "old")
+	writeFile(t, filepath.Join(home, ".bashrc.backup-20240101-120000"), "rc")
+	writeFile(t, filepath.Join(home, "cache", "builds", "gearbox-unknown", "bin"), "build")
+
+	m := manifest.NewManifest()
+	m.AddInstallation("gearbox-recorded-tool", &manifest.InstallationRecord{
+		Method:      manifest.MethodSourceBuild,
+		BinaryPaths: []string{binary},
+		BuildDir:    filepath.Join(buildDir, "gearbox-recorded-tool"),
+	})
+	manager := manifest.NewManager()
+	if err := manager.Save(m); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"manifest-20240101-120000.json", "manifest-20240102-120000.json", "manifest-20240103-120000.json"} {
+		writeFile(t, filepath.Join(home, manifest.ManifestDir, manifest.BackupDir, name), "{}")
+	}
+	ageTree(t, home, old)
+	newest := filepath.Join(home, manifest.ManifestDir, manifest.BackupDir, "manifest-20240103-120000.json")
+	os.Chtimes(newest, old.Add(time.Hour), old.Add(time.Hour))
+
+	o := &Orchestrator{configMgr: &ConfigManager{config: Config{
+		Tools: []ToolConfig{
+			{Name: "gearbox-removed-tool", BinaryName: "gearbox-removed-tool"},
+			{Name: "gearbox-recorded-tool", BinaryName: "gearbox-recorded-tool"},
+		},
+	}}}
+	garbage, err := o.FindGarbage(GCOptions{Days: 7, KeepBackups: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := make(map[string]string)
+	for _, item := range garbage {
+		found[item.Path] = item.Kind
+	}
+	// The TUI log is outside HOME
+	delete(found, tuiLogFile)
+	want := map[string]string{
+		filepath.Join(buildDir, "gearbox-removed-tool"):                                                GarbageBuildDir,
+		filepath.Join(home, "cache", "builds", "gearbox-unknown"):                                      GarbageCachedBuild,
+		binary + ".backup-20240101-120000":                                                             GarbageBackup,
+		filepath.Join(home, ".bashrc.backup-20240101-120000"):                                          GarbageBackup,
+		filepath.Join(home, manifest.ManifestDir, manifest.BackupDir, "manifest-20240101-120000.json"): GarbageManifestBackup,
+		filepath.Join(home, manifest.ManifestDir, manifest.BackupDir, "manifest-20240102-120000.json"): GarbageManifestBackup,
+	}
+	for path, kind := range want {
+		if found[path] != kind {
+			t.Errorf("expected %s to be %s garbage, got %q", path, kind, found[path])
+		}
+	}
+	if len(found) != len(want) {
+		t.Errorf("expected %d orphans, got %v", len(want), found)
+	}
+}
+
+func TestFindGarbageKeepsCheckoutsOfQueuedDaemonJobs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GEARBOX_CACHE_DIR", filepath.Join(home, "cache"))
+	buildDir := filepath.Join(home, "tools", "build")
+	for _, dir := range []string{"gearbox-removed-tool", "gearbox-queued-tool"} {
+		writeFile(t, filepath.Join(buildDir, dir, "README"), dir)
+	}
+	ageTree(t, home, time.Now().AddDate(0, 0, -30))
+
+	o := &Orchestrator{
+		options: InstallationOptions{BusyTools: []string{"gearbox-queued-tool"}},
+		configMgr: &ConfigManager{config: Config{Tools: []ToolConfig{
+			{Name: "gearbox-removed-tool", BinaryName: "gearbox-removed-tool"},
+			{Name: "gearbox-queued-tool", BinaryName: "gearbox-queued-tool"},
+		}}},
+	}
+	garbage, err := o.FindGarbage(GCOptions{Days: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buildDirs []string
+	for _, item := range garbage {
+		if item.Kind == GarbageBuildDir {
+			buildDirs = append(buildDirs, item.Path)
+		}
+	}
+	if len(buildDirs) != 1 || buildDirs[0] != filepath.Join(buildDir, "gearbox-removed-tool") {
+		t.Errorf("expected only the checkout of the removed tool, got %v", buildDirs)
+	}
+}
+
+func TestSelectGarbageAppliesAgeAndSizeThresholds(t *testing.T) {
+	now := time.Now()
+	candidates := []Garbage{
+		{Path: "recent", Size: 200 << 20, ModTime: now.AddDate(0, 0, -1)},
+		{Path: "small", Size: 1 << 20, ModTime: now.AddDate(0, 0, -30)},
+		{Path: "old-and-large", Size: 200 << 20, ModTime: now.AddDate(0, 0, -30)},
+	}
+
+	garbage := selectGarbage(candidates, GCOptions{Days: 7, MinSizeMB: 100}, now)
+	if len(garbage) != 1 || garbage[0].Path != "old-and-large" {
+		t.Errorf("expected only the old and large orphan, got %+v", garbage)
+	}
+	if garbage := selectGarbage(candidates, GCOptions{}, now); len(garbage) != 3 {
+		t.Errorf("expected every orphan without thresholds, got %+v", garbage)
+	}
+}
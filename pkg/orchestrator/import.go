@@ -0,0 +1,181 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gearbox/pkg/manifest"
+)
+
+// importPlan groups the tools and bundles of an environment by build type
+type importPlan struct {
+	Groups       map[string][]string // Build type -> tools and bundles to install
+	Dependencies []string            // Tools installed only as dependencies
+	Skipped      []string            // Entries skipped with the reason
+}
+
+// ImportEnvironment shows a plan for an exported environment file and
+// installs its bundles and tools with their recorded build types
+func (o *Orchestrator) ImportEnvironment(path string) error {
+	env, err := manifest.LoadEnvironment(path)
+	if err != nil {
+		return err
+	}
+
+	plan, err := o.buildImportPlan(env)
+	if err != nil {
+		return err
+	}
+
+	o.showImportPlan(env, plan)
+
+	if len(plan.Groups) == 0 {
+		o.reportf("✅ Nothing to install\n")
+		return nil
+	}
+	if o.options.DryRun {
+		return nil
+	}
+
+	var buildTypes []string
+	for buildType := range plan.Groups {
+		buildTypes = append(buildTypes, buildType)
+	}
+	sort.Strings(buildTypes)
+
+	for i, buildType := range buildTypes {
+		o.options.BuildType = buildType
+		o.results = o.results[:0]
+		if i > 0 {
+			// Common dependencies only need to be installed once
+			o.options.SkipCommonDeps = true
+		}
+
+		// Dependencies are installed without being recorded as user-requested
+		if err := o.installTools(plan.Groups[buildType], plan.Dependencies); err != nil {
+			return fmt.Errorf("failed to import %s tools: %w", buildType, err)
+		}
+	}
+
+	return nil
+}
+
+// buildImportPlan decides what to install from an environment. Bundles and
+// user-requested tools are installed directly; dependencies are only added
+// when none of them already provides the tool.
+func (o *Orchestrator) buildImportPlan(env *manifest.Environment) (*importPlan, error) {
+	plan := &importPlan{Groups: make(map[string][]string)}
+	defaultBuildType := o.configMgr.GetConfig().DefaultBuildType
+
+	add := func(entry manifest.EnvironmentEntry, dependency bool) {
+		buildType := entry.BuildType
+		if !isValidBuildType(buildType) {
+			buildType = defaultBuildType
+		}
+		plan.Groups[buildType] = append(plan.Groups[buildType], entry.Name)
+		if dependency {
+			plan.Dependencies = append(plan.Dependencies, entry.Name)
+		}
+	}
+
+	var requested []string
+	for _, bundle := range env.Bundles {
+		if !o.isBundle(bundle.Name, o.bundleConfig.Bundles) {
+			plan.Skipped = append(plan.Skipped, fmt.Sprintf("%s (unknown bundle)", bundle.Name))
+			continue
+		}
+		if !o.options.Force && o.bundleInstalled(bundle.Name) {
+			plan.Skipped = append(plan.Skipped, fmt.Sprintf("%s (already installed)", bundle.Name))
+			continue
+		}
+		requested = append(requested, bundle.Name)
+		add(bundle, false)
+	}
+
+	// Tools pulled in by the requested bundles
+	covered, err := o.expandBundlesAndTools(requested)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand bundles: %w", err)
+	}
+
+	for i, entries := range [][]manifest.EnvironmentEntry{env.Tools, env.Dependencies} {
+		for _, entry := range entries {
+			tool, found := o.findTool(entry.Name)
+			if !found {
+				plan.Skipped = append(plan.Skipped, fmt.Sprintf("%s (unknown tool)", entry.Name))
+				continue
+			}
+			if contains(covered, entry.Name) {
+				continue
+			}
+			if !o.options.Force && isToolInstalled(tool) {
+				plan.Skipped = append(plan.Skipped, fmt.Sprintf("%s (already installed)", entry.Name))
+				continue
+			}
+			covered = append(covered, entry.Name)
+			add(entry, i == 1)
+		}
+	}
+
+	return plan, nil
+}
+
+// bundleInstalled reports whether every tool in a bundle is already installed
+func (o *Orchestrator) bundleInstalled(bundleName string) bool {
+	tools, err := o.expandBundlesAndTools([]string{bundleName})
+	if err != nil {
+		return false
+	}
+
+	for _, name := range tools {
+		tool, found := o.findTool(name)
+		if !found || !isToolInstalled(tool) {
+			return false
+		}
+	}
+	return true
+}
+
+// showImportPlan displays what an import will install
+func (o *Orchestrator) showImportPlan(env *manifest.Environment, plan *importPlan) {
+	o.reportf("📥 Import Plan\n")
+	o.reportf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	if env.Hostname != "" {
+		o.reportf("Exported from: %s (%s)\n", env.Hostname, env.ExportedAt.Format("2006-01-02 15:04"))
+	}
+	o.reportf("Bundles: %d, Tools: %d, Dependencies: %d\n\n", len(env.Bundles), len(env.Tools), len(env.Dependencies))
+
+	versions := make(map[string]string)
+	for _, entry := range append(env.Tools, env.Dependencies...) {
+		if entry.Version != "" {
+			versions[entry.Name] = entry.Version
+		}
+	}
+
+	var buildTypes []string
+	for buildType := range plan.Groups {
+		buildTypes = append(buildTypes, buildType)
+	}
+	sort.Strings(buildTypes)
+
+	for _, buildType := range buildTypes {
+		var names []string
+		for _, name := range plan.Groups[buildType] {
+			if version := versions[name]; version != "" {
+				name = fmt.Sprintf("%s %s", name, version)
+			}
+			names = append(names, name)
+		}
+		o.reportf("📦 %s (%d): %s\n", strings.Title(buildType), len(names), strings.Join(names, ", "))
+	}
+
+	if len(versions) > 0 {
+		o.reportf("Versions are those exported; the install scripts determine what gets installed\n")
+	}
+
+	if len(plan.Skipped) > 0 {
+		o.reportf("\n⏭️  Skipped: %s\n", strings.Join(plan.Skipped, ", "))
+	}
+	o.reportf("\n")
+}
@@ -0,0 +1,125 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gearbox/pkg/manifest"
+)
+
+func TestBuildImportPlan(t *testing.T) {
+	o := newRepoOrchestrator(t, InstallationOptions{Force: true})
+
+	env := &manifest.Environment{
+		SchemaVersion: manifest.EnvironmentSchemaVersion,
+		Bundles: []manifest.EnvironmentEntry{
+			{Name: "minimal", Method: manifest.MethodBundle},
+			{Name: "no-such-bundle", Method: manifest.MethodBundle},
+		},
+		Tools: []manifest.EnvironmentEntry{
+			{Name: "fd", BuildType: "maximum"},
+			{Name: "bat", BuildType: "maximum"},
+		},
+		Dependencies: []manifest.EnvironmentEntry{
+			{Name: "no-such-tool"},
+			{Name: "jq", BuildType: "maximum"},
+		},
+	}
+
+	plan, err := o.buildImportPlan(env)
+	if err != nil {
+		t.Fatalf("failed to build import plan: %v", err)
+	}
+
+	expected := map[string][]string{
+		"standard": {"minimal"},
+		"maximum":  {"bat", "jq"},
+	}
+	if !reflect.DeepEqual(plan.Groups, expected) {
+		t.Errorf("expected groups %v, got %v", expected, plan.Groups)
+	}
+
+	// Dependencies are installed without being recorded as user-requested
+	if expected := []string{"jq"}; !reflect.DeepEqual(plan.Dependencies, expected) {
+		t.Errorf("expected dependencies %v, got %v", expected, plan.Dependencies)
+	}
+
+	// fd is provided by the minimal bundle and is not reported as skipped
+	expectedSkipped := []string{"no-such-bundle (unknown bundle)", "no-such-tool (unknown tool)"}
+	if !reflect.DeepEqual(plan.Skipped, expectedSkipped) {
+		t.Errorf("expected skipped %v, got %v", expectedSkipped, plan.Skipped)
+	}
+}
+
+func TestImportReportsThePlanInsteadOfPrintingIt(t *testing.T) {
+	o := newRepoOrchestrator(t, InstallationOptions{Force: true, DryRun: true})
+	var messages []string
+	o.reporter = ReporterFunc(func(event Event) {
+		if event.Type == EventMessage {
+			messages = append(messages, event.Message)
+		}
+	})
+
+	data, err := json.Marshal(&manifest.Environment{
+		SchemaVersion: manifest.EnvironmentSchemaVersion,
+		Tools:         []manifest.EnvironmentEntry{{Name: "bat", BuildType: "maximum"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "environment.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := o.ImportEnvironment(path); err != nil {
+		t.Fatal(err)
+	}
+	report := strings.Join(messages, "")
+	if !strings.Contains(report, "Import Plan") || !strings.Contains(report, "Maximum (1): bat") {
+		t.Errorf("expected the plan to be reported, got %q", report)
+	}
+}
+
+func TestInstalledBundleRoundTripsThroughExport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	o := newRepoOrchestrator(t, InstallationOptions{BuildType: "maximum", Force: true})
+
+	tools, err := o.expandBundlesAndTools([]string{"minimal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker, err := manifest.NewTracker()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range tools {
+		config := manifest.TrackingConfig{Method: manifest.MethodSourceBuild, Version: "1.0.0", BuildType: "maximum", InstalledByBundle: "minimal"}
+		if err := tracker.TrackInstallation(name, config); err != nil {
+			t.Fatal(err)
+		}
+	}
+	o.recordBundles(map[string][]string{"minimal": tools})
+
+	m, err := manifest.NewManager().Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := manifest.ExportEnvironment(m)
+	if len(env.Bundles) != 1 || env.Bundles[0].Name != "minimal" || env.Bundles[0].BuildType != "maximum" {
+		t.Fatalf("expected the bundle with its build type, got %+v", env.Bundles)
+	}
+
+	plan, err := o.buildImportPlan(env)
+	if err != nil {
+		t.Fatalf("failed to build import plan: %v", err)
+	}
+	// The bundle covers its tools, which are not installed one by one
+	if expected := map[string][]string{"maximum": {"minimal"}}; !reflect.DeepEqual(plan.Groups, expected) {
+		t.Errorf("expected groups %v, got %v", expected, plan.Groups)
+	}
+}
@@ -8,14 +8,18 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
-	"sync"
+	"syscall"
 	"time"
-
-	"github.com/schollz/progressbar/v3"
 )
 
 // InstallTools orchestrates the installation of specified tools
 func (o *Orchestrator) InstallTools(toolNames []string) error {
+	return o.installTools(toolNames, nil)
+}
+
+// installTools installs tools and bundles; the tools listed in dependencies
+// are not recorded as user-requested
+func (o *Orchestrator) installTools(toolNames, dependencies []string) error {
 	// Track which tools come from which bundles for progress display
 	bundleToolMap := make(map[string][]string)
 	var directTools []string
@@ -39,33 +43,6 @@ func (o *Orchestrator) InstallTools(toolNames []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to expand bundles: %w", err)
 	}
-	
-	// Show installation header with bundle context
-	if len(bundleToolMap) > 0 {
-		fmt.Printf("🔧 Gearbox Orchestrator - Installing %d tools", len(expandedToolNames))
-		if len(bundleToolMap) == 1 {
-			for bundleName := range bundleToolMap {
-				fmt.Printf(" (bundle: %s)", bundleName)
-			}
-		} else {
-			fmt.Printf(" (from %d bundles)", len(bundleToolMap))
-		}
-		if len(directTools) > 0 {
-			fmt.Printf(" + %d direct tools", len(directTools))
-		}
-		fmt.Printf("\n\n")
-		
-		// Show bundle breakdown
-		for bundleName, tools := range bundleToolMap {
-			fmt.Printf("📦 Bundle '%s': %d tools\n", bundleName, len(tools))
-		}
-		if len(directTools) > 0 {
-			fmt.Printf("🔧 Direct tools: %d\n", len(directTools))
-		}
-		fmt.Printf("\n")
-	} else {
-		fmt.Printf("🔧 Gearbox Orchestrator - Installing %d tools\n\n", len(expandedToolNames))
-	}
 
 	// Validate tool names
 	var validTools []ToolConfig
@@ -80,25 +57,40 @@ func (o *Orchestrator) InstallTools(toolNames []string) error {
 	// Handle nerd-fonts specific options
 	if len(validTools) == 1 && validTools[0].Name == "nerd-fonts" {
 		if o.options.Fonts != "" || o.options.Interactive || o.options.Preview || o.options.ConfigureApps {
+			o.reportf("🔧 Gearbox Orchestrator - Installing %d tools\n\n", len(validTools))
 			return o.installNerdFontsWithOptions(validTools[0])
 		}
 	}
 
-	// Check for cross-tool recommendations
-	o.suggestRelatedTools(validTools)
-
 	// Resolve dependencies and determine installation order
 	installOrder, err := o.resolveDependencies(validTools)
 	if err != nil {
 		return fmt.Errorf("dependency resolution failed: %w", err)
 	}
 
+	plan := o.installationPlan(installOrder, toolNames)
+	if len(bundleToolMap) > 0 {
+		plan.Bundles = bundleToolMap
+		plan.DirectTools = directTools
+	}
+	plan.Suggestions = o.suggestRelatedTools(validTools)
+	o.report(Event{Type: EventPlan, Plan: plan})
+
+	if o.mirror != nil {
+		if err := o.prepareOfflineInstall(installOrder); err != nil {
+			return err
+		}
+	}
+
 	if o.options.DryRun {
-		return o.showDryRun(installOrder, toolNames)
+		return nil
 	}
 
-	// Show installation plan
-	o.showInstallationPlan(installOrder)
+	if !o.options.SkipDiskCheck {
+		if err := o.checkDiskSpace(installOrder); err != nil {
+			return err
+		}
+	}
 
 	// Install system packages first (if any)
 	if err := o.installSystemPackagesFromBundles(toolNames); err != nil {
@@ -107,34 +99,40 @@ func (o *Orchestrator) InstallTools(toolNames []string) error {
 
 	// Install common dependencies first (unless skipped)
 	if !o.options.SkipCommonDeps {
-		fmt.Printf("📦 Installing common dependencies...\n")
-		if err := o.installCommonDependencies(); err != nil {
+		o.reportf("📦 Installing common dependencies...\n")
+		if err := o.installCommonDependencies(installOrder); err != nil {
 			return fmt.Errorf("failed to install common dependencies: %w", err)
 		}
-		fmt.Printf("✅ Common dependencies installed\n\n")
+		o.reportf("✅ Common dependencies installed\n\n")
+	}
+
+	// Fetch sources once into the shared cache
+	if o.sourceCache != nil {
+		o.updateSourceCache(installOrder)
 	}
 
 	// Execute installations with progress tracking
-	fmt.Printf("🚀 Starting installations...\n")
-	o.progressBar = progressbar.NewOptions(len(installOrder),
-		progressbar.OptionSetDescription("Installing tools"),
-		progressbar.OptionSetWidth(50),
-		progressbar.OptionShowCount(),
-		progressbar.OptionShowIts(),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "█",
-			SaucerHead:    "█",
-			SaucerPadding: "░",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}))
-
-	if err := o.executeInstallations(installOrder); err != nil {
-		return err
+	o.reportf("🚀 Starting installations...\n")
+	err = o.executeInstallations(installOrder)
+	var requested []string
+	for _, name := range directTools {
+		if !contains(dependencies, name) {
+			requested = append(requested, name)
+		}
+	}
+	o.recordInstallations(requested, bundleToolMap)
+	o.recordBundles(bundleToolMap)
+
+	// Keep the measured disk usage for future estimates
+	if o.buildStats != nil {
+		if saveErr := o.buildStats.save(); saveErr != nil && o.options.Verbose {
+			o.reportf("⚠️  Failed to save build statistics: %v\n", saveErr)
+		}
 	}
 
 	// Show results
-	return o.showResults()
+	o.report(Event{Type: EventSummary, Summary: o.summarizeResults()})
+	return err
 }
 
 // installSystemPackagesFromBundles installs system packages for any bundles in the tool list
@@ -172,12 +170,12 @@ func (o *Orchestrator) installSystemPackagesFromBundles(toolNames []string) erro
 	}
 	
 	if o.options.DryRun {
-		fmt.Printf("📦 System packages that would be installed (%s): %s\n\n", o.packageMgr.Name, strings.Join(uniquePackages, ", "))
+		o.reportf("📦 System packages that would be installed (%s): %s\n\n", o.packageMgr.Name, strings.Join(uniquePackages, ", "))
 		return nil
 	}
 	
 	// Install system packages
-	return o.packageMgr.installPackages(uniquePackages, o.options.DryRun)
+	return o.installPackages(uniquePackages)
 }
 
 // resolveDependencies resolves dependencies and determines optimal installation order
@@ -222,39 +220,47 @@ func (o *Orchestrator) resolveDependencies(tools []ToolConfig) ([]ToolConfig, er
 	return installOrder, nil
 }
 
-// showDryRun displays what would be installed without executing
-func (o *Orchestrator) showDryRun(tools []ToolConfig, originalToolNames []string) error {
-	fmt.Printf("🔍 Dry Run - Installation Plan\n\n")
-	fmt.Printf("Build Type: %s\n", o.options.BuildType)
-	fmt.Printf("Max Parallel Jobs: %d\n", o.options.MaxParallelJobs)
-	fmt.Printf("Skip Common Deps: %v\n", o.options.SkipCommonDeps)
-	fmt.Printf("Run Tests: %v\n", o.options.RunTests)
-	fmt.Printf("Shell Integration: %v\n", !o.options.NoShell)
-	
-	// Show system packages if any
-	o.showSystemPackagesPlan(originalToolNames)
-	
-	fmt.Printf("\nInstallation Order:\n")
+// installationPlan describes the installation of the tools in the given
+// order. Dry runs also compare the build types.
+func (o *Orchestrator) installationPlan(tools []ToolConfig, originalToolNames []string) *InstallPlan {
+	scheduler := o.newBuildScheduler()
+	plan := &InstallPlan{
+		DryRun:           o.options.DryRun,
+		BuildType:        o.options.BuildType,
+		ParallelJobs:     o.options.MaxParallelJobs,
+		MemoryMB:         int64(scheduler.memoryMB),
+		CPUs:             scheduler.cpus,
+		SkipCommonDeps:   o.options.SkipCommonDeps,
+		RunTests:         o.options.RunTests,
+		ShellIntegration: !o.options.NoShell,
+		Tools:            []PlannedTool{},
+	}
 
-	for i, tool := range tools {
-		buildFlag := tool.BuildTypes[o.options.BuildType]
-		if buildFlag == "" {
-			buildFlag = "(default)"
-		}
-		fmt.Printf("  %2d. %-15s (%s) - Build flag: %s\n", 
-			i+1, tool.Name, tool.Language, buildFlag)
+	if o.packageMgr != nil {
+		plan.PackageManager = o.packageMgr.Name
+		plan.SystemPackages = o.bundleSystemPackages(originalToolNames)
 	}
 
-	fmt.Printf("\nTotal tools to install: %d\n", len(tools))
-	return nil
+	for _, tool := range tools {
+		estimate, _ := o.estimateDisk(tool)
+		duration, _ := o.EstimateBuildTime(tool, o.options.BuildType, 1)
+		plan.Tools = append(plan.Tools, PlannedTool{
+			Name:             tool.Name,
+			Language:         tool.Language,
+			BuildFlag:        tool.BuildTypes[o.options.BuildType],
+			DiskMB:           estimate.SourceMB + estimate.BuildMB + estimate.InstallMB,
+			EstimatedSeconds: duration.Seconds(),
+		})
+	}
+
+	o.planDiskSpace(plan, tools)
+	o.planTime(plan, tools, o.options.DryRun)
+	return plan
 }
 
-// showSystemPackagesPlan displays system packages that would be installed in dry-run mode
-func (o *Orchestrator) showSystemPackagesPlan(toolNames []string) {
-	if o.packageMgr == nil {
-		return
-	}
-	
+// bundleSystemPackages returns the system packages of the bundles among the
+// tool names without duplicates
+func (o *Orchestrator) bundleSystemPackages(toolNames []string) []string {
 	var allSystemPackages []string
 	
 	// Check each tool name to see if it's a bundle with system packages
@@ -268,111 +274,164 @@ func (o *Orchestrator) showSystemPackagesPlan(toolNames []string) {
 		}
 	}
 	
-	if len(allSystemPackages) > 0 {
-		// Remove duplicates
-		seen := make(map[string]bool)
-		var uniquePackages []string
-		for _, pkg := range allSystemPackages {
-			if !seen[pkg] {
-				seen[pkg] = true
-				uniquePackages = append(uniquePackages, pkg)
-			}
-		}
-		
-		fmt.Printf("\nSystem packages that would be installed (%s):\n", o.packageMgr.Name)
-		for _, pkg := range uniquePackages {
-			fmt.Printf("  - %s\n", pkg)
-		}
-	}
-}
-
-// showInstallationPlan displays the installation plan
-func (o *Orchestrator) showInstallationPlan(tools []ToolConfig) {
-	fmt.Printf("📋 Installation Plan\n")
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	fmt.Printf("Build Type: %s\n", o.options.BuildType)
-	fmt.Printf("Parallel Jobs: %d\n", o.options.MaxParallelJobs)
-	fmt.Printf("Total Tools: %d\n\n", len(tools))
-
-	// Group by language for display
-	languageGroups := make(map[string][]ToolConfig)
-	for _, tool := range tools {
-		languageGroups[tool.Language] = append(languageGroups[tool.Language], tool)
-	}
-
-	for lang, langTools := range languageGroups {
-		fmt.Printf("📦 %s (%d tools): ", strings.Title(lang), len(langTools))
-		var names []string
-		for _, tool := range langTools {
-			names = append(names, tool.Name)
+	// Remove duplicates
+	seen := make(map[string]bool)
+	var uniquePackages []string
+	for _, pkg := range allSystemPackages {
+		if !seen[pkg] {
+			seen[pkg] = true
+			uniquePackages = append(uniquePackages, pkg)
 		}
-		fmt.Printf("%s\n", strings.Join(names, ", "))
 	}
-	fmt.Println()
+	return uniquePackages
 }
 
-// installCommonDependencies installs common dependencies
-func (o *Orchestrator) installCommonDependencies() error {
+// installCommonDependencies installs common dependencies and the system
+// packages the given tools depend on
+func (o *Orchestrator) installCommonDependencies(tools []ToolConfig) error {
 	commonDepsScript := filepath.Join(o.scriptsDir, "installation", "common", "install-common-deps.sh")
 	
 	if _, err := os.Stat(commonDepsScript); os.IsNotExist(err) {
 		return fmt.Errorf("common dependencies script not found: %s", commonDepsScript)
 	}
 
-	cmd := exec.Command("bash", commonDepsScript)
-	cmd.Dir = o.repoDir
-	
-	if o.options.Verbose {
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+	var args []string
+	if o.packageMgr != nil && o.packageMapping != nil && len(o.packageMapping.CommonDependencies) > 0 {
+		// Install system packages through the detected package manager so the
+		// script only has to set up the language toolchains
+		canonical := append([]string{}, o.packageMapping.CommonDependencies...)
+		for _, tool := range tools {
+			canonical = append(canonical, o.packageMapping.SystemDependencies(tool.Dependencies)...)
+		}
+
+		packages := o.packageMapping.Translate(canonical, o.packageMgr.Name)
+		if err := o.installPackages(packages); err != nil {
+			return err
+		}
+		args = append(args, "--skip-system-packages")
 	}
 
+	cmd := o.scriptCommand(append([]string{commonDepsScript}, args...)...)
+	cmd.Dir = o.repoDir
+	cmd.Env = append(os.Environ(), o.scriptEnv()...)
+
+	// Report the output like that of installation scripts, which the text
+	// output only shows when verbose
+	lines := &lineReporter{o: o, tool: "common-deps"}
+	defer lines.Flush()
+	cmd.Stdout = lines
+	cmd.Stderr = lines
+
 	return cmd.Run()
 }
 
-// executeInstallations executes tool installations with parallel processing
+// executeInstallations executes tool installations in parallel. Builds start
+// in installation order as soon as the memory and cores they need are free.
 func (o *Orchestrator) executeInstallations(tools []ToolConfig) error {
-	semaphore := make(chan struct{}, o.options.MaxParallelJobs)
-	var wg sync.WaitGroup
-	errorChan := make(chan error, len(tools))
+	scheduler := o.newBuildScheduler()
 
-	for _, tool := range tools {
-		wg.Add(1)
-		go func(t ToolConfig) {
-			defer wg.Done()
-			
-			// Acquire semaphore
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
+	stopMonitor := o.monitorDiskSpace()
+	defer close(stopMonitor)
 
-			result := o.installTool(t)
-			
-			o.mu.Lock()
-			o.results = append(o.results, result)
-			o.mu.Unlock()
+	type finishedBuild struct {
+		result     InstallationResult
+		allocation buildAllocation
+	}
+	type activeBuild struct {
+		started    time.Time
+		estimate   time.Duration
+		allocation buildAllocation
+	}
+	finished := make(chan finishedBuild)
+	active := make(map[string]activeBuild)
+	var errors []error
 
-			if result.Error != nil {
-				errorChan <- fmt.Errorf("failed to install %s: %w", t.Name, result.Error)
+	pending := tools
+	running, completed := 0, 0
+	for len(pending) > 0 || running > 0 {
+		var waiting []ToolConfig
+		for _, tool := range pending {
+			skipped := ""
+			if o.diskSpaceLow.Load() {
+				skipped = "skipped: not enough disk space"
+			} else if o.ctx != nil && o.ctx.Err() != nil {
+				skipped = "skipped: installation cancelled"
+			}
+			if skipped != "" {
+				result := InstallationResult{
+					Tool:  tool,
+					Error: fmt.Errorf("%s", skipped),
+				}
+				o.mu.Lock()
+				o.results = append(o.results, result)
+				o.mu.Unlock()
+				errors = append(errors, fmt.Errorf("failed to install %s: %s", tool.Name, skipped))
+				o.report(Event{Type: EventToolFinished, Tool: tool.Name, Result: &ToolResult{Tool: tool.Name, Error: result.Error.Error()}})
+				completed++
+				continue
 			}
 
-			// Update progress
-			o.progressBar.Add(1)
-		}(tool)
-	}
+			allocation, ok := scheduler.reserve(o.resourceWeight(tool, o.options.BuildType))
+			if !ok {
+				waiting = append(waiting, tool)
+				continue
+			}
+
+			running++
+			estimate, _ := o.estimateDuration(tool, o.options.BuildType, allocation.Jobs)
+			o.report(Event{Type: EventToolStarted, Tool: tool.Name, Build: &BuildStart{
+				Jobs:             allocation.Jobs,
+				MemoryMB:         allocation.MemoryMB,
+				EstimatedSeconds: estimate.Seconds(),
+			}})
+			active[tool.Name] = activeBuild{time.Now(), estimate, allocation}
+			go func(t ToolConfig, allocation buildAllocation) {
+				finished <- finishedBuild{o.installTool(t, allocation.Jobs), allocation}
+			}(tool, allocation)
+		}
+		pending = waiting
 
-	wg.Wait()
-	close(errorChan)
+		if running == 0 {
+			continue
+		}
 
-	// Check for errors
-	var errors []error
-	for err := range errorChan {
-		errors = append(errors, err)
+		// Estimate the remaining time from the builds still to come and
+		// the expected rest of the running ones
+		var planned []plannedBuild
+		for _, build := range active {
+			remaining := build.estimate - time.Since(build.started)
+			if remaining < 0 {
+				remaining = 0
+			}
+			planned = append(planned, plannedBuild{remaining, build.allocation})
+		}
+		eta := o.estimateSchedule(o.newBuildScheduler(), pending, o.options.BuildType, planned)
+		o.report(Event{Type: EventProgress, Progress: &Progress{Completed: completed, Total: len(tools), ETASeconds: eta.Seconds()}})
+
+		build := <-finished
+		running--
+		delete(active, build.result.Tool.Name)
+		scheduler.release(build.allocation)
+
+		o.mu.Lock()
+		o.results = append(o.results, build.result)
+		o.mu.Unlock()
+
+		if build.result.Error != nil {
+			errors = append(errors, fmt.Errorf("failed to install %s: %w", build.result.Tool.Name, build.result.Error))
+		}
+
+		result := toolResult(build.result)
+		o.report(Event{Type: EventToolFinished, Tool: result.Tool, Result: &result})
+		completed++
 	}
+	o.report(Event{Type: EventProgress, Progress: &Progress{Completed: completed, Total: len(tools)}})
 
+	// Check for errors
 	if len(errors) > 0 {
-		fmt.Printf("\n❌ Installation completed with %d errors:\n", len(errors))
+		o.reportf("\n❌ Installation completed with %d errors:\n", len(errors))
 		for _, err := range errors {
-			fmt.Printf("  • %v\n", err)
+			o.reportf("  • %v\n", err)
 		}
 		return fmt.Errorf("%d tools failed to install", len(errors))
 	}
@@ -400,26 +459,26 @@ func (o *Orchestrator) findToolScript(toolName string) string {
 	return fallbackPath
 }
 
-// installTool installs a single tool
-func (o *Orchestrator) installTool(tool ToolConfig) InstallationResult {
-	start := time.Now()
-	
-	// Find the script in the appropriate category directory
-	scriptPath := o.findToolScript(tool.Name)
-	
-	// Check if script exists
-	if _, err := os.Stat(scriptPath); os.IsNotExist(err) {
-		return InstallationResult{
-			Tool:     tool,
-			Success:  false,
-			Error:    fmt.Errorf("installation script not found: %s", scriptPath),
-			Duration: time.Since(start),
-		}
+// scriptCommand returns a command that runs a script with bash. With a
+// context, the script runs in its own process group, so that cancelling the
+// context stops the builds it started as well.
+func (o *Orchestrator) scriptCommand(args ...string) *exec.Cmd {
+	if o.ctx == nil {
+		return exec.Command("bash", args...)
 	}
 
-	// Build command arguments using standard protocol
+	cmd := exec.CommandContext(o.ctx, "bash", args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+	cmd.WaitDelay = 10 * time.Second
+	return cmd
+}
+
+// scriptFlags returns the standard script protocol flags for installing a tool
+func (o *Orchestrator) scriptFlags(tool ToolConfig) []string {
 	var args []string
-	args = append(args, scriptPath)
 
 	// Add standardized build type flag
 	switch o.options.BuildType {
@@ -454,11 +513,34 @@ func (o *Orchestrator) installTool(tool ToolConfig) InstallationResult {
 		args = append(args, "--dry-run")
 	}
 
+	return args
+}
+
+// installTool installs a single tool
+func (o *Orchestrator) installTool(tool ToolConfig, jobs int) InstallationResult {
+	start := time.Now()
+	
+	// Find the script in the appropriate category directory
+	scriptPath := o.findToolScript(tool.Name)
+	
+	// Check if script exists
+	if _, err := os.Stat(scriptPath); os.IsNotExist(err) {
+		return InstallationResult{
+			Tool:     tool,
+			Success:  false,
+			Error:    fmt.Errorf("installation script not found: %s", scriptPath),
+			Duration: time.Since(start),
+		}
+	}
+
+	// Build command arguments using standard protocol
+	args := append([]string{scriptPath}, o.scriptFlags(tool)...)
+
 	// Execute installation
-	cmd := exec.Command("bash", args...)
+	cmd := o.scriptCommand(args...)
 	
 	// Set working directory to build directory (~/tools/build)
-	buildDir := os.ExpandEnv("$HOME/tools/build")
+	buildDir := toolsBuildDir()
 	if err := os.MkdirAll(buildDir, 0755); err != nil {
 		return InstallationResult{
 			Tool:     tool,
@@ -468,20 +550,22 @@ func (o *Orchestrator) installTool(tool ToolConfig) InstallationResult {
 		}
 	}
 	cmd.Dir = buildDir
+	cmd.Env = append(append(os.Environ(), o.toolEnv(tool)...), buildJobsEnv(jobs)...)
 
+	// Keep the output for the result and report it line by line
 	var output strings.Builder
-	if o.options.Verbose {
-		cmd.Stdout = io.MultiWriter(os.Stdout, &output)
-		cmd.Stderr = io.MultiWriter(os.Stderr, &output)
-	} else {
-		cmd.Stdout = &output
-		cmd.Stderr = &output
-	}
+	lines := &lineReporter{o: o, tool: tool.Name}
+	defer lines.Flush()
+	cmd.Stdout = io.MultiWriter(&output, lines)
+	cmd.Stderr = cmd.Stdout
 
 	// Provide automatic "yes" responses to avoid interactive prompts
 	cmd.Stdin = strings.NewReader("y\ny\ny\ny\ny\ny\ny\ny\ny\ny\n")
 
 	err := cmd.Run()
+	if err == nil {
+		o.recordBuild(tool, peakMemoryMB(cmd), jobs, time.Since(start))
+	}
 	
 	return InstallationResult{
 		Tool:     tool,
@@ -492,13 +576,10 @@ func (o *Orchestrator) installTool(tool ToolConfig) InstallationResult {
 	}
 }
 
-// showResults displays installation results
-func (o *Orchestrator) showResults() error {
-	fmt.Printf("\n\n📊 Installation Results\n")
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-
-	var successful, failed int
-	var totalDuration time.Duration
+// summarizeResults counts the installation results, successful ones first
+func (o *Orchestrator) summarizeResults() *InstallSummary {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 
 	// Sort results by success status (successful first)
 	sort.Slice(o.results, func(i, j int) bool {
@@ -508,40 +589,21 @@ func (o *Orchestrator) showResults() error {
 		return o.results[i].Tool.Name < o.results[j].Tool.Name
 	})
 
+	summary := &InstallSummary{Results: []ToolResult{}}
 	for _, result := range o.results {
-		totalDuration += result.Duration
-		
+		summary.TotalSeconds += result.Duration.Seconds()
 		if result.Success {
-			successful++
-			fmt.Printf("✅ %-15s (%6.1fs) - %s\n", 
-				result.Tool.Name, 
-				result.Duration.Seconds(),
-				result.Tool.Description)
+			summary.Successful++
 		} else {
-			failed++
-			fmt.Printf("❌ %-15s (%6.1fs) - %v\n", 
-				result.Tool.Name, 
-				result.Duration.Seconds(),
-				result.Error)
+			summary.Failed++
 		}
+		summary.Results = append(summary.Results, toolResult(result))
 	}
-
-	fmt.Printf("\n📈 Summary\n")
-	fmt.Printf("Successful: %d\n", successful)
-	fmt.Printf("Failed: %d\n", failed)
-	fmt.Printf("Total Duration: %.1fs\n", totalDuration.Seconds())
-	fmt.Printf("Average Duration: %.1fs\n", totalDuration.Seconds()/float64(len(o.results)))
-
-	if failed == 0 {
-		fmt.Printf("\n🎉 All tools installed successfully!\n")
-		return nil
-	} else {
-		return fmt.Errorf("%d tools failed to install", failed)
-	}
+	return summary
 }
 
 // suggestRelatedTools analyzes the tools being installed and suggests related tools
-func (o *Orchestrator) suggestRelatedTools(tools []ToolConfig) {
+func (o *Orchestrator) suggestRelatedTools(tools []ToolConfig) []string {
 	var suggestions []string
 	var installingNames []string
 	
@@ -610,13 +672,5 @@ func (o *Orchestrator) suggestRelatedTools(tools []ToolConfig) {
 		}
 	}
 	
-	// Show suggestions if any
-	if len(suggestions) > 0 {
-		fmt.Printf("\n💡 Related Tool Suggestions\n")
-		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-		for _, suggestion := range suggestions {
-			fmt.Printf("   %s\n", suggestion)
-		}
-		fmt.Printf("\n   Use: gearbox install <additional_tools>\n\n")
-	}
+	return suggestions
 }
\ No newline at end of file
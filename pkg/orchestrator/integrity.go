@@ -0,0 +1,179 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"gearbox/pkg/manifest"
+	"gearbox/pkg/testreport"
+)
+
+// ToolDrift returns how a tracked installation differs from its record: its
+// binaries against the recorded checksums and PATH, and the version the tool
+// reports against the recorded version. full hashes every binary, see
+// manifest.CheckFiles.
+func (o *Orchestrator) ToolDrift(name string, record *manifest.InstallationRecord, full bool) []manifest.Drift {
+	if record.Method == manifest.MethodBundle {
+		return nil
+	}
+	drifts := manifest.CheckFiles(name, record, exec.LookPath, full)
+
+	tool, found := o.findTool(name)
+	if !found || !isToolInstalled(tool) {
+		return drifts
+	}
+	recorded := extractVersionFromOutput(record.Version)
+	if !knownVersion(recorded) {
+		return drifts
+	}
+	if live := getToolVersion(tool); knownVersion(live) && live != recorded {
+		drifts = append(drifts, manifest.Drift{
+			Tool:   name,
+			Kind:   manifest.DriftVersion,
+			Detail: fmt.Sprintf("%s, recorded %s", live, recorded),
+		})
+	}
+	return drifts
+}
+
+// VerifyIntegrity hashes the binaries of tracked tools and compares them,
+// their place on PATH and their versions with the manifest. It returns a test
+// case per tool.
+func (o *Orchestrator) VerifyIntegrity(toolNames []string) (testreport.Suite, error) {
+	m, names, err := trackedTools(toolNames)
+	if err != nil {
+		return testreport.Suite{}, err
+	}
+
+	o.reportf("🔐 Verifying Installation Integrity\n")
+	o.reportf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+
+	suite := testreport.Suite{Name: "verify-integrity", Timestamp: time.Now()}
+	if len(names) == 0 {
+		o.reportf("No installations tracked\n")
+		return suite, nil
+	}
+
+	var intact, drifted, unrecorded int
+	for _, name := range names {
+		start := time.Now()
+		record := m.Installations[name]
+		drifts := o.ToolDrift(name, record, true)
+		testCase := testreport.Case{Name: name}
+		if len(drifts) == 0 {
+			intact++
+			if len(record.Files) == 0 {
+				unrecorded++
+				o.reportf("✅ %-15s no checksums recorded\n", name)
+			} else {
+				o.reportf("✅ %-15s %d files match their checksums\n", name, len(record.Files))
+			}
+		} else {
+			drifted++
+			testCase.Failure = o.showDrifts(name, drifts)
+		}
+		testCase.Duration = time.Since(start)
+		suite.Add(testCase)
+	}
+
+	o.reportf("\n📈 Integrity Summary: %d intact, %d drifted\n", intact, drifted)
+	if unrecorded > 0 {
+		o.reportf("💡 %d tools were installed before checksums were recorded; reinstall them with --force to record checksums\n", unrecorded)
+	}
+	if drifted > 0 {
+		o.reportf("💡 Reinstall missing or modified binaries with 'gearbox install --force TOOL'\n")
+		return suite, fmt.Errorf("%d tools drifted from the manifest", drifted)
+	}
+	return suite, nil
+}
+
+// ShowDrift reports the tracked tools that differ from the manifest. Only
+// binaries whose modification time changed are hashed, which keeps it fast
+// enough for every status check.
+func (o *Orchestrator) ShowDrift(toolNames []string) error {
+	m, names, err := trackedTools(toolNames)
+	if err != nil {
+		return err
+	}
+
+	o.reportf("📊 Drift Between Manifest and System\n")
+	o.reportf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	if len(names) == 0 {
+		o.reportf("No installations tracked\n")
+		return nil
+	}
+
+	drifted := 0
+	for _, name := range names {
+		if drifts := o.ToolDrift(name, m.Installations[name], false); len(drifts) > 0 {
+			drifted++
+			o.showDrifts(name, drifts)
+		}
+	}
+
+	if drifted == 0 {
+		o.reportf("✅ No drift in %d tracked tools\n", len(names))
+		return nil
+	}
+	o.reportf("\n📈 Summary: %d of %d tracked tools drifted\n", drifted, len(names))
+	o.reportf("💡 Run 'gearbox verify --integrity' to compare every checksum\n")
+	return nil
+}
+
+// showDrifts prints the drift of a tool and returns it as text
+func (o *Orchestrator) showDrifts(name string, drifts []manifest.Drift) string {
+	o.reportf("❌ %-15s %d problems\n", name, len(drifts))
+	lines := make([]string, len(drifts))
+	for i, drift := range drifts {
+		lines[i] = drift.String()
+		icon := "❌"
+		if drift.Kind == manifest.DriftShadowed || drift.Kind == manifest.DriftUnreachable || drift.Kind == manifest.DriftVersion {
+			icon = "⚠️ "
+		}
+		o.reportf("   %s %s\n", icon, lines[i])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// trackedTools loads the manifest and returns the named tools, or all tracked
+// tools without bundles, sorted by name
+func trackedTools(toolNames []string) (*manifest.InstallationManifest, []string, error) {
+	manager := manifest.NewManager()
+	if !manager.Exists() {
+		if len(toolNames) > 0 {
+			return nil, nil, fmt.Errorf("tool %s is not tracked in the manifest", toolNames[0])
+		}
+		return manifest.NewManifest(), nil, nil
+	}
+	m, err := manager.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(toolNames) > 0 {
+		for _, name := range toolNames {
+			if _, tracked := m.Installations[name]; !tracked {
+				return nil, nil, fmt.Errorf("tool %s is not tracked in the manifest", name)
+			}
+		}
+		return m, toolNames, nil
+	}
+
+	var names []string
+	for name, record := range m.Installations {
+		if record.Method != manifest.MethodBundle {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return m, names, nil
+}
+
+// knownVersion reports whether a version was detected, rather than being a
+// placeholder of tools without a version command
+func knownVersion(version string) bool {
+	return version != "" && version != "installed" && version != "unknown"
+}
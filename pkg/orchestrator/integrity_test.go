@@ -0,0 +1,39 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gearbox/pkg/manifest"
+)
+
+func TestToolDriftComparesRecordedAndLiveVersions(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "gearbox-fake")
+	if err := os.WriteFile(binary, []byte("#!/bin/sh\necho 'gearbox-fake 2.0.0'\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(filepath.ListSeparator)+os.Getenv("PATH"))
+
+	o := &Orchestrator{configMgr: &ConfigManager{config: Config{
+		Tools: []ToolConfig{{Name: "gearbox-fake", BinaryName: "gearbox-fake", TestCommand: "--version"}},
+	}}}
+	record := &manifest.InstallationRecord{
+		Method:      manifest.MethodSourceBuild,
+		Version:     "1.0.0",
+		BinaryPaths: []string{binary},
+		Files:       manifest.RecordFiles([]string{binary}),
+	}
+
+	drifts := o.ToolDrift("gearbox-fake", record, true)
+	if len(drifts) != 1 || drifts[0].Kind != manifest.DriftVersion || drifts[0].Detail != "2.0.0, recorded 1.0.0" {
+		t.Errorf("expected a version mismatch, got %+v", drifts)
+	}
+
+	// Scripts record the whole version output
+	record.Version = "gearbox-fake 2.0.0"
+	if drifts := o.ToolDrift("gearbox-fake", record, true); len(drifts) != 0 {
+		t.Errorf("expected no drift, got %+v", drifts)
+	}
+}
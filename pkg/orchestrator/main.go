@@ -5,12 +5,14 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"gearbox/pkg/output"
 )
 
 // Global variables
 var (
-	configPath string
-	repoDir    string
+	configPath   string
+	repoDir      string
+	outputFormat string
 )
 
 // Main is the entry point for the orchestrator command-line tool.
@@ -29,6 +31,14 @@ func Main() {
 	rootCmd.AddCommand(statusCmd())
 	rootCmd.AddCommand(verifyCmd())
 	rootCmd.AddCommand(doctorCmd())
+	rootCmd.AddCommand(validateCmd())
+	rootCmd.AddCommand(exportCmd())
+	rootCmd.AddCommand(importCmd())
+	rootCmd.AddCommand(mirrorCmd())
+	rootCmd.AddCommand(cacheCmd())
+	rootCmd.AddCommand(gcCmd())
+	rootCmd.AddCommand(adoptCmd())
+	rootCmd.AddCommand(syncCmd())
 	
 	// Add tracking commands
 	rootCmd.AddCommand(trackInstallationCmd())
@@ -48,6 +58,11 @@ func Main() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "Path to tools.json configuration file")
 	rootCmd.PersistentFlags().StringVar(&repoDir, "repo-dir", "", "Repository directory (default: auto-detect)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "table", "Output format of list, show, doctor and uninstall-plan (table, json, yaml)")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		_, err := output.ParseFormat(outputFormat)
+		return err
+	}
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
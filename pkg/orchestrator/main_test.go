@@ -391,7 +391,7 @@ func TestErrorHandling(t *testing.T) {
 	repoDir = "/nonexistent/repo"
 	configPath = ""
 	
-	_, err = NewOrchestrator(InstallationOptions{})
+	_, err = NewOrchestratorBuilder(InstallationOptions{}).WithRepoDir(repoDir).WithConfigPath(configPath).Build()
 	if err == nil {
 		t.Error("Expected error when repo directory is invalid")
 	}
@@ -424,11 +424,11 @@ func BenchmarkLoadConfig(b *testing.B) {
 
 // BenchmarkResolveDependencies benchmarks dependency resolution
 func BenchmarkResolveDependencies(b *testing.B) {
-	configPath := setupTestConfig(&testing.T{})
-	tempDir := filepath.Dir(configPath)
+	testConfigPath := setupTestConfig(&testing.T{})
+	tempDir := filepath.Dir(filepath.Dir(testConfigPath))
 	
 	repoDir = tempDir
-	configPath = configPath
+	configPath = testConfigPath
 	
 	orchestrator, err := NewOrchestratorBuilder(InstallationOptions{}).Build()
 	if err != nil {
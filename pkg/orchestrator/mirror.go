@@ -0,0 +1,734 @@
+package orchestrator
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MirrorSchemaVersion defines the current offline mirror index version
+const MirrorSchemaVersion = "1.0"
+
+// mirrorIndexFile is the index written at the root of every mirror
+const mirrorIndexFile = "mirror.json"
+
+// defaultMirrorGoVersion matches the Go version installed by install-common-deps.sh
+const defaultMirrorGoVersion = "1.23.4"
+
+// MirrorOptions controls what an offline mirror contains
+type MirrorOptions struct {
+	GoVersion   string // Go toolchain version (default: defaultMirrorGoVersion)
+	RustVersion string // Rust toolchain version or "stable"
+	NoReleases  bool   // Skip prebuilt release assets
+}
+
+// MirrorIndex records the content of an offline mirror
+type MirrorIndex struct {
+	SchemaVersion string            `json:"schema_version"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	Arch          string            `json:"arch"`
+	Tools         []string          `json:"tools"`
+	Repositories  map[string]string `json:"repositories"`         // Upstream URL -> bare repository
+	Assets        map[string]string `json:"assets"`               // Upstream URL -> downloaded file
+	Toolchains    map[string]string `json:"toolchains"`           // Toolchain -> version
+	Incomplete    map[string]string `json:"incomplete,omitempty"` // Tool -> what still needs network access
+}
+
+// Mirror is an offline mirror directory created by 'mirror'
+type Mirror struct {
+	Dir   string
+	Index *MirrorIndex
+}
+
+// OpenMirror loads the offline mirror in dir
+func OpenMirror(dir string) (*Mirror, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mirror directory: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(absDir, mirrorIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("not a gearbox mirror: %s (run 'gearbox mirror %s' on a connected machine)", dir, dir)
+	}
+
+	var index MirrorIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse mirror index: %w", err)
+	}
+	if index.SchemaVersion != MirrorSchemaVersion {
+		return nil, fmt.Errorf("unsupported mirror schema version: %s (expected %s)", index.SchemaVersion, MirrorSchemaVersion)
+	}
+
+	return &Mirror{Dir: absDir, Index: &index}, nil
+}
+
+// newMirror opens the mirror in dir, or starts a new one
+func newMirror(dir string) (*Mirror, error) {
+	if mirror, err := OpenMirror(dir); err == nil {
+		return mirror, nil
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mirror directory: %w", err)
+	}
+	if err := os.MkdirAll(absDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create mirror directory: %w", err)
+	}
+
+	return &Mirror{
+		Dir: absDir,
+		Index: &MirrorIndex{
+			SchemaVersion: MirrorSchemaVersion,
+			Arch:          runtime.GOARCH,
+			Repositories:  make(map[string]string),
+			Assets:        make(map[string]string),
+			Toolchains:    make(map[string]string),
+		},
+	}, nil
+}
+
+// save writes the mirror index
+func (m *Mirror) save() error {
+	m.Index.UpdatedAt = time.Now()
+	sort.Strings(m.Index.Tools)
+
+	data, err := json.MarshalIndent(m.Index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode mirror index: %w", err)
+	}
+	return os.WriteFile(filepath.Join(m.Dir, mirrorIndexFile), append(data, '\n'), 0644)
+}
+
+// urlPath maps an upstream URL to a path below the mirror, e.g.
+// https://github.com/sharkdp/fd.git -> github.com/sharkdp/fd.git
+func urlPath(rawURL string) string {
+	p := rawURL
+	if i := strings.Index(p, "://"); i >= 0 {
+		p = p[i+3:]
+	}
+	if i := strings.IndexAny(p, "?#"); i >= 0 {
+		p = p[:i]
+	}
+	return strings.Trim(path.Clean("/"+p), "/")
+}
+
+// repositoryPath returns the bare repository path for a repository URL
+func repositoryPath(repoURL string) string {
+	return filepath.Join("git", strings.TrimSuffix(urlPath(repoURL), ".git")+".git")
+}
+
+// assetPath returns the path of a downloaded file for a URL
+func assetPath(rawURL string) string {
+	return filepath.Join("assets", urlPath(rawURL))
+}
+
+// Env returns the environment that makes installation scripts use the
+// mirror instead of the network. Repository URLs are rewritten separately
+// with urlRewrites.
+func (m *Mirror) Env() []string {
+	return []string{
+		"GEARBOX_MIRROR=" + m.Dir,
+		"GEARBOX_OFFLINE=true",
+		"GOPROXY=file://" + filepath.Join(m.Dir, "go", "mod", "cache", "download"),
+		"GOSUMDB=off",
+		"GOTOOLCHAIN=local",
+		"CARGO_NET_OFFLINE=true",
+	}
+}
+
+// urlRewrites points every host with mirrored repositories at the bare mirrors
+func (m *Mirror) urlRewrites() []urlRewrite {
+	hosts := make(map[string]bool)
+	for repoURL := range m.Index.Repositories {
+		if i := strings.Index(repoURL, "://"); i >= 0 {
+			host := strings.SplitN(repoURL[i+3:], "/", 2)[0]
+			hosts[repoURL[:i+3]+host] = true
+		}
+	}
+	var bases []string
+	for base := range hosts {
+		bases = append(bases, base)
+	}
+	sort.Strings(bases)
+
+	var rewrites []urlRewrite
+	for _, base := range bases {
+		rewrites = append(rewrites, urlRewrite{
+			Base:      filepath.Join(m.Dir, "git", urlPath(base)) + "/",
+			InsteadOf: base + "/",
+		})
+	}
+	return rewrites
+}
+
+// scriptEnv returns extra environment variables for installation scripts
+func (o *Orchestrator) scriptEnv() []string {
+	var env []string
+	var rewrites []urlRewrite
+	if o.mirror != nil {
+		env = append(env, o.mirror.Env()...)
+		rewrites = append(rewrites, o.mirror.urlRewrites()...)
+	}
+	if o.sourceCache != nil {
+		env = append(env, "GEARBOX_CACHE_DIR="+o.sourceCache.Dir)
+		rewrites = append(rewrites, o.sourceCache.urlRewrites()...)
+	}
+	if len(rewrites) > 0 {
+		env = append(env, gitRewriteEnv(rewrites)...)
+	}
+	return env
+}
+
+// prepareOfflineInstall reports tools the mirror cannot install offline and
+// seeds the cargo registry so that crates resolve without network access
+func (o *Orchestrator) prepareOfflineInstall(tools []ToolConfig) error {
+	o.reportf("📴 Offline mode: using mirror %s\n", o.mirror.Dir)
+
+	var missing, incomplete []string
+	for _, tool := range tools {
+		if !contains(o.mirror.Index.Tools, tool.Name) {
+			missing = append(missing, tool.Name)
+		} else if reason, found := o.mirror.Index.Incomplete[tool.Name]; found {
+			incomplete = append(incomplete, fmt.Sprintf("%s (%s)", tool.Name, reason))
+		}
+	}
+	if len(missing) > 0 {
+		o.reportf("⚠️  Not in mirror: %s\n", strings.Join(missing, ", "))
+	}
+	if len(incomplete) > 0 {
+		o.reportf("⚠️  May need network access: %s\n", strings.Join(incomplete, ", "))
+	}
+	o.reportf("\n")
+
+	if o.options.DryRun {
+		return nil
+	}
+
+	registry := filepath.Join(o.mirror.Dir, "cargo", "registry")
+	if _, err := os.Stat(registry); err != nil {
+		return nil
+	}
+
+	cargoHome := os.Getenv("CARGO_HOME")
+	if cargoHome == "" {
+		cargoHome = filepath.Join(os.Getenv("HOME"), ".cargo")
+	}
+	for _, dir := range []string{"index", "cache"} {
+		src := filepath.Join(registry, dir)
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		if err := seedTree(src, filepath.Join(cargoHome, "registry", dir)); err != nil {
+			return fmt.Errorf("failed to seed cargo registry from mirror: %w", err)
+		}
+	}
+	return nil
+}
+
+// installPackages installs system packages, or in offline mode checks that
+// they are present: the package manager needs the network to fetch them
+func (o *Orchestrator) installPackages(packages []string) error {
+	if o.mirror == nil || o.options.DryRun || len(packages) == 0 {
+		return o.packageMgr.installPackages(packages, o.options.DryRun, o.options.SystemUpgrade, o.reportf)
+	}
+
+	missing, err := o.packageMgr.missingPackages(packages)
+	if err != nil {
+		o.reportf("⚠️  Warning: cannot check system packages offline: %v\n", err)
+		return nil
+	}
+	if len(missing) > 0 {
+		install := append(append([]string{}, o.packageMgr.InstallCmd...), missing...)
+		return fmt.Errorf("offline mode cannot install system packages (%s): %s; install them first with: %s",
+			o.packageMgr.Name, strings.Join(missing, ", "), strings.Join(o.packageMgr.commandArgs(install, os.Geteuid() == 0), " "))
+	}
+	o.reportf("✅ System packages already installed (%s)\n", o.packageMgr.Name)
+	return nil
+}
+
+// seedTree copies files from src to dst that do not exist in dst yet
+func seedTree(src, dst string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, relPath)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if _, err := os.Stat(target); err == nil {
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+// CreateMirror downloads everything needed to install the given tools and
+// bundles without network access into dir. Without names every tool is mirrored.
+// Running it again on an existing mirror updates it.
+func (o *Orchestrator) CreateMirror(dir string, names []string, opts MirrorOptions) error {
+	if len(names) == 0 {
+		for _, tool := range o.configMgr.GetConfig().Tools {
+			names = append(names, tool.Name)
+		}
+	}
+
+	expandedToolNames, err := o.expandBundlesAndTools(names)
+	if err != nil {
+		return fmt.Errorf("failed to expand bundles: %w", err)
+	}
+
+	var tools []ToolConfig
+	for _, name := range expandedToolNames {
+		tool, found := o.findTool(name)
+		if !found {
+			return fmt.Errorf("tool not found: %s", name)
+		}
+		tools = append(tools, tool)
+	}
+
+	tools, err = o.resolveDependencies(tools)
+	if err != nil {
+		return fmt.Errorf("dependency resolution failed: %w", err)
+	}
+
+	mirror, err := newMirror(dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🪞 Mirroring %d tools into %s\n\n", len(tools), mirror.Dir)
+
+	var failed []string
+	for i, tool := range tools {
+		fmt.Printf("[%d/%d] %s\n", i+1, len(tools), tool.Name)
+		if err := mirror.addTool(tool, opts); err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			failed = append(failed, tool.Name)
+			continue
+		}
+		if !contains(mirror.Index.Tools, tool.Name) {
+			mirror.Index.Tools = append(mirror.Index.Tools, tool.Name)
+		}
+	}
+
+	fmt.Printf("\n🧰 Toolchains\n")
+	if err := mirror.addGoToolchain(opts.GoVersion); err != nil {
+		fmt.Printf("  ❌ go: %v\n", err)
+		failed = append(failed, "go toolchain")
+	}
+	if err := mirror.addRustToolchain(opts.RustVersion); err != nil {
+		fmt.Printf("  ❌ rust: %v\n", err)
+		failed = append(failed, "rust toolchain")
+	}
+
+	if err := mirror.save(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(failed) > 0 {
+		return fmt.Errorf("mirror incomplete, failed: %s (run again to retry)", strings.Join(failed, ", "))
+	}
+
+	fmt.Printf("✅ Mirror ready: %d tools, %d repositories, %d files\n", len(mirror.Index.Tools), len(mirror.Index.Repositories), len(mirror.Index.Assets))
+	fmt.Printf("Install offline with: gearbox install --mirror %s\n", mirror.Dir)
+	return nil
+}
+
+// addTool mirrors the repository, language dependencies and release assets of a tool
+func (m *Mirror) addTool(tool ToolConfig, opts MirrorOptions) error {
+	config := tool.Mirror
+	if config == nil {
+		config = &MirrorConfig{}
+	}
+	delete(m.Index.Incomplete, tool.Name)
+
+	if usesRepository(tool) {
+		repoPath, err := m.addRepository(tool.Repository)
+		if err != nil {
+			return err
+		}
+
+		switch tool.Language {
+		case "rust":
+			if err := m.fetchSourceDependencies(repoPath, "Cargo.toml", m.cargoFetchCmd); err != nil {
+				return fmt.Errorf("failed to fetch crates: %w", err)
+			}
+		case "go":
+			if err := m.fetchSourceDependencies(repoPath, "go.mod", m.goModDownloadCmd); err != nil {
+				return fmt.Errorf("failed to download Go modules: %w", err)
+			}
+		case "python", "javascript", "typescript":
+			m.markIncomplete(tool.Name, tool.Language+" packages")
+		}
+	}
+
+	for _, module := range config.GoModules {
+		if err := m.addGoModule(module); err != nil {
+			return fmt.Errorf("failed to download %s: %w", module, err)
+		}
+	}
+
+	if !opts.NoReleases || config.Release != "" {
+		if err := m.addReleaseAssets(tool, config); err != nil {
+			// Release assets are only used by some build types
+			fmt.Printf("  ⚠️  Release assets: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// markIncomplete records that a tool still needs network access
+func (m *Mirror) markIncomplete(toolName, reason string) {
+	if m.Index.Incomplete == nil {
+		m.Index.Incomplete = make(map[string]string)
+	}
+	m.Index.Incomplete[toolName] = reason
+}
+
+// addRepository creates or updates the bare mirror of a repository
+func (m *Mirror) addRepository(repoURL string) (string, error) {
+	relPath := repositoryPath(repoURL)
+	repoPath := filepath.Join(m.Dir, relPath)
+
+	if _, err := os.Stat(repoPath); err == nil {
+		fmt.Printf("  🔄 Updating %s\n", repoURL)
+	} else {
+		fmt.Printf("  📥 Cloning %s\n", repoURL)
+	}
+	if err := syncBareRepository(repoURL, repoPath, nil); err != nil {
+		return "", err
+	}
+
+	m.Index.Repositories[repoURL] = relPath
+	return repoPath, nil
+}
+
+// fetchSourceDependencies checks out a bare mirror into a temporary
+// directory and runs fetch in it when it contains the given manifest file
+func (m *Mirror) fetchSourceDependencies(repoPath, manifestFile string, fetch func(workDir string) *exec.Cmd) error {
+	workDir, err := os.MkdirTemp("", "gearbox-mirror-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+
+	checkout := exec.Command("git", "clone", "--quiet", "--depth", "1", "file://"+repoPath, workDir)
+	if output, err := checkout.CombinedOutput(); err != nil {
+		return fmt.Errorf("checkout failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	if _, err := os.Stat(filepath.Join(workDir, manifestFile)); err != nil {
+		return nil
+	}
+
+	cmd := fetch(workDir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %s", strings.Join(cmd.Args, " "), lastLine(string(output)))
+	}
+	return nil
+}
+
+// cargoFetchCmd downloads the crates of a Cargo project into the mirror's cargo home
+func (m *Mirror) cargoFetchCmd(workDir string) *exec.Cmd {
+	fmt.Printf("  📦 Fetching crates\n")
+	cmd := exec.Command("cargo", "fetch", "--manifest-path", filepath.Join(workDir, "Cargo.toml"))
+	cmd.Env = append(os.Environ(), "CARGO_HOME="+filepath.Join(m.Dir, "cargo"))
+	return cmd
+}
+
+// goModDownloadCmd downloads the modules of a Go project into the mirror's module cache
+func (m *Mirror) goModDownloadCmd(workDir string) *exec.Cmd {
+	fmt.Printf("  📦 Downloading Go modules\n")
+	cmd := exec.Command("go", "mod", "download")
+	cmd.Dir = workDir
+	cmd.Env = m.goEnv()
+	return cmd
+}
+
+// goEnv returns the environment for go commands that fill the mirror's module cache
+func (m *Mirror) goEnv() []string {
+	return append(os.Environ(),
+		"GOMODCACHE="+filepath.Join(m.Dir, "go", "mod"),
+		"GOFLAGS=-modcacherw")
+}
+
+// addGoModule downloads a module installed with 'go install module@version'
+// together with its dependencies
+func (m *Mirror) addGoModule(module string) error {
+	fmt.Printf("  📦 Downloading %s\n", module)
+
+	cmd := exec.Command("go", "mod", "download", "-json", module)
+	cmd.Dir = os.TempDir()
+	cmd.Env = m.goEnv()
+	output, err := cmd.Output()
+	if err != nil {
+		return err
+	}
+
+	var info struct {
+		Dir string
+	}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return fmt.Errorf("failed to parse go mod download output: %w", err)
+	}
+
+	deps := exec.Command("go", "mod", "download")
+	deps.Dir = info.Dir
+	deps.Env = m.goEnv()
+	if output, err := deps.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s", lastLine(string(output)))
+	}
+	return nil
+}
+
+// githubRelease is the part of the GitHub releases API response used for mirroring
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	Assets  []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// addReleaseAssets downloads the GitHub release assets of a tool. The API
+// response is stored as well, since scripts query it for the latest version.
+func (m *Mirror) addReleaseAssets(tool ToolConfig, config *MirrorConfig) error {
+	repo := strings.TrimSuffix(strings.TrimPrefix(tool.Repository, "https://github.com/"), ".git")
+	if repo == tool.Repository || repo == "" {
+		return nil
+	}
+
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repo)
+	if config.Release != "" {
+		apiURL = fmt.Sprintf("https://api.github.com/repos/%s/releases/tags/%s", repo, config.Release)
+	}
+
+	// Always refresh the API response so that updates pick up new releases
+	apiFile := filepath.Join(m.Dir, assetPath(apiURL))
+	os.Remove(apiFile)
+	if err := m.addAsset(apiURL); err != nil {
+		if config.Release == "" && strings.Contains(err.Error(), "404") {
+			return nil // No releases
+		}
+		return err
+	}
+
+	data, err := os.ReadFile(apiFile)
+	if err != nil {
+		return err
+	}
+	var release githubRelease
+	if err := json.Unmarshal(data, &release); err != nil {
+		return fmt.Errorf("failed to parse release of %s: %w", repo, err)
+	}
+
+	var count int
+	for _, asset := range release.Assets {
+		if !matchReleaseAsset(asset.Name, config.Assets, m.Index.Arch) {
+			continue
+		}
+		if err := m.addAsset(asset.URL); err != nil {
+			return err
+		}
+		count++
+	}
+	if count > 0 {
+		fmt.Printf("  📥 %d release assets (%s)\n", count, release.TagName)
+	}
+	return nil
+}
+
+// matchReleaseAsset reports whether a release asset should be mirrored.
+// Without patterns Linux assets for the architecture are mirrored.
+func matchReleaseAsset(name string, patterns []string, arch string) bool {
+	if len(patterns) > 0 {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+		return false
+	}
+
+	lower := strings.ToLower(name)
+	if !strings.Contains(lower, "linux") {
+		return false
+	}
+
+	aliases := map[string][]string{
+		"amd64": {"x86_64", "amd64", "x64"},
+		"arm64": {"aarch64", "arm64"},
+	}
+	for _, alias := range aliases[arch] {
+		if strings.Contains(lower, alias) {
+			return true
+		}
+	}
+	return false
+}
+
+// addGoToolchain downloads the Go release archive used by the installation scripts
+func (m *Mirror) addGoToolchain(version string) error {
+	if version == "" {
+		version = defaultMirrorGoVersion
+	}
+
+	goURL := fmt.Sprintf("https://golang.org/dl/go%s.linux-%s.tar.gz", version, m.Index.Arch)
+	if err := m.addAsset(goURL); err != nil {
+		return err
+	}
+
+	fmt.Printf("  ✅ go %s\n", version)
+	m.Index.Toolchains["go"] = version
+	return nil
+}
+
+// rustTargets returns the host and static (musl) Rust targets for an architecture
+func rustTargets(arch string) (string, string) {
+	machine := "x86_64"
+	if arch == "arm64" {
+		machine = "aarch64"
+	}
+	return machine + "-unknown-linux-gnu", machine + "-unknown-linux-musl"
+}
+
+// addRustToolchain downloads the standalone Rust installer and the musl
+// standard library, which install without rustup or network access
+func (m *Mirror) addRustToolchain(version string) error {
+	if version == "" || version == "stable" {
+		resolved, err := latestRustVersion()
+		if err != nil {
+			return err
+		}
+		version = resolved
+	}
+
+	host, musl := rustTargets(m.Index.Arch)
+	for _, name := range []string{
+		fmt.Sprintf("rust-%s-%s.tar.gz", version, host),
+		fmt.Sprintf("rust-std-%s-%s.tar.gz", version, musl),
+	} {
+		dest := filepath.Join(m.Dir, "toolchains", name)
+		if err := downloadFile("https://static.rust-lang.org/dist/"+name, dest); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("  ✅ rust %s\n", version)
+	m.Index.Toolchains["rust"] = version
+	return nil
+}
+
+// latestRustVersion reads the current stable version from the Rust release channel
+func latestRustVersion() (string, error) {
+	resp, err := http.Get("https://static.rust-lang.org/dist/channel-rust-stable.toml")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch Rust release channel: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// [pkg.rust]
+	// version = "1.83.0 (90b35a623 2024-11-26)"
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	inRust := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inRust = line == "[pkg.rust]"
+			continue
+		}
+		if inRust && strings.HasPrefix(line, "version = \"") {
+			return strings.Fields(strings.TrimPrefix(line, "version = \""))[0], nil
+		}
+	}
+	return "", fmt.Errorf("stable Rust version not found in release channel")
+}
+
+// addAsset downloads a URL into the mirror unless it is already present
+func (m *Mirror) addAsset(rawURL string) error {
+	relPath := assetPath(rawURL)
+	if err := downloadFile(rawURL, filepath.Join(m.Dir, relPath)); err != nil {
+		return err
+	}
+	m.Index.Assets[rawURL] = relPath
+	return nil
+}
+
+// downloadFile downloads a URL to dest unless dest already exists
+func downloadFile(rawURL, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" && strings.HasPrefix(rawURL, "https://api.github.com/") {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", rawURL, resp.Status)
+	}
+
+	// Write to a temporary file so that interrupted downloads are retried
+	tmp := dest + ".part"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dest)
+}
+
+// lastLine returns the last non-empty line of command output
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,158 @@
+package orchestrator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMirrorPaths(t *testing.T) {
+	tests := []struct {
+		url      string
+		repoPath string
+	}{
+		{"https://github.com/sharkdp/fd.git", "git/github.com/sharkdp/fd.git"},
+		{"https://github.com/ryanoasis/nerd-fonts", "git/github.com/ryanoasis/nerd-fonts.git"},
+	}
+	for _, test := range tests {
+		if got := repositoryPath(test.url); got != test.repoPath {
+			t.Errorf("repositoryPath(%s) = %s, want %s", test.url, got, test.repoPath)
+		}
+	}
+
+	if got := assetPath("https://golang.org/dl/go1.23.4.linux-amd64.tar.gz?x=1"); got != "assets/golang.org/dl/go1.23.4.linux-amd64.tar.gz" {
+		t.Errorf("unexpected asset path: %s", got)
+	}
+}
+
+func TestMatchReleaseAsset(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		arch     string
+		want     bool
+	}{
+		{"fd-v10.2.0-x86_64-unknown-linux-musl.tar.gz", nil, "amd64", true},
+		{"lazygit_0.44.1_Linux_x86_64.tar.gz", nil, "amd64", true},
+		{"fd-v10.2.0-aarch64-unknown-linux-gnu.tar.gz", nil, "amd64", false},
+		{"fd-v10.2.0-x86_64-apple-darwin.tar.gz", nil, "amd64", false},
+		{"fd-v10.2.0-aarch64-unknown-linux-gnu.tar.gz", nil, "arm64", true},
+		{"FiraCode.zip", []string{"*.zip"}, "amd64", true},
+		{"FiraCode.tar.xz", []string{"*.zip"}, "amd64", false},
+	}
+	for _, test := range tests {
+		if got := matchReleaseAsset(test.name, test.patterns, test.arch); got != test.want {
+			t.Errorf("matchReleaseAsset(%s, %v, %s) = %v, want %v", test.name, test.patterns, test.arch, got, test.want)
+		}
+	}
+}
+
+func TestMirrorRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := OpenMirror(dir); err == nil {
+		t.Fatal("expected error opening a directory without a mirror index")
+	}
+
+	mirror, err := newMirror(dir)
+	if err != nil {
+		t.Fatalf("failed to create mirror: %v", err)
+	}
+	mirror.Index.Tools = []string{"ripgrep", "fd"}
+	mirror.Index.Repositories["https://github.com/sharkdp/fd.git"] = repositoryPath("https://github.com/sharkdp/fd.git")
+	mirror.markIncomplete("serena", "python packages")
+	if err := mirror.save(); err != nil {
+		t.Fatalf("failed to save mirror: %v", err)
+	}
+
+	loaded, err := OpenMirror(dir)
+	if err != nil {
+		t.Fatalf("failed to open mirror: %v", err)
+	}
+	if strings.Join(loaded.Index.Tools, ",") != "fd,ripgrep" {
+		t.Errorf("expected sorted tools, got %v", loaded.Index.Tools)
+	}
+	if loaded.Index.Incomplete["serena"] != "python packages" {
+		t.Errorf("expected incomplete serena, got %v", loaded.Index.Incomplete)
+	}
+}
+
+func TestMirrorEnvRewritesRepositories(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	// A local repository stands in for the upstream
+	upstream := filepath.Join(t.TempDir(), "upstream")
+	for _, args := range [][]string{
+		{"init", "--quiet", upstream},
+		{"-C", upstream, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "initial"},
+	} {
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s", args, output)
+		}
+	}
+
+	mirror, err := newMirror(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create mirror: %v", err)
+	}
+
+	repoURL := "https://example.invalid/owner/tool.git"
+	repoPath := filepath.Join(mirror.Dir, repositoryPath(repoURL))
+	if output, err := exec.Command("git", "clone", "--quiet", "--mirror", upstream, repoPath).CombinedOutput(); err != nil {
+		t.Fatalf("failed to create bare mirror: %s", output)
+	}
+	mirror.Index.Repositories[repoURL] = repositoryPath(repoURL)
+
+	// Both URL spellings resolve to the bare mirror without network access
+	for _, url := range []string{repoURL, strings.TrimSuffix(repoURL, ".git")} {
+		clone := exec.Command("git", "clone", "--quiet", url, filepath.Join(t.TempDir(), "clone"))
+		clone.Env = append(append(os.Environ(), mirror.Env()...), gitRewriteEnv(mirror.urlRewrites())...)
+		if output, err := clone.CombinedOutput(); err != nil {
+			t.Errorf("clone of %s through mirror failed: %s", url, output)
+		}
+	}
+}
+
+func TestSeedTreeKeepsExistingFiles(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "cache"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(src, "cache", "a.crate"), []byte("mirror"), 0644)
+	os.WriteFile(filepath.Join(src, "cache", "b.crate"), []byte("mirror"), 0644)
+	os.MkdirAll(filepath.Join(dst, "cache"), 0755)
+	os.WriteFile(filepath.Join(dst, "cache", "a.crate"), []byte("local"), 0644)
+
+	if err := seedTree(src, dst); err != nil {
+		t.Fatalf("seedTree failed: %v", err)
+	}
+
+	for name, want := range map[string]string{"a.crate": "local", "b.crate": "mirror"} {
+		data, err := os.ReadFile(filepath.Join(dst, "cache", name))
+		if err != nil || string(data) != want {
+			t.Errorf("%s: expected %q, got %q (%v)", name, want, data, err)
+		}
+	}
+}
+
+func TestOfflineInstallChecksSystemPackages(t *testing.T) {
+	// The query reports git as installed; installing would fail
+	pm := &PackageManager{
+		Name:       "apt",
+		InstallCmd: []string{"gearbox-no-such-install"},
+		CheckCmd:   []string{"sh", "-c", "echo installed git", "sh"},
+	}
+	o := &Orchestrator{packageMgr: pm, mirror: &Mirror{Dir: t.TempDir()}}
+
+	if err := o.installPackages([]string{"git"}); err != nil {
+		t.Errorf("expected installed packages to pass offline, got %v", err)
+	}
+	err := o.installPackages([]string{"git", "curl"})
+	if err == nil || !strings.Contains(err.Error(), "curl") || strings.Contains(err.Error(), "git,") {
+		t.Errorf("expected an error naming only the missing package, got %v", err)
+	}
+}
@@ -335,6 +335,7 @@ func (o *Orchestrator) executeNerdFontsScript(args []string) error {
 	cmdArgs := []string{"bash", scriptPath}
 	cmdArgs = append(cmdArgs, args...)
 	cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
+	cmd.Env = append(os.Environ(), o.scriptEnv()...)
 	
 	// Connect stdio for interactive features
 	cmd.Stdout = os.Stdout
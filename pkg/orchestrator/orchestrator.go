@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,8 +11,10 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	
 	"gearbox/pkg/manifest"
+	"gearbox/pkg/testreport"
 )
 
 // NewOrchestrator creates a new orchestrator instance with the given options.
@@ -19,12 +22,18 @@ import (
 // and initializes the orchestrator with proper settings for parallel execution.
 // OrchestratorBuilder provides a builder pattern for creating orchestrators
 type OrchestratorBuilder struct {
-	options      InstallationOptions
-	repoDir      string
-	configPath   string
-	configMgr    *ConfigManager
-	bundleConfig *BundleConfiguration
-	packageMgr   *PackageManager
+	options        InstallationOptions
+	repoDir        string
+	configPath     string
+	configMgr      *ConfigManager
+	bundleConfig   *BundleConfiguration
+	packageMgr     *PackageManager
+	packageMapping *PackageMapping
+	mirror         *Mirror
+	sourceCache    *SourceCache
+	buildStats     *BuildStats
+	reporter       Reporter
+	ctx            context.Context
 }
 
 // NewOrchestratorBuilder creates a new orchestrator builder using the builder pattern.
@@ -54,8 +63,30 @@ func (b *OrchestratorBuilder) WithConfigPath(path string) *OrchestratorBuilder {
 	return b
 }
 
+// WithReporter sets the reporter that receives installation events instead
+// of the text output on stdout
+func (b *OrchestratorBuilder) WithReporter(reporter Reporter) *OrchestratorBuilder {
+	b.reporter = reporter
+	return b
+}
+
+// WithContext sets the context whose cancellation stops the installation
+// scripts, such as when a frontend cancels an installation
+func (b *OrchestratorBuilder) WithContext(ctx context.Context) *OrchestratorBuilder {
+	b.ctx = ctx
+	return b
+}
+
 // autoDetectPaths automatically detects repository and config paths
 func (b *OrchestratorBuilder) autoDetectPaths() error {
+	// Fall back to the --repo-dir and --config command-line flags
+	if b.repoDir == "" {
+		b.repoDir = repoDir
+	}
+	if b.configPath == "" {
+		b.configPath = configPath
+	}
+
 	// Auto-detect repository directory if not provided
 	if b.repoDir == "" {
 		if wd, err := os.Getwd(); err == nil {
@@ -110,7 +141,7 @@ func (b *OrchestratorBuilder) setupParallelism() {
 		}
 		
 		if b.options.Verbose {
-			fmt.Printf("Auto-detected parallel jobs: %d (CPU cores: %d, memory-limited: %d)\n", 
+			fmt.Fprintf(os.Stderr, "Auto-detected parallel jobs: %d (CPU cores: %d, memory-limited: %d)\n", 
 				b.options.MaxParallelJobs, cpuCount, memoryLimitedJobs)
 		}
 	}
@@ -118,33 +149,25 @@ func (b *OrchestratorBuilder) setupParallelism() {
 
 // calculateMemoryBasedJobs estimates max parallel jobs based on available memory
 func (b *OrchestratorBuilder) calculateMemoryBasedJobs() int {
-	// Conservative estimates for memory usage per build type
-	// These are rough estimates - could be made more sophisticated
-	memoryPerJobMB := map[string]int{
-		"minimal":  200,  // 200MB per minimal build
-		"standard": 500,  // 500MB per standard build
-		"maximum":  1000, // 1GB per maximum build
-	}
-	
 	buildType := b.options.BuildType
 	if buildType == "" {
 		buildType = "standard"
 	}
 	
-	memPerJob := memoryPerJobMB[buildType]
+	memPerJob := defaultBuildMemoryMB[buildType]
 	if memPerJob == 0 {
 		memPerJob = 500 // default
 	}
 	
 	// Try to get system memory (Linux-specific basic implementation)
-	availableMemoryMB := b.getAvailableMemoryMB()
+	availableMemoryMB := getAvailableMemoryMB()
 	if availableMemoryMB <= 0 {
 		// Fallback: assume 4GB available memory
 		availableMemoryMB = 4096
 	}
 	
 	// Reserve 1GB for system processes
-	usableMemoryMB := availableMemoryMB - 1024
+	usableMemoryMB := availableMemoryMB - systemMemoryReserveMB
 	if usableMemoryMB < memPerJob {
 		return 1 // Can only run one job
 	}
@@ -153,7 +176,7 @@ func (b *OrchestratorBuilder) calculateMemoryBasedJobs() int {
 }
 
 // getAvailableMemoryMB gets available memory in MB (basic Linux implementation)
-func (b *OrchestratorBuilder) getAvailableMemoryMB() int {
+func getAvailableMemoryMB() int {
 	// Read /proc/meminfo for available memory
 	content, err := os.ReadFile("/proc/meminfo")
 	if err != nil {
@@ -201,7 +224,7 @@ func (b *OrchestratorBuilder) loadBundleConfig() error {
 			return nil
 		}
 		if b.options.Verbose {
-			fmt.Printf("⚠️  Warning: Failed to open bundles.json: %v\n", err)
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to open bundles.json: %v\n", err)
 		}
 		b.bundleConfig = &BundleConfiguration{
 			SchemaVersion: "1.0",
@@ -215,7 +238,7 @@ func (b *OrchestratorBuilder) loadBundleConfig() error {
 	decoder := json.NewDecoder(file)
 	if err := decoder.Decode(&bundleConfig); err != nil {
 		if b.options.Verbose {
-			fmt.Printf("⚠️  Warning: Failed to decode bundles.json: %v\n", err)
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to decode bundles.json: %v\n", err)
 		}
 		b.bundleConfig = &BundleConfiguration{
 			SchemaVersion: "1.0",
@@ -228,11 +251,46 @@ func (b *OrchestratorBuilder) loadBundleConfig() error {
 	return nil
 }
 
+// loadPackageMapping loads the canonical package name mapping (optional)
+func (b *OrchestratorBuilder) loadPackageMapping() error {
+	mapping, err := loadPackageMapping(b.repoDir)
+	if err != nil {
+		if b.options.Verbose {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: %v\n", err)
+		}
+		mapping = &PackageMapping{SchemaVersion: "1.0"}
+	}
+	b.packageMapping = mapping
+	return nil
+}
+
+// loadMirror opens the offline mirror when one is configured
+func (b *OrchestratorBuilder) loadMirror() error {
+	if b.options.Mirror == "" {
+		return nil
+	}
+
+	mirror, err := OpenMirror(b.options.Mirror)
+	if err != nil {
+		return err
+	}
+	b.mirror = mirror
+	return nil
+}
+
+// loadSourceCache sets up the shared source cache unless it is disabled
+func (b *OrchestratorBuilder) loadSourceCache() {
+	if b.options.NoCache || !cacheEnabled() {
+		return
+	}
+	b.sourceCache = newSourceCache(CacheDir())
+}
+
 // detectPackageManager detects the system package manager
 func (b *OrchestratorBuilder) detectPackageManager() error {
 	packageMgr, err := detectPackageManager()
 	if err != nil && b.options.Verbose {
-		fmt.Printf("⚠️  System package manager not detected: %v\n", err)
+		fmt.Fprintf(os.Stderr, "⚠️  System package manager not detected: %v\n", err)
 	}
 	b.packageMgr = packageMgr
 	return nil
@@ -255,19 +313,39 @@ func (b *OrchestratorBuilder) Build() (*Orchestrator, error) {
 		return nil, err
 	}
 
+	if err := b.loadPackageMapping(); err != nil {
+		return nil, err
+	}
+
 	if err := b.detectPackageManager(); err != nil {
 		return nil, err
 	}
 
+	if err := b.loadMirror(); err != nil {
+		return nil, err
+	}
+
+	b.loadSourceCache()
+	b.buildStats = loadBuildStats(buildStatsPath())
+	if b.reporter == nil {
+		b.reporter = NewTextReporter(os.Stdout, b.options.Verbose)
+	}
+
 	// Create orchestrator instance
 	orchestrator := &Orchestrator{
-		configMgr:    b.configMgr,
-		bundleConfig: b.bundleConfig,
-		packageMgr:   b.packageMgr,
-		options:      b.options,
-		repoDir:      b.repoDir,
-		scriptsDir:   filepath.Join(b.repoDir, "scripts"),
-		results:      make([]InstallationResult, 0),
+		configMgr:      b.configMgr,
+		bundleConfig:   b.bundleConfig,
+		packageMgr:     b.packageMgr,
+		packageMapping: b.packageMapping,
+		mirror:         b.mirror,
+		sourceCache:    b.sourceCache,
+		buildStats:     b.buildStats,
+		reporter:       b.reporter,
+		ctx:            b.ctx,
+		options:        b.options,
+		repoDir:        b.repoDir,
+		scriptsDir:     filepath.Join(b.repoDir, "scripts"),
+		results:        make([]InstallationResult, 0),
 	}
 
 	// Initialize memory pool for result objects
@@ -463,8 +541,8 @@ func (o *Orchestrator) ShowStatus(toolNames []string, manifestOnly bool, unified
 	return nil
 }
 
-// VerifyTools verifies tool installations
-func (o *Orchestrator) VerifyTools(toolNames []string) error {
+// VerifyTools verifies tool installations and returns a test case per tool
+func (o *Orchestrator) VerifyTools(toolNames []string) (testreport.Suite, error) {
 	config := o.configMgr.GetConfig()
 	var tools []ToolConfig
 	
@@ -474,35 +552,42 @@ func (o *Orchestrator) VerifyTools(toolNames []string) error {
 		for _, name := range toolNames {
 			tool, found := o.findTool(name)
 			if !found {
-				return fmt.Errorf("tool not found: %s", name)
+				return testreport.Suite{}, fmt.Errorf("tool not found: %s", name)
 			}
 			tools = append(tools, tool)
 		}
 	}
 
-	fmt.Printf("🔍 Verifying Tool Installations\n")
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	o.reportf("🔍 Verifying Tool Installations\n")
+	o.reportf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 
 	var verified, failed int
+	suite := testreport.Suite{Name: "verify", Timestamp: time.Now()}
 
 	for _, tool := range tools {
-		if verifyTool(tool) {
+		start := time.Now()
+		output, err := runToolTest(tool)
+		testCase := testreport.Case{Name: tool.Name, Output: output}
+		if err == nil {
 			verified++
 			version := getToolVersion(tool)
-			fmt.Printf("✅ %-15s %s\n", tool.Name, version)
+			o.reportf("✅ %-15s %s\n", tool.Name, version)
 		} else {
 			failed++
-			fmt.Printf("❌ %-15s Verification failed\n", tool.Name)
+			testCase.Failure = err.Error()
+			o.reportf("❌ %-15s Verification failed\n", tool.Name)
 		}
+		testCase.Duration = time.Since(start)
+		suite.Add(testCase)
 	}
 
-	fmt.Printf("\n📈 Verification Summary: %d passed, %d failed\n", verified, failed)
+	o.reportf("\n📈 Verification Summary: %d passed, %d failed\n", verified, failed)
 	
 	if failed > 0 {
-		return fmt.Errorf("%d tools failed verification", failed)
+		return suite, fmt.Errorf("%d tools failed verification", failed)
 	}
 	
-	return nil
+	return suite, nil
 }
 
 // GetConfig returns the orchestrator's configuration
@@ -517,12 +602,7 @@ func (o *Orchestrator) RunDoctor(toolNames []string) error {
 		return o.runNerdFontsDoctor()
 	}
 	
-	// General doctor functionality can be added here
-	fmt.Printf("🔍 General Health Check\n")
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	fmt.Printf("For tool-specific diagnostics, specify a tool name.\n")
-	fmt.Printf("Example: gearbox doctor nerd-fonts\n")
-	
+	o.showDoctorReport(o.DoctorReport())
 	return nil
 }
 
@@ -540,14 +620,6 @@ func (o *Orchestrator) Cleanup() error {
 
 	var cleanupErrors []error
 
-	// Clean up progress bar
-	if o.progressBar != nil {
-		if err := o.progressBar.Close(); err != nil {
-			cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to close progress bar: %w", err))
-		}
-		o.progressBar = nil
-	}
-
 	// Clear results to free memory
 	for i := range o.results {
 		// Clear individual result data
@@ -570,14 +642,14 @@ func (o *Orchestrator) CleanupOnFailure(failedTool string, tempDirs []string) er
 	var cleanupErrors []error
 
 	// Log cleanup action
-	fmt.Printf("🧹 Cleaning up after failed installation of %s...\n", failedTool)
+	o.reportf("🧹 Cleaning up after failed installation of %s...\n", failedTool)
 
 	// Clean up temporary directories
 	for _, dir := range tempDirs {
 		if err := os.RemoveAll(dir); err != nil {
 			cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to remove temp dir %s: %w", dir, err))
 		} else {
-			fmt.Printf("✓ Removed temporary directory: %s\n", dir)
+			o.reportf("✓ Removed temporary directory: %s\n", dir)
 		}
 	}
 
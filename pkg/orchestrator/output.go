@@ -0,0 +1,120 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+
+	"gearbox/pkg/output"
+	"gearbox/pkg/uninstall"
+)
+
+// ToolList is the structured output of 'list'
+type ToolList struct {
+	SchemaVersion string       `json:"schema_version"`
+	Tools         []ToolConfig `json:"tools"`
+}
+
+// BundleInfo is a bundle with its included bundles and system packages resolved
+type BundleInfo struct {
+	BundleConfig
+	ExpandedTools    []string `json:"expanded_tools"`              // Tools of the bundle and the bundles it includes
+	PackageManager   string   `json:"package_manager,omitempty"`   // Detected package manager
+	ResolvedPackages []string `json:"resolved_packages,omitempty"` // System packages for the package manager
+}
+
+// BundleList is the structured output of 'list bundles'
+type BundleList struct {
+	SchemaVersion string       `json:"schema_version"`
+	Bundles       []BundleInfo `json:"bundles"`
+}
+
+// BundleDetails is the structured output of 'show bundle'
+type BundleDetails struct {
+	SchemaVersion string     `json:"schema_version"`
+	Bundle        BundleInfo `json:"bundle"`
+}
+
+// RemovalPlanReport is the structured output of 'uninstall-plan'
+type RemovalPlanReport struct {
+	SchemaVersion string                    `json:"schema_version"`
+	Plan          *uninstall.RemovalPlan    `json:"plan"`
+	Validation    []uninstall.SafetyWarning `json:"validation"` // Warnings from validating the plan
+}
+
+// ToolList returns the tools of a category, or all tools, sorted by name
+func (o *Orchestrator) ToolList(category string) ToolList {
+	list := ToolList{SchemaVersion: output.SchemaVersion, Tools: []ToolConfig{}}
+	for _, tool := range o.configMgr.GetConfig().Tools {
+		if category == "" || tool.Category == category {
+			list.Tools = append(list.Tools, tool)
+		}
+	}
+	sort.Slice(list.Tools, func(i, j int) bool {
+		return list.Tools[i].Name < list.Tools[j].Name
+	})
+	return list
+}
+
+// bundleInfo resolves the tools and system packages of a bundle
+func (o *Orchestrator) bundleInfo(bundle BundleConfig, bundles []BundleConfig) (BundleInfo, error) {
+	tools, err := o.expandBundle(bundle.Name, bundles, make(map[string]bool))
+	if err != nil {
+		return BundleInfo{}, fmt.Errorf("failed to expand bundle: %w", err)
+	}
+
+	info := BundleInfo{BundleConfig: bundle, ExpandedTools: tools}
+	if o.packageMgr != nil {
+		info.PackageManager = o.packageMgr.Name
+		info.ResolvedPackages, _ = o.expandSystemPackages(bundle.Name, bundles, make(map[string]bool), o.packageMgr.Name)
+	}
+	return info, nil
+}
+
+// BundleList returns all bundles sorted by name
+func (o *Orchestrator) BundleList() (BundleList, error) {
+	bundleConfig, err := o.loadBundles()
+	if err != nil {
+		return BundleList{}, fmt.Errorf("failed to load bundles: %w", err)
+	}
+
+	list := BundleList{SchemaVersion: output.SchemaVersion, Bundles: []BundleInfo{}}
+	for _, bundle := range bundleConfig.Bundles {
+		info, err := o.bundleInfo(bundle, bundleConfig.Bundles)
+		if err != nil {
+			return BundleList{}, err
+		}
+		list.Bundles = append(list.Bundles, info)
+	}
+	sort.Slice(list.Bundles, func(i, j int) bool {
+		return list.Bundles[i].Name < list.Bundles[j].Name
+	})
+	return list, nil
+}
+
+// BundleDetails returns a bundle by name
+func (o *Orchestrator) BundleDetails(bundleName string) (BundleDetails, error) {
+	bundleConfig, err := o.loadBundles()
+	if err != nil {
+		return BundleDetails{}, fmt.Errorf("failed to load bundles: %w", err)
+	}
+
+	bundle, found := o.findBundle(bundleName, bundleConfig.Bundles)
+	if !found {
+		return BundleDetails{}, fmt.Errorf("bundle not found: %s", bundleName)
+	}
+
+	info, err := o.bundleInfo(*bundle, bundleConfig.Bundles)
+	if err != nil {
+		return BundleDetails{}, err
+	}
+	return BundleDetails{SchemaVersion: output.SchemaVersion, Bundle: info}, nil
+}
+
+// removalPlanReport returns a removal plan with the warnings of validating it
+func removalPlanReport(plan *uninstall.RemovalPlan, engine *uninstall.RemovalEngine) RemovalPlanReport {
+	validation := engine.ValidatePlan(plan)
+	if validation == nil {
+		validation = []uninstall.SafetyWarning{}
+	}
+	return RemovalPlanReport{SchemaVersion: output.SchemaVersion, Plan: plan, Validation: validation}
+}
@@ -0,0 +1,43 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"gearbox/pkg/output"
+)
+
+func TestToolList(t *testing.T) {
+	o := &Orchestrator{configMgr: &ConfigManager{config: Config{
+		Tools: []ToolConfig{
+			{Name: "ripgrep", Category: "core"},
+			{Name: "ffmpeg", Category: "media"},
+			{Name: "fd", Category: "core"},
+		},
+	}}}
+
+	list := o.ToolList("core")
+	if list.SchemaVersion != output.SchemaVersion {
+		t.Errorf("expected schema version %s, got %q", output.SchemaVersion, list.SchemaVersion)
+	}
+	if len(list.Tools) != 2 || list.Tools[0].Name != "fd" || list.Tools[1].Name != "ripgrep" {
+		t.Errorf("expected fd and ripgrep sorted by name, got %+v", list.Tools)
+	}
+	if got := o.ToolList("unknown"); got.Tools == nil || len(got.Tools) != 0 {
+		t.Errorf("expected an empty list rather than null, got %+v", got.Tools)
+	}
+}
+
+func TestDoctorReportSummary(t *testing.T) {
+	var report DoctorReport
+	report.add(HealthCheckResult{Name: "a", Status: HealthPass})
+	report.add(HealthCheckResult{Name: "b", Status: HealthWarn})
+	report.add(HealthCheckResult{Name: "c", Status: HealthFail})
+	report.add(HealthCheckResult{Name: "d", Status: HealthPass})
+
+	if report.Summary != (DoctorSummary{Passed: 2, Warnings: 1, Failed: 1}) {
+		t.Errorf("unexpected summary %+v", report.Summary)
+	}
+	if len(report.Checks) != 4 {
+		t.Errorf("expected 4 checks, got %d", len(report.Checks))
+	}
+}
@@ -1,18 +1,31 @@
 package orchestrator
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 )
 
+// SupportedPackageManagers lists every package manager gearbox can drive,
+// in detection order. Bundles must provide packages for each of them.
+var SupportedPackageManagers = []string{"apt", "yum", "dnf", "pacman", "zypper", "apk"}
+
 // PackageManager represents a system package manager
 type PackageManager struct {
 	Name          string
 	InstallCmd    []string
-	CheckCmd      []string
-	UpdateCmd     []string
+	CheckCmd      []string // Batch query; prints installed package names
+	OwnerCmd      []string // Query for the package owning a file
+	UpdateCmd     []string // Refreshes the package lists before installing, if needed
+	UpgradeCmd    []string // Installs with a full system upgrade, where refreshing alone is a partial upgrade
+	Env           []string // Extra environment for non-interactive operation
 	Available     bool
 }
 
@@ -24,102 +37,473 @@ type SystemPackage struct {
 	Available   bool
 }
 
-// detectPackageManager detects the available package manager on the system
-func detectPackageManager() (*PackageManager, error) {
-	if runtime.GOOS != "linux" {
-		return nil, fmt.Errorf("system package installation only supported on Linux")
+// PackageMapping translates canonical (Debian-style) package names into the
+// names used by each supported package manager. An entry that omits a manager
+// uses the canonical name unchanged; an empty list means nothing needs to be
+// installed on that manager.
+type PackageMapping struct {
+	SchemaVersion      string                         `json:"schema_version"`
+	CommonDependencies []string                       `json:"common_dependencies"`
+	Toolchains         []string                       `json:"toolchains"` // Installed by gearbox itself, not the package manager
+	Packages           map[string]map[string][]string `json:"packages"`
+}
+
+// loadPackageMapping loads the package mapping table from config/packages.json
+func loadPackageMapping(repoDir string) (*PackageMapping, error) {
+	mappingPath := filepath.Join(repoDir, "config", "packages.json")
+
+	file, err := os.Open(mappingPath)
+	if err != nil {
+		// The mapping is optional; without it canonical names are used as-is
+		if os.IsNotExist(err) {
+			return &PackageMapping{SchemaVersion: "1.0"}, nil
+		}
+		return nil, fmt.Errorf("failed to open packages.json: %w", err)
+	}
+	defer file.Close()
+
+	var mapping PackageMapping
+	decoder := json.NewDecoder(file)
+	if err := decoder.Decode(&mapping); err != nil {
+		return nil, fmt.Errorf("failed to decode packages.json: %w", err)
+	}
+
+	return &mapping, nil
+}
+
+// Translate converts canonical package names into the names used by the
+// given package manager, dropping packages that are not needed there.
+// Unmapped names are passed through unchanged.
+func (m *PackageMapping) Translate(names []string, managerName string) []string {
+	var translated []string
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		resolved := []string{name}
+		if m != nil {
+			if entry, exists := m.Packages[name]; exists {
+				if packages, exists := entry[managerName]; exists {
+					resolved = packages
+				}
+			}
+		}
+
+		for _, pkg := range resolved {
+			if !seen[pkg] {
+				seen[pkg] = true
+				translated = append(translated, pkg)
+			}
+		}
+	}
+
+	return translated
+}
+
+// SystemDependencies returns the tool dependencies that are system packages,
+// i.e. everything except the toolchains gearbox installs itself
+func (m *PackageMapping) SystemDependencies(dependencies []string) []string {
+	var packages []string
+	for _, dep := range dependencies {
+		if m == nil || !contains(m.Toolchains, dep) {
+			packages = append(packages, dep)
+		}
+	}
+	return packages
+}
+
+// Unmapped returns the canonical names that have no entry in the mapping table, sorted
+func (m *PackageMapping) Unmapped(names []string) []string {
+	var unmapped []string
+	for _, name := range names {
+		if strings.HasPrefix(name, "@") || contains(unmapped, name) {
+			continue
+		}
+		if m != nil {
+			if _, exists := m.Packages[name]; exists {
+				continue
+			}
+		}
+		unmapped = append(unmapped, name)
+	}
+	sort.Strings(unmapped)
+	return unmapped
+}
+
+// Validate checks that every mapping entry only references supported package managers
+func (m *PackageMapping) Validate() []error {
+	var errs []error
+	if m == nil {
+		return errs
+	}
+
+	var names []string
+	for name := range m.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var managers []string
+		for manager := range m.Packages[name] {
+			managers = append(managers, manager)
+		}
+		sort.Strings(managers)
+		for _, manager := range managers {
+			if !contains(SupportedPackageManagers, manager) {
+				errs = append(errs, fmt.Errorf("package %s: unsupported package manager %s", name, manager))
+			}
+		}
 	}
 
-	managers := []PackageManager{
+	for _, name := range m.Unmapped(m.SystemDependencies(m.CommonDependencies)) {
+		errs = append(errs, fmt.Errorf("common dependency %s: no package mapping", name))
+	}
+
+	return errs
+}
+
+// packageManagers returns the definitions of all supported package managers.
+// Every install and update command is non-interactive so that installs can
+// run unattended from the orchestrator and the TUI.
+func packageManagers() []PackageManager {
+	return []PackageManager{
 		{
 			Name:       "apt",
-			InstallCmd: []string{"apt-get", "install", "-y"},
-			CheckCmd:   []string{"dpkg", "-l"},
-			UpdateCmd:  []string{"apt-get", "update"},
+			InstallCmd: []string{"apt-get", "install", "-y", "--no-install-recommends"},
+			CheckCmd:   []string{"dpkg-query", "-W", "-f=${db:Status-Status} ${Package}\\n"},
+			OwnerCmd:   []string{"dpkg-query", "-S"},
+			UpdateCmd:  []string{"apt-get", "update", "-q"},
+			Env:        []string{"DEBIAN_FRONTEND=noninteractive"},
 		},
 		{
 			Name:       "yum",
 			InstallCmd: []string{"yum", "install", "-y"},
-			CheckCmd:   []string{"rpm", "-qa"},
-			UpdateCmd:  []string{"yum", "update"},
+			CheckCmd:   []string{"rpm", "-q", "--qf", "%{NAME}\\n"},
+			OwnerCmd:   []string{"rpm", "-qf", "--qf", "%{NAME}\\n"},
+			UpdateCmd:  []string{"yum", "makecache", "-y"},
 		},
 		{
 			Name:       "dnf",
 			InstallCmd: []string{"dnf", "install", "-y"},
-			CheckCmd:   []string{"rpm", "-qa"},
-			UpdateCmd:  []string{"dnf", "update"},
+			CheckCmd:   []string{"rpm", "-q", "--qf", "%{NAME}\\n"},
+			OwnerCmd:   []string{"rpm", "-qf", "--qf", "%{NAME}\\n"},
+			UpdateCmd:  []string{"dnf", "makecache", "-y"},
+		},
+		{
+			Name:       "pacman",
+			// Arch does not support partial upgrades, so the package
+			// database is only synced together with a system upgrade
+			InstallCmd: []string{"pacman", "-S", "--noconfirm", "--needed"},
+			CheckCmd:   []string{"pacman", "-Qq"},
+			OwnerCmd:   []string{"pacman", "-Qqo"},
+			UpgradeCmd: []string{"pacman", "-Syu", "--noconfirm", "--needed"},
+		},
+		{
+			Name:       "zypper",
+			InstallCmd: []string{"zypper", "--non-interactive", "install", "--no-recommends"},
+			CheckCmd:   []string{"rpm", "-q", "--qf", "%{NAME}\\n"},
+			OwnerCmd:   []string{"rpm", "-qf", "--qf", "%{NAME}\\n"},
+			UpdateCmd:  []string{"zypper", "--non-interactive", "refresh"},
 		},
+		{
+			Name:       "apk",
+			InstallCmd: []string{"apk", "add", "--no-cache"},
+			CheckCmd:   []string{"apk", "info", "-e"},
+			OwnerCmd:   []string{"apk", "info", "-q", "--who-owns"},
+			UpdateCmd:  []string{"apk", "update", "-q"},
+		},
+	}
+}
+
+// findPackageManager returns the definition of a supported package manager by name
+func findPackageManager(name string) (*PackageManager, error) {
+	for _, mgr := range packageManagers() {
+		if mgr.Name == name {
+			return &mgr, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported package manager: %s (supported: %s)", name, strings.Join(SupportedPackageManagers, ", "))
+}
+
+// detectPackageManager detects the available package manager on the system
+func detectPackageManager() (*PackageManager, error) {
+	if runtime.GOOS != "linux" {
+		return nil, fmt.Errorf("system package installation only supported on Linux")
 	}
 
-	for _, mgr := range managers {
+	for _, mgr := range packageManagers() {
 		if _, err := exec.LookPath(mgr.InstallCmd[0]); err == nil {
 			mgr.Available = true
 			return &mgr, nil
 		}
 	}
 
-	return nil, fmt.Errorf("no supported package manager found (apt, yum, dnf)")
+	return nil, fmt.Errorf("no supported package manager found (%s)", strings.Join(SupportedPackageManagers, ", "))
 }
 
 // isPackageInstalled checks if a system package is installed
 func (pm *PackageManager) isPackageInstalled(packageName string) (bool, error) {
-	var cmd *exec.Cmd
-	
-	switch pm.Name {
+	installed, err := pm.installedPackages([]string{packageName})
+	if err != nil {
+		return false, err
+	}
+	return installed[packageName], nil
+}
+
+// installedPackages queries the package database once for all given packages
+// and returns the subset that is installed.
+func (pm *PackageManager) installedPackages(packages []string) (map[string]bool, error) {
+	installed := make(map[string]bool)
+	if len(packages) == 0 {
+		return installed, nil
+	}
+	if len(pm.CheckCmd) == 0 {
+		return nil, fmt.Errorf("unsupported package manager: %s", pm.Name)
+	}
+
+	// Package groups (e.g. "@Development Tools") cannot be queried by name
+	var queryable []string
+	for _, pkg := range packages {
+		if !strings.HasPrefix(pkg, "@") {
+			queryable = append(queryable, pkg)
+		}
+	}
+	if len(queryable) == 0 {
+		return installed, nil
+	}
+
+	output, err := pm.query(queryable)
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, err
+	}
+	for name := range parseInstalledPackages(pm.Name, output) {
+		if contains(queryable, name) {
+			installed[name] = true
+		}
+	}
+	if err == nil {
+		return installed, nil
+	}
+
+	// Query tools exit non-zero when any package is missing, and rpm exits
+	// with the number of missing packages. The packages the query did not
+	// report are queried one by one, where exit status 1 means not installed.
+	for _, pkg := range queryable {
+		if installed[pkg] {
+			continue
+		}
+		output, err := pm.query([]string{pkg})
+		if err != nil {
+			if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+				return nil, err
+			}
+			continue
+		}
+		if parseInstalledPackages(pm.Name, output)[pkg] {
+			installed[pkg] = true
+		}
+	}
+	return installed, nil
+}
+
+// query runs the check command for packages and returns what it printed.
+// The error wraps the *exec.ExitError of a query that exited non-zero.
+func (pm *PackageManager) query(packages []string) (string, error) {
+	args := append(append([]string{}, pm.CheckCmd[1:]...), packages...)
+	cmd := exec.Command(pm.CheckCmd[0], args...)
+	cmd.Env = append(os.Environ(), pm.Env...)
+
+	output, err := cmd.Output()
+	if err != nil {
+		var stderr string
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			stderr = strings.TrimSpace(string(exitErr.Stderr))
+		}
+		return string(output), fmt.Errorf("failed to query installed packages with %s: %w: %s", pm.CheckCmd[0], err, stderr)
+	}
+	return string(output), nil
+}
+
+// parseInstalledPackages extracts installed package names from the output
+// of a package manager's batch query command
+func parseInstalledPackages(managerName, output string) map[string]bool {
+	installed := make(map[string]bool)
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+
+		switch managerName {
+		case "apt":
+			// "<status> <package>"; removed packages keep "config-files" status
+			if len(fields) == 2 && fields[0] == "installed" {
+				installed[fields[1]] = true
+			}
+		default:
+			// One package name per line; rpm reports missing packages as
+			// "package foo is not installed", which has several fields
+			if len(fields) == 1 {
+				installed[fields[0]] = true
+			}
+		}
+	}
+
+	return installed
+}
+
+// owningPackage returns the installed package a file belongs to, empty when
+// no package owns it
+func (pm *PackageManager) owningPackage(path string) string {
+	if len(pm.OwnerCmd) == 0 {
+		return ""
+	}
+	args := append(append([]string{}, pm.OwnerCmd[1:]...), path)
+	output, err := exec.Command(pm.OwnerCmd[0], args...).Output()
+	if err != nil {
+		return ""
+	}
+	return parseOwningPackage(pm.Name, string(output))
+}
+
+// apkVersionSuffix matches the version apk appends to package names
+var apkVersionSuffix = regexp.MustCompile(`-[0-9][^-]*-r[0-9]+$`)
+
+// parseOwningPackage extracts the package name from the output of a package
+// manager's owner query
+func parseOwningPackage(managerName, output string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	switch managerName {
 	case "apt":
-		cmd = exec.Command("dpkg", "-l", packageName)
-	case "yum", "dnf":
-		cmd = exec.Command("rpm", "-q", packageName)
+		// "fd-find: /usr/bin/fdfind", or "pkg:amd64, other: path" for shared files
+		packages, _, found := strings.Cut(line, ": ")
+		if !found || strings.HasPrefix(line, "diversion ") {
+			return ""
+		}
+		name, _, _ := strings.Cut(packages, ",")
+		name, _, _ = strings.Cut(strings.TrimSpace(name), ":")
+		return name
+	case "apk":
+		// "/usr/bin/fd is owned by fd-9.0.0-r0", or the package alone with -q
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			return ""
+		}
+		return apkVersionSuffix.ReplaceAllString(fields[len(fields)-1], "")
 	default:
-		return false, fmt.Errorf("unsupported package manager: %s", pm.Name)
+		if strings.Contains(line, " ") {
+			return "" // "file ... is not owned by any package"
+		}
+		return line
 	}
-	
-	err := cmd.Run()
-	return err == nil, nil
+}
+
+// missingPackages returns the packages that are not yet installed, preserving order
+func (pm *PackageManager) missingPackages(packages []string) ([]string, error) {
+	installed, err := pm.installedPackages(packages)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, pkg := range packages {
+		if !installed[pkg] {
+			missing = append(missing, pkg)
+		}
+	}
+	return missing, nil
 }
 
 // installPackages installs system packages using the detected package manager
-func (pm *PackageManager) installPackages(packages []string, dryRun bool) error {
+// and reports progress through reportf. Package managers that only install
+// alongside a full system upgrade upgrade the system when upgrade is set, and
+// otherwise install from the current package database.
+func (pm *PackageManager) installPackages(packages []string, dryRun, upgrade bool, reportf func(format string, args ...interface{})) error {
 	if len(packages) == 0 {
 		return nil
 	}
 
 	if dryRun {
-		fmt.Printf("📦 Would install system packages (%s): %s\n", pm.Name, strings.Join(packages, ", "))
+		reportf("📦 Would install system packages (%s): %s\n", pm.Name, strings.Join(packages, ", "))
+		return nil
+	}
+
+	// Skip packages that are already present
+	missing, err := pm.missingPackages(packages)
+	if err != nil {
+		// The package manager skips what is installed anyway
+		reportf("⚠️  Warning: %v\n", err)
+		missing = packages
+	}
+	if len(missing) == 0 {
+		reportf("✅ System packages already installed (%s)\n", pm.Name)
 		return nil
 	}
 
-	fmt.Printf("📦 Installing system packages via %s: %s\n", pm.Name, strings.Join(packages, ", "))
+	reportf("📦 Installing system packages via %s: %s\n", pm.Name, strings.Join(missing, ", "))
 	
+	root := os.Geteuid() == 0
+
 	// Update package lists first
 	if len(pm.UpdateCmd) > 0 {
-		updateCmd := exec.Command(pm.UpdateCmd[0], pm.UpdateCmd[1:]...)
+		updateCmd := pm.command(pm.UpdateCmd, root)
 		if err := updateCmd.Run(); err != nil {
-			fmt.Printf("⚠️  Warning: Failed to update package lists: %v\n", err)
+			reportf("⚠️  Warning: Failed to update package lists: %v\n", err)
 		}
 	}
 	
 	// Install packages
-	args := append(pm.InstallCmd[1:], packages...)
-	installCmd := exec.Command(pm.InstallCmd[0], args...)
+	install := pm.InstallCmd
+	if upgrade && len(pm.UpgradeCmd) > 0 {
+		reportf("⬆️  Upgrading the system with %s\n", pm.Name)
+		install = pm.UpgradeCmd
+	}
+	installCmd := pm.command(append(append([]string{}, install...), missing...), root)
 	
 	if err := installCmd.Run(); err != nil {
-		return fmt.Errorf("failed to install packages %v: %w", packages, err)
+		if len(pm.UpgradeCmd) > 0 && !upgrade {
+			return fmt.Errorf("failed to install packages %v: %w; upgrade the system first with '%s' or install with --system-upgrade",
+				missing, err, strings.Join(pm.commandArgs(pm.UpgradeCmd[:2], root), " "))
+		}
+		return fmt.Errorf("failed to install packages %v: %w", missing, err)
 	}
 	
-	fmt.Printf("✅ Successfully installed system packages: %s\n", strings.Join(packages, ", "))
+	reportf("✅ Successfully installed system packages: %s\n", strings.Join(missing, ", "))
 	return nil
 }
 
+// commandArgs returns the arguments that run a package manager command with
+// root privileges: through sudo unless gearbox runs as root. sudo resets the
+// environment, so the manager's variables are passed through env.
+func (pm *PackageManager) commandArgs(argv []string, root bool) []string {
+	if root {
+		return argv
+	}
+	args := []string{"sudo"}
+	if len(pm.Env) > 0 {
+		args = append(append(args, "env"), pm.Env...)
+	}
+	return append(args, argv...)
+}
+
+// command creates a package manager command that runs with root privileges
+func (pm *PackageManager) command(argv []string, root bool) *exec.Cmd {
+	args := pm.commandArgs(argv, root)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = append(os.Environ(), pm.Env...)
+	cmd.Stdin = os.Stdin // sudo may ask for a password
+	return cmd
+}
+
 // getSystemPackagesForManager returns the appropriate package list for the current package manager
-func (b *BundleConfig) getSystemPackagesForManager(managerName string) []string {
+func (b *BundleConfig) getSystemPackagesForManager(managerName string, mapping *PackageMapping) []string {
 	// First check if there's a manager-specific list
 	if packages, exists := b.PackageManagers[managerName]; exists {
 		return packages
 	}
 	
-	// Fall back to generic system_packages
-	return b.SystemPackages
+	// Fall back to generic system_packages, translated from canonical names
+	return mapping.Translate(b.SystemPackages, managerName)
 }
 
 // expandSystemPackages collects all system packages from a bundle and its included bundles
@@ -152,7 +536,7 @@ func (o *Orchestrator) expandSystemPackages(bundleName string, bundles []BundleC
 	}
 	
 	// Then add direct system packages
-	packages = append(packages, bundle.getSystemPackagesForManager(managerName)...)
+	packages = append(packages, bundle.getSystemPackagesForManager(managerName, o.packageMapping)...)
 	
 	// Remove duplicates while preserving order
 	seen := make(map[string]bool)
@@ -1,6 +1,8 @@
 package orchestrator
 
 import (
+	"fmt"
+	"os"
 	"runtime"
 	"strings"
 	"testing"
@@ -72,7 +74,7 @@ func TestGetSystemPackagesForManager(t *testing.T) {
 	
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := bundle.getSystemPackagesForManager(tt.manager)
+			result := bundle.getSystemPackagesForManager(tt.manager, nil)
 			
 			if len(result) != len(tt.expected) {
 				t.Errorf("expected %d packages, got %d", len(tt.expected), len(result))
@@ -245,4 +247,308 @@ func TestPackageDeduplication(t *testing.T) {
 	if len(result) != 3 {
 		t.Errorf("expected 3 unique packages, got %d", len(result))
 	}
-}
\ No newline at end of file
+}
+
+func TestPackageManagerDefinitions(t *testing.T) {
+	managers := packageManagers()
+	
+	if len(managers) != len(SupportedPackageManagers) {
+		t.Fatalf("expected %d package managers, got %d", len(SupportedPackageManagers), len(managers))
+	}
+	
+	for i, mgr := range managers {
+		if mgr.Name != SupportedPackageManagers[i] {
+			t.Errorf("expected manager %s at position %d, got %s", SupportedPackageManagers[i], i, mgr.Name)
+		}
+		if len(mgr.InstallCmd) == 0 || len(mgr.CheckCmd) == 0 {
+			t.Errorf("%s: install and check commands are required", mgr.Name)
+		}
+		// pacman only refreshes with a system upgrade; a separate -Sy would
+		// be a partial upgrade
+		if (len(mgr.UpdateCmd) == 0) != (mgr.Name == "pacman") {
+			t.Errorf("%s: unexpected update command %v", mgr.Name, mgr.UpdateCmd)
+		}
+		if (len(mgr.UpgradeCmd) > 0) != (mgr.Name == "pacman") || contains(mgr.InstallCmd, "-Syu") {
+			t.Errorf("%s: unexpected install command %v, upgrade command %v", mgr.Name, mgr.InstallCmd, mgr.UpgradeCmd)
+		}
+	}
+}
+
+func TestParseInstalledPackages(t *testing.T) {
+	tests := []struct {
+		name     string
+		manager  string
+		output   string
+		expected []string
+	}{
+		{
+			name:     "dpkg-query skips removed packages",
+			manager:  "apt",
+			output:   "installed git\nconfig-files vim\ninstalled curl\n",
+			expected: []string{"git", "curl"},
+		},
+		{
+			name:     "rpm ignores not installed messages",
+			manager:  "dnf",
+			output:   "git\npackage tree is not installed\ncurl\n",
+			expected: []string{"git", "curl"},
+		},
+		{
+			name:     "pacman quiet query",
+			manager:  "pacman",
+			output:   "git\ncurl\n",
+			expected: []string{"git", "curl"},
+		},
+		{
+			name:     "apk info",
+			manager:  "apk",
+			output:   "git\n",
+			expected: []string{"git"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseInstalledPackages(tt.manager, tt.output)
+			
+			if len(result) != len(tt.expected) {
+				t.Errorf("expected %d packages, got %d: %v", len(tt.expected), len(result), result)
+			}
+			for _, pkg := range tt.expected {
+				if !result[pkg] {
+					t.Errorf("expected package %s to be reported as installed", pkg)
+				}
+			}
+		})
+	}
+}
+
+func TestInstalledPackagesUsesExitStatus(t *testing.T) {
+	// Like rpm: installed names on stdout, the number of missing packages as
+	// exit status, and exit status 2 when the database cannot be read
+	script := `status=0
+for p; do
+	case $p in
+	git) echo git ;;
+	broken) echo "error: could not open the package database" >&2; exit 2 ;;
+	*) status=$((status+1)) ;;
+	esac
+done
+exit $status`
+	pm := &PackageManager{Name: "dnf", CheckCmd: []string{"sh", "-c", script, "sh"}}
+
+	installed, err := pm.installedPackages([]string{"git", "tree", "curl"})
+	if err != nil {
+		t.Fatalf("expected missing packages not to fail the query, got %v", err)
+	}
+	if len(installed) != 1 || !installed["git"] {
+		t.Errorf("expected only git to be installed, got %v", installed)
+	}
+
+	_, err = pm.installedPackages([]string{"git", "broken"})
+	if err == nil || !strings.Contains(err.Error(), "could not open the package database") {
+		t.Errorf("expected the query error, got %v", err)
+	}
+}
+
+func TestInstalledPackagesReportsQueryErrors(t *testing.T) {
+	pm := &PackageManager{Name: "apt", CheckCmd: []string{"gearbox-no-such-query"}}
+	if _, err := pm.installedPackages([]string{"git"}); err == nil {
+		t.Error("expected an error when the query cannot run")
+	}
+}
+
+func TestPackageCommandsRunWithSudo(t *testing.T) {
+	apt, _ := findPackageManager("apt")
+	install := append(append([]string{}, apt.InstallCmd...), "git")
+
+	want := "sudo env DEBIAN_FRONTEND=noninteractive apt-get install -y --no-install-recommends git"
+	if got := strings.Join(apt.commandArgs(install, false), " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := strings.Join(apt.commandArgs(install, true), " "); got != strings.Join(install, " ") {
+		t.Errorf("expected no sudo as root, got %q", got)
+	}
+
+	dnf, _ := findPackageManager("dnf")
+	if got := strings.Join(dnf.commandArgs(dnf.UpdateCmd, false), " "); got != "sudo dnf makecache -y" {
+		t.Errorf("unexpected update command %q", got)
+	}
+}
+
+func TestParseOwningPackage(t *testing.T) {
+	tests := []struct {
+		name     string
+		manager  string
+		output   string
+		expected string
+	}{
+		{"dpkg search", "apt", "fd-find: /usr/bin/fdfind\n", "fd-find"},
+		{"dpkg shared file", "apt", "ripgrep:amd64, other: /usr/bin/rg\n", "ripgrep"},
+		{"dpkg diversion", "apt", "diversion by foo from: /usr/bin/rg\n", ""},
+		{"rpm query", "dnf", "ripgrep\n", "ripgrep"},
+		{"rpm not owned", "dnf", "file /usr/local/bin/rg is not owned by any package\n", ""},
+		{"pacman quiet query", "pacman", "fd\n", "fd"},
+		{"apk owner", "apk", "/usr/bin/fd is owned by fd-9.0.0-r0\n", "fd"},
+		{"no output", "apt", "", ""},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := parseOwningPackage(tt.manager, tt.output); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPackageMappingTranslate(t *testing.T) {
+	mapping := &PackageMapping{
+		Toolchains: []string{"rust"},
+		Packages: map[string]map[string][]string{
+			"build-essential": {"pacman": {"base-devel"}, "zypper": {"gcc", "gcc-c++", "make"}},
+			"python3-venv":    {"pacman": {}},
+			"git":             {},
+		},
+	}
+	
+	tests := []struct {
+		name     string
+		manager  string
+		input    []string
+		expected []string
+	}{
+		{
+			name:     "canonical names on apt",
+			manager:  "apt",
+			input:    []string{"build-essential", "python3-venv", "git"},
+			expected: []string{"build-essential", "python3-venv", "git"},
+		},
+		{
+			name:     "renamed and not needed on pacman",
+			manager:  "pacman",
+			input:    []string{"build-essential", "python3-venv", "git"},
+			expected: []string{"base-devel", "git"},
+		},
+		{
+			name:     "one name expands to several",
+			manager:  "zypper",
+			input:    []string{"build-essential", "make"},
+			expected: []string{"gcc", "gcc-c++", "make"},
+		},
+		{
+			name:     "unmapped names pass through",
+			manager:  "apk",
+			input:    []string{"libfoo-dev"},
+			expected: []string{"libfoo-dev"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mapping.Translate(tt.input, tt.manager)
+			
+			if strings.Join(result, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+	
+	deps := mapping.SystemDependencies([]string{"rust", "build-essential", "libfoo-dev"})
+	if strings.Join(deps, ",") != "build-essential,libfoo-dev" {
+		t.Errorf("expected toolchains to be filtered from dependencies, got %v", deps)
+	}
+	
+	unmapped := mapping.Unmapped([]string{"git", "libfoo-dev", "@Development Tools"})
+	if strings.Join(unmapped, ",") != "libfoo-dev" {
+		t.Errorf("expected only libfoo-dev to be unmapped, got %v", unmapped)
+	}
+}
+
+func TestGetSystemPackagesForManagerTranslatesCanonicalNames(t *testing.T) {
+	mapping := &PackageMapping{
+		Packages: map[string]map[string][]string{
+			"golang-go": {"dnf": {"golang"}, "pacman": {"go"}},
+		},
+	}
+	bundle := BundleConfig{
+		Name:            "go-dev",
+		SystemPackages:  []string{"golang-go"},
+		PackageManagers: map[string][]string{"apk": {"go-override"}},
+	}
+	
+	if result := bundle.getSystemPackagesForManager("dnf", mapping); strings.Join(result, ",") != "golang" {
+		t.Errorf("expected [golang] for dnf, got %v", result)
+	}
+	if result := bundle.getSystemPackagesForManager("apt", mapping); strings.Join(result, ",") != "golang-go" {
+		t.Errorf("expected [golang-go] for apt, got %v", result)
+	}
+	// Explicit package_managers lists take precedence over the mapping
+	if result := bundle.getSystemPackagesForManager("apk", mapping); strings.Join(result, ",") != "go-override" {
+		t.Errorf("expected [go-override] for apk, got %v", result)
+	}
+}
+
+func TestValidatePackageManagerCoverage(t *testing.T) {
+	complete := make(map[string][]string)
+	for _, manager := range SupportedPackageManagers {
+		complete[manager] = []string{"git"}
+	}
+	
+	config := &BundleConfiguration{
+		Bundles: []BundleConfig{
+			{Name: "tools-only", Tools: []string{"fd"}},
+			{Name: "complete", PackageManagers: complete},
+			{
+				Name:           "apt-only",
+				SystemPackages: []string{"git"},
+				PackageManagers: map[string][]string{
+					"apt": {"git"},
+				},
+			},
+			{
+				Name: "unknown-manager",
+				PackageManagers: map[string][]string{
+					"apt": {}, "yum": {}, "dnf": {}, "pacman": {}, "zypper": {}, "apk": {}, "brew": {},
+				},
+			},
+		},
+	}
+	
+	errs := config.ValidatePackageManagerCoverage(nil)
+	
+	// apt-only has no mapping for 5 managers, unknown-manager has 1 unsupported entry
+	if len(errs) != 6 {
+		t.Fatalf("expected 6 validation errors, got %d: %v", len(errs), errs)
+	}
+	for _, err := range errs {
+		if !strings.Contains(err.Error(), "apt-only") && !strings.Contains(err.Error(), "unknown-manager") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
+
+func TestPacmanUpgradesOnlyWhenAsked(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("package commands run through sudo")
+	}
+	// The install fails; the upgrade succeeds
+	pm := &PackageManager{
+		Name:       "pacman",
+		InstallCmd: []string{"false"},
+		CheckCmd:   []string{"false"},
+		UpgradeCmd: []string{"true", "-Syu"},
+	}
+	var messages []string
+	reportf := func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}
+
+	err := pm.installPackages([]string{"git"}, false, false, reportf)
+	if err == nil || !strings.Contains(err.Error(), "upgrade the system first with 'true -Syu'") {
+		t.Errorf("expected the install to fail with a hint to upgrade, got %v", err)
+	}
+	if err := pm.installPackages([]string{"git"}, false, true, reportf); err != nil {
+		t.Errorf("expected the upgrade to install the packages, got %v", err)
+	}
+}
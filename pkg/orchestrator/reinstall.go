@@ -0,0 +1,106 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"gearbox/pkg/manifest"
+)
+
+// ErrNoDaemon is returned by DaemonInstaller when no daemon is running
+var ErrNoDaemon = errors.New("no gearbox daemon running")
+
+// DaemonInstaller installs tools as a job of the gearbox daemon with the
+// flags of the install command, and reports the events of the job. The
+// daemon package imports this one, so the orchestrator binary sets it.
+var DaemonInstaller func(tools, args []string, report func(Event)) error
+
+// reinstall installs tools again so that the manifest records them afresh.
+// Their records are removed first and put back for tools the installation
+// did not record again, so that a failed reinstall keeps them. The tools are
+// installed by the daemon when one is running, queued behind its other jobs.
+func (o *Orchestrator) reinstall(tools []string) error {
+	tracker, err := manifest.NewTracker()
+	if err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	records := make(map[string]*manifest.InstallationRecord)
+	for _, tool := range tools {
+		if record, found := tracker.GetInstallation(tool); found {
+			if err := tracker.Untrack(tool); err != nil {
+				return err
+			}
+			records[tool] = record
+		}
+	}
+
+	installErr := ErrNoDaemon
+	if DaemonInstaller != nil {
+		installErr = DaemonInstaller(tools, o.installArgs(), o.report)
+	}
+	if errors.Is(installErr, ErrNoDaemon) {
+		installErr = o.InstallTools(tools)
+	}
+
+	if len(records) > 0 {
+		tracker, err := manifest.NewTracker()
+		if err != nil {
+			o.reportf("⚠️  Failed to restore the records of %d tools: %v\n", len(records), err)
+			return installErr
+		}
+		for _, tool := range tools {
+			record, found := records[tool]
+			if !found || tracker.IsInstalled(tool) {
+				continue
+			}
+			if err := tracker.RestoreInstallation(tool, record); err != nil {
+				o.reportf("⚠️  Failed to restore the record of %s: %v\n", tool, err)
+				continue
+			}
+			o.reportf("↩️  Kept the previous record of %s\n", tool)
+		}
+	}
+	return installErr
+}
+
+// installArgs returns the flags of the install command for the options of
+// the orchestrator
+func (o *Orchestrator) installArgs() []string {
+	opts := o.options
+	var args []string
+	if opts.BuildType != "" {
+		args = append(args, "--build-type", opts.BuildType)
+	}
+	if opts.MaxParallelJobs > 0 {
+		args = append(args, "--jobs", strconv.Itoa(opts.MaxParallelJobs))
+	}
+	if opts.Mirror != "" {
+		// The daemon runs jobs in its own working directory
+		mirror, err := filepath.Abs(opts.Mirror)
+		if err != nil {
+			mirror = opts.Mirror
+		}
+		args = append(args, "--mirror", mirror)
+	}
+	flags := []struct {
+		name string
+		set  bool
+	}{
+		{"--force", opts.Force},
+		{"--skip-common-deps", opts.SkipCommonDeps},
+		{"--run-tests", opts.RunTests},
+		{"--no-shell", opts.NoShell},
+		{"--no-cache", opts.NoCache},
+		{"--skip-disk-check", opts.SkipDiskCheck},
+		{"--no-config", opts.NoConfig},
+		{"--system-upgrade", opts.SystemUpgrade},
+	}
+	for _, flag := range flags {
+		if flag.set {
+			args = append(args, flag.name)
+		}
+	}
+	return args
+}
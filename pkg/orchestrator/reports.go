@@ -0,0 +1,107 @@
+package orchestrator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gearbox/pkg/testreport"
+)
+
+// suiteRecorder passes installation events on and turns every finished tool
+// into a test case with the output of its installation script
+type suiteRecorder struct {
+	next   Reporter
+	mu     sync.Mutex
+	output map[string]*strings.Builder
+	suite  testreport.Suite
+}
+
+// newSuiteRecorder returns a recorder in front of the given reporter
+func newSuiteRecorder(next Reporter) *suiteRecorder {
+	return &suiteRecorder{
+		next:   next,
+		output: make(map[string]*strings.Builder),
+		suite:  testreport.Suite{Name: "install", Timestamp: time.Now()},
+	}
+}
+
+// Report records output and results before passing the event on
+func (r *suiteRecorder) Report(event Event) {
+	r.next.Report(event)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch event.Type {
+	case EventOutput:
+		if r.output[event.Tool] == nil {
+			r.output[event.Tool] = &strings.Builder{}
+		}
+		fmt.Fprintln(r.output[event.Tool], event.Message)
+	case EventToolFinished:
+		testCase := testreport.Case{
+			Name:     event.Tool,
+			Duration: seconds(event.Result.DurationSeconds),
+			Failure:  event.Result.Error,
+		}
+		if output := r.output[event.Tool]; output != nil {
+			testCase.Output = output.String()
+		}
+		r.suite.Add(testCase)
+	}
+}
+
+// doctorSuite turns the doctor checks into test cases. Warnings pass and
+// keep their message in the output.
+func doctorSuite(report DoctorReport) testreport.Suite {
+	suite := testreport.Suite{Name: "doctor", Timestamp: time.Now()}
+	for _, check := range report.Checks {
+		testCase := testreport.Case{Name: check.Name, Duration: check.Duration, Output: check.Message}
+		if check.Status == HealthWarn {
+			testCase.Output = "warning: " + check.Message
+		}
+		if check.Suggestion != "" {
+			testCase.Output += "\n" + check.Suggestion
+		}
+		if check.Status == HealthFail {
+			testCase.Failure = check.Message
+		}
+		suite.Add(testCase)
+	}
+	return suite
+}
+
+// addReportFlag adds the --report flag for CI test reports to a command
+func addReportFlag(cmd *cobra.Command, reports *[]string) {
+	cmd.Flags().StringArrayVar(reports, "report", nil, "Write a test report for CI: junit=<file> or tap[=<file>], stdout without a file (repeatable)")
+}
+
+// runWithReports runs a command and writes the test cases it returns to the
+// --report targets, also when the command fails. The command writes its text
+// output to out, which is stderr when a report is written to stdout.
+func runWithReports(specs []string, run func(out io.Writer) (testreport.Suite, error)) error {
+	targets, err := testreport.ParseTargets(specs)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if testreport.UsesStdout(targets) {
+		out = os.Stderr
+	}
+
+	suite, runErr := run(out)
+	if suite.Name == "" {
+		// Failed before checking anything
+		return runErr
+	}
+	if err := testreport.WriteAll(targets, suite, os.Stdout); err != nil {
+		return err
+	}
+	return runErr
+}
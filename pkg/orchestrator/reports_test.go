@@ -0,0 +1,75 @@
+package orchestrator
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gearbox/pkg/testreport"
+)
+
+func TestSuiteRecorderCollectsOutputPerTool(t *testing.T) {
+	forwarded := &eventRecorder{}
+	recorder := newSuiteRecorder(forwarded)
+
+	recorder.Report(Event{Type: EventOutput, Tool: "fd", Message: "Compiling fd"})
+	recorder.Report(Event{Type: EventOutput, Tool: "ffmpeg", Message: "configure: error"})
+	recorder.Report(Event{Type: EventToolFinished, Tool: "fd", Result: &ToolResult{Tool: "fd", Success: true, DurationSeconds: 90}})
+	recorder.Report(Event{Type: EventToolFinished, Tool: "ffmpeg", Result: &ToolResult{Tool: "ffmpeg", Error: "exit status 1"}})
+
+	if len(forwarded.events) != 4 {
+		t.Errorf("expected all events to be passed on, got %d", len(forwarded.events))
+	}
+	cases := recorder.suite.Cases
+	if len(cases) != 2 {
+		t.Fatalf("expected 2 test cases, got %+v", cases)
+	}
+	if cases[0].Name != "fd" || cases[0].Output != "Compiling fd\n" || cases[0].Duration != 90*time.Second || cases[0].Failure != "" {
+		t.Errorf("unexpected case %+v", cases[0])
+	}
+	if cases[1].Output != "configure: error\n" || cases[1].Failure != "exit status 1" {
+		t.Errorf("unexpected case %+v", cases[1])
+	}
+}
+
+func TestDoctorSuiteFailsOnlyFailedChecks(t *testing.T) {
+	var report DoctorReport
+	report.add(HealthCheckResult{Name: "configuration", Status: HealthPass, Message: "ok"})
+	report.add(HealthCheckResult{Name: "toolchain-go", Status: HealthWarn, Message: "go not found in PATH"})
+	report.add(HealthCheckResult{Name: "disk-space", Status: HealthFail, Message: "200 MB free", Suggestion: "Free space"})
+
+	suite := doctorSuite(report)
+	if suite.Failures() != 1 || suite.Cases[2].Failure != "200 MB free" {
+		t.Errorf("expected only disk-space to fail, got %+v", suite.Cases)
+	}
+	if suite.Cases[1].Output != "warning: go not found in PATH" {
+		t.Errorf("expected the warning in the output, got %q", suite.Cases[1].Output)
+	}
+}
+
+func TestRunWithReportsMovesTextOutputForStdoutReports(t *testing.T) {
+	for spec, want := range map[string]*os.File{"tap": os.Stderr, "tap=" + t.TempDir() + "/report.tap": os.Stdout} {
+		var got io.Writer
+		runWithReports([]string{spec}, func(out io.Writer) (testreport.Suite, error) {
+			got = out
+			return testreport.Suite{}, nil
+		})
+		if got != want {
+			t.Errorf("--report %s: text output went to the wrong stream", spec)
+		}
+	}
+}
+
+func TestPackageInstallsReportThroughTheReporter(t *testing.T) {
+	var messages []string
+	o := &Orchestrator{reporter: ReporterFunc(func(event Event) { messages = append(messages, event.Message) })}
+	pm := &PackageManager{Name: "apt"}
+	if err := pm.installPackages([]string{"git"}, true, false, o.reportf); err != nil {
+		t.Fatalf("installPackages() error = %v", err)
+	}
+	if len(messages) != 1 || !strings.Contains(messages[0], "Would install system packages (apt): git") {
+		t.Errorf("expected the dry run to be reported, got %q", messages)
+	}
+}
@@ -0,0 +1,147 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"syscall"
+)
+
+// systemMemoryReserveMB is left to the rest of the system when scheduling builds
+const systemMemoryReserveMB = 1024
+
+// defaultBuildMemoryMB is the memory per build job when neither tools.json
+// nor earlier builds say otherwise
+var defaultBuildMemoryMB = map[string]int{
+	"minimal":  200,  // 200MB per minimal build
+	"standard": 500,  // 500MB per standard build
+	"maximum":  1000, // 1GB per maximum build
+}
+
+// ResourceWeight is what a build uses while it runs
+type ResourceWeight struct {
+	MemoryMB int `json:"memory_mb"` // Peak memory per build job
+	CPUs     int `json:"cpus"`      // Build jobs the build keeps busy
+}
+
+// buildAllocation is what the scheduler gave a running build
+type buildAllocation struct {
+	Jobs     int // Inner build jobs (CARGO_BUILD_JOBS, make -j)
+	MemoryMB int
+}
+
+// resourceScheduler hands out memory and cores to concurrent builds, so that
+// heavy builds run with fewer neighbours and light ones fill the gaps
+type resourceScheduler struct {
+	memoryMB  int
+	cpus      int
+	maxBuilds int
+
+	usedMemoryMB int
+	usedCPUs     int
+	running      int
+}
+
+// newResourceScheduler creates a scheduler for the given memory, cores and
+// maximum number of concurrent builds
+func newResourceScheduler(memoryMB, cpus, maxBuilds int) *resourceScheduler {
+	return &resourceScheduler{
+		memoryMB:  max(memoryMB, 1),
+		cpus:      max(cpus, 1),
+		maxBuilds: max(maxBuilds, 1),
+	}
+}
+
+// reserve allocates resources for a build if they are free. A build that
+// does not fit the idle machine runs alone with fewer jobs.
+func (s *resourceScheduler) reserve(weight ResourceWeight) (buildAllocation, bool) {
+	if s.running >= s.maxBuilds {
+		return buildAllocation{}, false
+	}
+
+	memoryPerJob := max(weight.MemoryMB, 1)
+	jobs := min(max(weight.CPUs, 1), s.cpus)
+	if s.running == 0 {
+		jobs = max(1, min(jobs, s.memoryMB/memoryPerJob))
+	} else if s.usedCPUs+jobs > s.cpus || s.usedMemoryMB+jobs*memoryPerJob > s.memoryMB {
+		return buildAllocation{}, false
+	}
+
+	allocation := buildAllocation{Jobs: jobs, MemoryMB: jobs * memoryPerJob}
+	s.hold(allocation)
+	return allocation, true
+}
+
+// hold accounts for a build that already has its resources
+func (s *resourceScheduler) hold(allocation buildAllocation) {
+	s.running++
+	s.usedCPUs += allocation.Jobs
+	s.usedMemoryMB += allocation.MemoryMB
+}
+
+// release returns the resources of a finished build
+func (s *resourceScheduler) release(allocation buildAllocation) {
+	s.running--
+	s.usedCPUs -= allocation.Jobs
+	s.usedMemoryMB -= allocation.MemoryMB
+}
+
+// newBuildScheduler creates a scheduler for the memory and cores of this machine
+func (o *Orchestrator) newBuildScheduler() *resourceScheduler {
+	availableMemoryMB := getAvailableMemoryMB()
+	if availableMemoryMB <= 0 {
+		// Fallback: assume 4GB available memory
+		availableMemoryMB = 4096
+	}
+	return newResourceScheduler(availableMemoryMB-systemMemoryReserveMB, runtime.NumCPU(), o.options.MaxParallelJobs)
+}
+
+// resourceWeight returns the memory and cores a tool's build needs with a
+// build type: seeded from tools.json by tool or language, with the peak
+// memory of its last build taking precedence
+func (o *Orchestrator) resourceWeight(tool ToolConfig, buildType string) ResourceWeight {
+	weight, found := tool.Resources[buildType]
+	if !found {
+		if language, found := o.configMgr.GetConfig().Languages[tool.Language]; found {
+			weight = language.Resources[buildType]
+		}
+	}
+	if weight.MemoryMB == 0 {
+		weight.MemoryMB = defaultBuildMemoryMB[buildType]
+		if weight.MemoryMB == 0 {
+			weight.MemoryMB = 500 // default
+		}
+	}
+	if weight.CPUs == 0 {
+		weight.CPUs = 1
+	}
+
+	if o.buildStats != nil {
+		if record, found := o.buildStats.lookup(tool.Name, buildType); found && record.PeakMemoryMB > 0 {
+			weight.MemoryMB = record.PeakMemoryMB
+		}
+	}
+	return weight
+}
+
+// buildJobsEnv limits the parallelism inside a build to the jobs it was given
+func buildJobsEnv(jobs int) []string {
+	return []string{
+		fmt.Sprintf("GEARBOX_BUILD_JOBS=%d", jobs),
+		fmt.Sprintf("CARGO_BUILD_JOBS=%d", jobs),
+		fmt.Sprintf("MAKEFLAGS=-j%d", jobs),
+		fmt.Sprintf("CMAKE_BUILD_PARALLEL_LEVEL=%d", jobs),
+		fmt.Sprintf("GOMAXPROCS=%d", jobs),
+	}
+}
+
+// peakMemoryMB returns the peak memory of the largest process of a finished command
+func peakMemoryMB(cmd *exec.Cmd) int {
+	if cmd.ProcessState == nil {
+		return 0
+	}
+	if usage, ok := cmd.ProcessState.SysUsage().(*syscall.Rusage); ok {
+		return int(usage.Maxrss / 1024) // Maxrss is in KB on Linux
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+package orchestrator
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestResourceSchedulerReserve(t *testing.T) {
+	// 8 GB and 8 cores, at most 4 builds
+	s := newResourceScheduler(8192, 8, 4)
+
+	heavy := ResourceWeight{MemoryMB: 1500, CPUs: 8}
+	light := ResourceWeight{MemoryMB: 400, CPUs: 2}
+
+	// A heavy build alone gets as many jobs as fit into memory
+	first, ok := s.reserve(heavy)
+	if !ok || first.Jobs != 5 || first.MemoryMB != 7500 {
+		t.Fatalf("expected 5 jobs with 7500 MB, got %+v (%v)", first, ok)
+	}
+
+	// Nothing else fits next to it
+	if _, ok := s.reserve(light); ok {
+		t.Errorf("light build must wait while memory is taken")
+	}
+
+	s.release(first)
+
+	// Light builds share the machine up to the cores
+	var allocations []buildAllocation
+	for i := 0; i < 4; i++ {
+		allocation, ok := s.reserve(light)
+		if !ok {
+			t.Fatalf("light build %d did not start", i+1)
+		}
+		allocations = append(allocations, allocation)
+	}
+	if _, ok := s.reserve(light); ok {
+		t.Errorf("expected the build limit to stop a fifth build")
+	}
+	if s.usedCPUs != 8 || s.usedMemoryMB != 3200 {
+		t.Errorf("expected 8 cores and 3200 MB in use, got %d and %d", s.usedCPUs, s.usedMemoryMB)
+	}
+
+	for _, allocation := range allocations {
+		s.release(allocation)
+	}
+	if s.running != 0 || s.usedCPUs != 0 || s.usedMemoryMB != 0 {
+		t.Errorf("expected everything released, got %+v", s)
+	}
+}
+
+func TestResourceWeight(t *testing.T) {
+	o := &Orchestrator{
+		configMgr: &ConfigManager{config: Config{
+			Languages: map[string]LanguageConfig{
+				"rust": {Resources: map[string]ResourceWeight{"maximum": {MemoryMB: 1500, CPUs: 8}}},
+			},
+		}},
+		buildStats: loadBuildStats(filepath.Join(t.TempDir(), buildStatsFile)),
+		options:    InstallationOptions{BuildType: "maximum"},
+	}
+
+	fd := ToolConfig{Name: "fd", Language: "rust"}
+	uv := ToolConfig{Name: "uv", Language: "rust", Resources: map[string]ResourceWeight{"maximum": {MemoryMB: 2500, CPUs: 8}}}
+	bun := ToolConfig{Name: "bun", Language: "javascript"}
+
+	if got := o.resourceWeight(fd, "maximum"); got != (ResourceWeight{MemoryMB: 1500, CPUs: 8}) {
+		t.Errorf("language default: got %+v", got)
+	}
+	if got := o.resourceWeight(uv, "maximum"); got != (ResourceWeight{MemoryMB: 2500, CPUs: 8}) {
+		t.Errorf("tool seed: got %+v", got)
+	}
+	if got := o.resourceWeight(bun, "maximum"); got != (ResourceWeight{MemoryMB: defaultBuildMemoryMB["maximum"], CPUs: 1}) {
+		t.Errorf("fallback: got %+v", got)
+	}
+
+	// The peak memory of the last build replaces the seed
+	o.buildStats.recordMemory("fd", "maximum", 900)
+	if got := o.resourceWeight(fd, "maximum"); got != (ResourceWeight{MemoryMB: 900, CPUs: 8}) {
+		t.Errorf("measured: got %+v", got)
+	}
+}
+
+func TestPeakMemoryMB(t *testing.T) {
+	// The shell holds about 20 MB in a variable
+	cmd := exec.Command("sh", "-c", `x=$(head -c 20000000 /dev/zero | tr '\0' a); echo ${#x} >/dev/null`)
+	if err := cmd.Run(); err != nil {
+		t.Skipf("shell not available: %v", err)
+	}
+	if got := peakMemoryMB(cmd); got < 15 {
+		t.Errorf("expected a peak of at least 15 MB, got %d", got)
+	}
+	if peakMemoryMB(exec.Command("true")) != 0 {
+		t.Errorf("expected no measurement before the command ran")
+	}
+}
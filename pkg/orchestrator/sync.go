@@ -0,0 +1,362 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gearbox/pkg/manifest"
+)
+
+// Kinds of disagreement between the manifest and the system
+const (
+	MismatchMissing   = "missing"   // Tracked, but the tool is gone
+	MismatchUntracked = "untracked" // Installed, but not tracked
+	MismatchVersion   = "version"   // The tool reports another version than recorded
+	MismatchMoved     = "moved"     // The recorded binaries are gone, but PATH finds the tool elsewhere
+)
+
+// Ways to resolve a mismatch
+const (
+	SyncAsk       = "ask"       // Ask for each mismatch
+	SyncUpdate    = "update"    // Change the manifest to match the system
+	SyncReinstall = "reinstall" // Reinstall the tool, which records it again
+	SyncUntrack   = "untrack"   // Remove the tool from the manifest, leaving its files
+	SyncIgnore    = "ignore"    // Change nothing
+)
+
+// SyncOptions controls 'sync'
+type SyncOptions struct {
+	Tools  []string // Tools to reconcile, all when empty
+	Policy string   // SyncAsk, or the action taken for every mismatch
+}
+
+// Mismatch is a disagreement between the manifest and the system
+type Mismatch struct {
+	Tool      string
+	Kind      string
+	Recorded  string   // Recorded version or binary path
+	Found     string   // Version or binary path on the system
+	Paths     []string // Binary paths on the system, for moved tools
+	BuildType string   // Recorded build type, used to reinstall
+
+	candidate *AdoptionCandidate // How an untracked tool is adopted
+}
+
+// String describes the mismatch in one line
+func (m Mismatch) String() string {
+	switch m.Kind {
+	case MismatchMissing:
+		if m.Recorded == "" {
+			return "tracked, but not found on PATH"
+		}
+		return fmt.Sprintf("tracked, but %s is missing", m.Recorded)
+	case MismatchUntracked:
+		return fmt.Sprintf("installed at %s (%s), but not tracked", m.Found, m.candidate.Method)
+	case MismatchVersion:
+		return fmt.Sprintf("version %s, recorded %s", m.Found, m.Recorded)
+	case MismatchMoved:
+		return fmt.Sprintf("moved from %s to %s", m.Recorded, m.Found)
+	default:
+		return m.Kind
+	}
+}
+
+// FindMismatches compares the manifest with the system: tracked tools that
+// are gone or moved, or report another version than recorded, and catalog
+// tools installed but not tracked
+func (o *Orchestrator) FindMismatches(toolNames []string) ([]Mismatch, error) {
+	m := manifest.NewManifest()
+	if manager := manifest.NewManager(); manager.Exists() {
+		loaded, err := manager.Load()
+		if err != nil {
+			return nil, err
+		}
+		m = loaded
+	}
+
+	var tracked, untracked []string
+	for _, name := range toolNames {
+		if _, found := m.Installations[name]; found {
+			tracked = append(tracked, name)
+		} else if _, found := o.findTool(name); found {
+			untracked = append(untracked, name)
+		} else {
+			return nil, fmt.Errorf("tool not found: %s", name)
+		}
+	}
+	if len(toolNames) == 0 {
+		for name, record := range m.Installations {
+			if record.Method != manifest.MethodBundle {
+				tracked = append(tracked, name)
+			}
+		}
+		sort.Strings(tracked)
+	}
+
+	var mismatches []Mismatch
+	for _, name := range tracked {
+		if mismatch, found := o.trackedMismatch(name, m.Installations[name]); found {
+			mismatches = append(mismatches, mismatch)
+		}
+	}
+
+	if len(toolNames) == 0 || len(untracked) > 0 {
+		candidates, err := o.FindAdoptable(untracked)
+		if err != nil {
+			return nil, err
+		}
+		for i := range candidates {
+			c := &candidates[i]
+			mismatches = append(mismatches, Mismatch{
+				Tool:      c.Tool.Name,
+				Kind:      MismatchUntracked,
+				Found:     c.Path,
+				candidate: c,
+			})
+		}
+	}
+
+	sort.SliceStable(mismatches, func(i, j int) bool {
+		return mismatches[i].Tool < mismatches[j].Tool
+	})
+	return mismatches, nil
+}
+
+// trackedMismatch compares a tracked tool with the system
+func (o *Orchestrator) trackedMismatch(name string, record *manifest.InstallationRecord) (Mismatch, bool) {
+	tool, inCatalog := o.findTool(name)
+
+	var recorded []string
+	for _, path := range record.BinaryPaths {
+		if path != "" {
+			recorded = append(recorded, path)
+		}
+	}
+	var existing []string
+	for _, path := range recorded {
+		if _, err := os.Stat(path); err == nil {
+			existing = append(existing, path)
+		}
+	}
+
+	if len(existing) == 0 {
+		if !inCatalog && len(recorded) == 0 {
+			return Mismatch{}, false
+		}
+		mismatch := Mismatch{Tool: name, Kind: MismatchMissing, BuildType: record.BuildType}
+		if len(recorded) > 0 {
+			mismatch.Recorded = recorded[0]
+			// Nerd Fonts are fonts, not binaries
+			if found, err := exec.LookPath(tool.BinaryName); inCatalog && tool.Name != "nerd-fonts" && err == nil {
+				if abs, err := filepath.Abs(found); err == nil {
+					found = abs
+				}
+				mismatch.Kind = MismatchMoved
+				mismatch.Found = found
+				mismatch.Paths = movedPaths(recorded, found)
+			}
+			return mismatch, true
+		}
+		// Tracked without binaries, so only the catalog tells
+		return mismatch, !isToolInstalled(tool)
+	}
+
+	if !inCatalog || !isToolInstalled(tool) {
+		return Mismatch{}, false
+	}
+	version := extractVersionFromOutput(record.Version)
+	if !knownVersion(version) {
+		return Mismatch{}, false
+	}
+	if live := toolVersionAt(tool, existing[0]); knownVersion(live) && live != version {
+		return Mismatch{Tool: name, Kind: MismatchVersion, Recorded: version, Found: live, BuildType: record.BuildType}, true
+	}
+	return Mismatch{}, false
+}
+
+// movedPaths returns where the recorded binaries of a tool are now, looking
+// for the other binaries next to the one PATH found
+func movedPaths(recorded []string, found string) []string {
+	paths := []string{found}
+	for _, path := range recorded {
+		moved := filepath.Join(filepath.Dir(found), filepath.Base(path))
+		if moved == found {
+			continue
+		}
+		if _, err := os.Stat(moved); err == nil {
+			paths = append(paths, moved)
+		}
+	}
+	return paths
+}
+
+// Sync reconciles the manifest with the system. It lists every mismatch and
+// resolves each one by asking, or with the policy for scripts: update the
+// manifest, reinstall the tool, untrack it or ignore the mismatch.
+func (o *Orchestrator) Sync(opts SyncOptions) error {
+	switch opts.Policy {
+	case "":
+		opts.Policy = SyncAsk
+	case SyncAsk, SyncUpdate, SyncReinstall, SyncUntrack, SyncIgnore:
+	default:
+		return fmt.Errorf("invalid --policy %q (use %s, %s, %s, %s or %s)", opts.Policy, SyncAsk, SyncUpdate, SyncReinstall, SyncUntrack, SyncIgnore)
+	}
+
+	mismatches, err := o.FindMismatches(opts.Tools)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔄 Manifest and System Mismatches\n")
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	if len(mismatches) == 0 {
+		fmt.Printf("✅ The manifest matches the system\n")
+		return nil
+	}
+	for _, mismatch := range mismatches {
+		fmt.Printf("❌ %-15s %-10s %s\n", mismatch.Tool, mismatch.Kind, mismatch)
+	}
+	fmt.Println()
+
+	actions := make([]string, len(mismatches))
+	for i, mismatch := range mismatches {
+		action := opts.Policy
+		if action == SyncAsk {
+			action = askSync(mismatch)
+		}
+		if action == SyncUntrack && mismatch.Kind == MismatchUntracked {
+			action = SyncIgnore
+		}
+		actions[i] = action
+	}
+
+	counts := make(map[string]int)
+	for _, action := range actions {
+		counts[action]++
+	}
+	if counts[SyncIgnore] < len(mismatches) {
+		tracker, err := manifest.NewTracker()
+		if err != nil {
+			return fmt.Errorf("failed to load manifest: %w", err)
+		}
+		if err := tracker.CreateSnapshot("sync"); err != nil {
+			fmt.Printf("⚠️  Failed to back up the manifest: %v\n", err)
+		}
+
+		for i, mismatch := range mismatches {
+			if err := applySync(tracker, mismatch, actions[i]); err != nil {
+				fmt.Printf("❌ %s: %v\n", mismatch.Tool, err)
+				counts[actions[i]]--
+				counts[SyncIgnore]++
+				actions[i] = SyncIgnore
+				continue
+			}
+			switch actions[i] {
+			case SyncUpdate:
+				fmt.Printf("✅ %s updated in the manifest\n", mismatch.Tool)
+			case SyncUntrack:
+				fmt.Printf("✅ %s untracked\n", mismatch.Tool)
+			}
+		}
+		fmt.Println()
+	}
+
+	var reinstall []Mismatch
+	for i, mismatch := range mismatches {
+		if actions[i] == SyncReinstall {
+			reinstall = append(reinstall, mismatch)
+		}
+	}
+	var installErr error
+	if len(reinstall) > 0 {
+		installErr = o.reinstallMismatched(reinstall)
+	}
+
+	fmt.Printf("📈 Synced %d mismatches: %d updated, %d reinstalled, %d untracked, %d ignored\n",
+		len(mismatches), counts[SyncUpdate], counts[SyncReinstall], counts[SyncUntrack], counts[SyncIgnore])
+	return installErr
+}
+
+// askSync asks how to resolve a mismatch, ignoring it by default
+func askSync(mismatch Mismatch) string {
+	prompt, choices := "[u]pdate manifest, [r]einstall, un[t]rack or [i]gnore?", "[u/r/t/I]"
+	if mismatch.Kind == MismatchUntracked {
+		prompt, choices = "[u]pdate manifest, [r]einstall or [i]gnore?", "[u/r/I]"
+	}
+	fmt.Printf("%s (%s): %s %s: ", mismatch.Tool, mismatch.Kind, prompt, choices)
+	var response string
+	fmt.Scanln(&response)
+	switch strings.ToLower(response) {
+	case "u", "update":
+		return SyncUpdate
+	case "r", "reinstall":
+		return SyncReinstall
+	case "t", "untrack":
+		return SyncUntrack
+	default:
+		return SyncIgnore
+	}
+}
+
+// applySync changes the manifest for a mismatch. Tools to reinstall keep
+// their records until reinstallMismatched replaces them.
+func applySync(tracker *manifest.Tracker, mismatch Mismatch, action string) error {
+	switch action {
+	case SyncUpdate:
+		switch mismatch.Kind {
+		case MismatchMissing:
+			return tracker.Untrack(mismatch.Tool)
+		case MismatchUntracked:
+			c := mismatch.candidate
+			return adoptTool(tracker, *c, c.managedByDefault())
+		case MismatchVersion:
+			return tracker.UpdateInstallation(mismatch.Tool, mismatch.Found, nil)
+		case MismatchMoved:
+			return tracker.UpdateInstallation(mismatch.Tool, "", mismatch.Paths)
+		}
+	case SyncUntrack:
+		if mismatch.Kind != MismatchUntracked {
+			return tracker.Untrack(mismatch.Tool)
+		}
+	}
+	return nil
+}
+
+// reinstallMismatched reinstalls tools, grouped by their recorded build type
+func (o *Orchestrator) reinstallMismatched(mismatches []Mismatch) error {
+	defaultBuildType := o.configMgr.GetConfig().DefaultBuildType
+	groups := make(map[string][]string)
+	for _, mismatch := range mismatches {
+		buildType := mismatch.BuildType
+		if !isValidBuildType(buildType) {
+			buildType = defaultBuildType
+		}
+		groups[buildType] = append(groups[buildType], mismatch.Tool)
+	}
+
+	var buildTypes []string
+	for buildType := range groups {
+		buildTypes = append(buildTypes, buildType)
+	}
+	sort.Strings(buildTypes)
+
+	// Untracked tools are still installed
+	o.options.Force = true
+	for i, buildType := range buildTypes {
+		o.options.BuildType = buildType
+		o.results = o.results[:0]
+		if i > 0 {
+			// Common dependencies only need to be installed once
+			o.options.SkipCommonDeps = true
+		}
+
+		if err := o.reinstall(groups[buildType]); err != nil {
+			return fmt.Errorf("failed to reinstall %s tools: %w", buildType, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package orchestrator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gearbox/pkg/manifest"
+)
+
+// setupSyncMismatches tracks tools that disagree with the system in every way
+func setupSyncMismatches(t *testing.T) (*Orchestrator, string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	bin := filepath.Join(home, "bin")
+	for _, name := range []string{"gearbox-version", "gearbox-moved", "gearbox-untracked"} {
+		writeFile(t, filepath.Join(bin, name), "#!/bin/sh\necho '"+name+" 2.0.0'\n")
+		os.Chmod(filepath.Join(bin, name), 0755)
+	}
+	t.Setenv("PATH", bin+string(filepath.ListSeparator)+os.Getenv("PATH"))
+
+	m := manifest.NewManifest()
+	m.AddInstallation("gearbox-version", &manifest.InstallationRecord{
+		Method: manifest.MethodSourceBuild, Version: "1.0.0", BinaryPaths: []string{filepath.Join(bin, "gearbox-version")},
+	})
+	m.AddInstallation("gearbox-moved", &manifest.InstallationRecord{
+		Method: manifest.MethodSourceBuild, Version: "2.0.0", BinaryPaths: []string{"/gone/gearbox-moved"},
+	})
+	m.AddInstallation("gearbox-missing", &manifest.InstallationRecord{
+		Method: manifest.MethodSourceBuild, Version: "1.0.0", BinaryPaths: []string{"/gone/gearbox-missing"},
+	})
+	if err := manifest.NewManager().Save(m); err != nil {
+		t.Fatal(err)
+	}
+
+	var tools []ToolConfig
+	for _, name := range []string{"gearbox-version", "gearbox-moved", "gearbox-missing", "gearbox-untracked"} {
+		tools = append(tools, ToolConfig{Name: name, BinaryName: name, TestCommand: "--version"})
+	}
+	return &Orchestrator{configMgr: &ConfigManager{config: Config{Tools: tools}}}, bin
+}
+
+func TestFindMismatches(t *testing.T) {
+	o, bin := setupSyncMismatches(t)
+
+	mismatches, err := o.FindMismatches(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]Mismatch{
+		"gearbox-missing":   {Kind: MismatchMissing, Recorded: "/gone/gearbox-missing"},
+		"gearbox-moved":     {Kind: MismatchMoved, Recorded: "/gone/gearbox-moved", Found: filepath.Join(bin, "gearbox-moved")},
+		"gearbox-untracked": {Kind: MismatchUntracked, Found: filepath.Join(bin, "gearbox-untracked")},
+		"gearbox-version":   {Kind: MismatchVersion, Recorded: "1.0.0", Found: "2.0.0"},
+	}
+	if len(mismatches) != len(want) {
+		t.Fatalf("expected %d mismatches, got %+v", len(want), mismatches)
+	}
+	for _, mismatch := range mismatches {
+		expected := want[mismatch.Tool]
+		if mismatch.Kind != expected.Kind || mismatch.Recorded != expected.Recorded || mismatch.Found != expected.Found {
+			t.Errorf("%s: expected %+v, got %+v", mismatch.Tool, expected, mismatch)
+		}
+	}
+
+	if _, err := o.FindMismatches([]string{"gearbox-unknown"}); err == nil {
+		t.Error("expected an error for a tool that is neither tracked nor in the catalog")
+	}
+	if mismatches, _ := o.FindMismatches([]string{"gearbox-version"}); len(mismatches) != 1 {
+		t.Errorf("expected only the named tool, got %+v", mismatches)
+	}
+}
+
+func TestSyncUpdatePolicyMakesTheManifestMatchTheSystem(t *testing.T) {
+	o, bin := setupSyncMismatches(t)
+
+	if err := o.Sync(SyncOptions{Policy: SyncUpdate}); err != nil {
+		t.Fatal(err)
+	}
+	m, err := manifest.NewManager().Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.IsInstalled("gearbox-missing") {
+		t.Error("expected the missing tool to be untracked")
+	}
+	if record, _ := m.GetInstallation("gearbox-version"); record.Version != "2.0.0" {
+		t.Errorf("expected the version to be updated, got %s", record.Version)
+	}
+	if record, _ := m.GetInstallation("gearbox-moved"); len(record.BinaryPaths) != 1 || record.BinaryPaths[0] != filepath.Join(bin, "gearbox-moved") || len(record.Files) != 1 {
+		t.Errorf("expected the moved binary to be recorded, got %v %+v", record.BinaryPaths, record.Files)
+	}
+	if record, found := m.GetInstallation("gearbox-untracked"); !found || record.Method != manifest.MethodPreExisting {
+		t.Errorf("expected the untracked tool to be adopted as pre-existing, got %+v", record)
+	}
+
+	if mismatches, _ := o.FindMismatches(nil); len(mismatches) != 0 {
+		t.Errorf("expected no mismatches after syncing, got %+v", mismatches)
+	}
+}
+
+func TestSyncReinstallKeepsTheRecordsOfToolsThatFailToReinstall(t *testing.T) {
+	o, _ := setupSyncMismatches(t)
+	var submitted [][]string
+	DaemonInstaller = func(tools, args []string, report func(Event)) error {
+		submitted = append(submitted, append(append([]string(nil), tools...), args...))
+		if tools[0] == "gearbox-moved" {
+			tracker, err := manifest.NewTracker()
+			if err != nil {
+				return err
+			}
+			return tracker.TrackInstallation("gearbox-moved", manifest.TrackingConfig{Method: manifest.MethodSourceBuild, Version: "3.0.0"})
+		}
+		return errors.New("build failed")
+	}
+	defer func() { DaemonInstaller = nil }()
+
+	if err := o.Sync(SyncOptions{Policy: SyncReinstall, Tools: []string{"gearbox-version"}}); err == nil {
+		t.Error("expected the failed reinstall to be reported")
+	}
+	if err := o.Sync(SyncOptions{Policy: SyncReinstall, Tools: []string{"gearbox-moved"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(submitted) != 2 || submitted[0][0] != "gearbox-version" || !contains(submitted[0], "--force") {
+		t.Errorf("expected forced reinstalls to be submitted to the daemon, got %v", submitted)
+	}
+
+	m, err := manifest.NewManager().Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record, found := m.GetInstallation("gearbox-version"); !found || record.Version != "1.0.0" {
+		t.Errorf("expected the record of the failed reinstall to be kept, got %+v", record)
+	}
+	if record, found := m.GetInstallation("gearbox-moved"); !found || record.Version != "3.0.0" {
+		t.Errorf("expected the reinstalled tool to be recorded afresh, got %+v", record)
+	}
+}
+
+func TestSyncRejectsUnknownPolicies(t *testing.T) {
+	o := &Orchestrator{configMgr: &ConfigManager{}}
+	if err := o.Sync(SyncOptions{Policy: "delete"}); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
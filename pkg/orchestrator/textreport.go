@@ -0,0 +1,236 @@
+package orchestrator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/schollz/progressbar/v3"
+)
+
+// textReporter renders events for a terminal with a progress bar
+type textReporter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	verbose bool
+	bar     *progressbar.ProgressBar
+}
+
+// NewTextReporter returns the reporter of the command line. Script output and
+// build starts are only shown when verbose is set.
+func NewTextReporter(w io.Writer, verbose bool) Reporter {
+	return &textReporter{out: w, verbose: verbose}
+}
+
+// Report prints the event
+func (r *textReporter) Report(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch event.Type {
+	case EventPlan:
+		r.showHeader(event.Plan)
+		r.showSuggestions(event.Plan)
+		if event.Plan.DryRun {
+			r.showDryRun(event.Plan)
+		} else {
+			r.showPlan(event.Plan)
+		}
+	case EventMessage:
+		fmt.Fprint(r.out, event.Message)
+	case EventToolStarted:
+		if r.verbose {
+			fmt.Fprintf(r.out, "▶️  Starting %s (%d jobs, ~%s memory)\n", event.Tool, event.Build.Jobs, formatMB(int64(event.Build.MemoryMB)))
+		}
+	case EventOutput:
+		if r.verbose {
+			fmt.Fprintln(r.out, event.Message)
+		}
+	case EventProgress:
+		r.showProgress(event.Progress)
+	case EventSummary:
+		if r.bar != nil {
+			r.bar.Close()
+			r.bar = nil
+		}
+		r.showSummary(event.Summary)
+	}
+}
+
+// showHeader prints the number of tools and the bundles they come from
+func (r *textReporter) showHeader(plan *InstallPlan) {
+	if len(plan.Bundles) == 0 {
+		fmt.Fprintf(r.out, "🔧 Gearbox Orchestrator - Installing %d tools\n\n", len(plan.Tools))
+		return
+	}
+
+	fmt.Fprintf(r.out, "🔧 Gearbox Orchestrator - Installing %d tools", len(plan.Tools))
+	if len(plan.Bundles) == 1 {
+		for bundleName := range plan.Bundles {
+			fmt.Fprintf(r.out, " (bundle: %s)", bundleName)
+		}
+	} else {
+		fmt.Fprintf(r.out, " (from %d bundles)", len(plan.Bundles))
+	}
+	if len(plan.DirectTools) > 0 {
+		fmt.Fprintf(r.out, " + %d direct tools", len(plan.DirectTools))
+	}
+	fmt.Fprintf(r.out, "\n\n")
+
+	// Show bundle breakdown
+	for bundleName, tools := range plan.Bundles {
+		fmt.Fprintf(r.out, "📦 Bundle '%s': %d tools\n", bundleName, len(tools))
+	}
+	if len(plan.DirectTools) > 0 {
+		fmt.Fprintf(r.out, "🔧 Direct tools: %d\n", len(plan.DirectTools))
+	}
+	fmt.Fprintf(r.out, "\n")
+}
+
+// showSuggestions prints related tools worth adding
+func (r *textReporter) showSuggestions(plan *InstallPlan) {
+	if len(plan.Suggestions) == 0 {
+		return
+	}
+
+	fmt.Fprintf(r.out, "\n💡 Related Tool Suggestions\n")
+	fmt.Fprintf(r.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	for _, suggestion := range plan.Suggestions {
+		fmt.Fprintf(r.out, "   %s\n", suggestion)
+	}
+	fmt.Fprintf(r.out, "\n   Use: gearbox install <additional_tools>\n\n")
+}
+
+// showPlan prints the plan of an installation that is about to start
+func (r *textReporter) showPlan(plan *InstallPlan) {
+	fmt.Fprintf(r.out, "📋 Installation Plan\n")
+	fmt.Fprintf(r.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Fprintf(r.out, "Build Type: %s\n", plan.BuildType)
+	fmt.Fprintf(r.out, "Parallel Jobs: %d\n", plan.ParallelJobs)
+	fmt.Fprintf(r.out, "Build Resources: %s memory, %d cores\n", formatMB(plan.MemoryMB), plan.CPUs)
+	fmt.Fprintf(r.out, "Total Tools: %d\n\n", len(plan.Tools))
+
+	// Group by language in installation order
+	var languages []string
+	languageGroups := make(map[string][]string)
+	for _, tool := range plan.Tools {
+		if _, found := languageGroups[tool.Language]; !found {
+			languages = append(languages, tool.Language)
+		}
+		languageGroups[tool.Language] = append(languageGroups[tool.Language], tool.Name)
+	}
+
+	for _, lang := range languages {
+		fmt.Fprintf(r.out, "📦 %s (%d tools): %s\n", strings.Title(lang), len(languageGroups[lang]), strings.Join(languageGroups[lang], ", "))
+	}
+	fmt.Fprintln(r.out)
+	r.showEstimates(plan)
+	fmt.Fprintln(r.out)
+}
+
+// showDryRun prints the plan of a dry run with the settings and the
+// estimates of every tool
+func (r *textReporter) showDryRun(plan *InstallPlan) {
+	fmt.Fprintf(r.out, "🔍 Dry Run - Installation Plan\n\n")
+	fmt.Fprintf(r.out, "Build Type: %s\n", plan.BuildType)
+	fmt.Fprintf(r.out, "Max Parallel Jobs: %d\n", plan.ParallelJobs)
+	fmt.Fprintf(r.out, "Skip Common Deps: %v\n", plan.SkipCommonDeps)
+	fmt.Fprintf(r.out, "Run Tests: %v\n", plan.RunTests)
+	fmt.Fprintf(r.out, "Shell Integration: %v\n", plan.ShellIntegration)
+
+	if len(plan.SystemPackages) > 0 {
+		fmt.Fprintf(r.out, "\nSystem packages that would be installed (%s):\n", plan.PackageManager)
+		for _, pkg := range plan.SystemPackages {
+			fmt.Fprintf(r.out, "  - %s\n", pkg)
+		}
+	}
+
+	fmt.Fprintf(r.out, "\nInstallation Order:\n")
+	for i, tool := range plan.Tools {
+		buildFlag := tool.BuildFlag
+		if buildFlag == "" {
+			buildFlag = "(default)"
+		}
+		fmt.Fprintf(r.out, "  %2d. %-15s (%s) - Build flag: %s, disk: ~%s, time: ~%s\n",
+			i+1, tool.Name, tool.Language, buildFlag, formatMB(tool.DiskMB), FormatEstimate(seconds(tool.EstimatedSeconds)))
+	}
+
+	fmt.Fprintf(r.out, "\nTotal tools to install: %d\n", len(plan.Tools))
+	r.showEstimates(plan)
+}
+
+// showEstimates prints the estimated disk usage per filesystem and the
+// estimated installation time
+func (r *textReporter) showEstimates(plan *InstallPlan) {
+	for _, req := range plan.Disk {
+		fmt.Fprintf(r.out, "💾 Disk Space: ~%s needed, %s free (%s)\n", formatMB(req.RequiredMB), formatMB(req.FreeMB), strings.Join(req.Paths, ", "))
+	}
+
+	fmt.Fprintf(r.out, "⏱️  Estimated Time: ~%s (%d of %d builds measured)\n", FormatEstimate(seconds(plan.EstimatedSeconds)), plan.MeasuredBuilds, len(plan.Tools))
+	if len(plan.ByBuildType) == 0 {
+		return
+	}
+	var estimates []string
+	for _, buildType := range buildTypes {
+		estimates = append(estimates, fmt.Sprintf("%s ~%s", buildType, FormatEstimate(seconds(plan.ByBuildType[buildType]))))
+	}
+	fmt.Fprintf(r.out, "   By build type: %s\n", strings.Join(estimates, ", "))
+}
+
+// showProgress advances the progress bar, creating it on the first event
+func (r *textReporter) showProgress(progress *Progress) {
+	if r.bar == nil {
+		r.bar = progressbar.NewOptions(progress.Total,
+			progressbar.OptionSetWriter(r.out),
+			progressbar.OptionSetDescription("Installing tools"),
+			progressbar.OptionSetWidth(50),
+			progressbar.OptionShowCount(),
+			progressbar.OptionShowIts(),
+			progressbar.OptionSetPredictTime(false), // Replaced by the learned build times
+			progressbar.OptionSetTheme(progressbar.Theme{
+				Saucer:        "█",
+				SaucerHead:    "█",
+				SaucerPadding: "░",
+				BarStart:      "[",
+				BarEnd:        "]",
+			}))
+	}
+
+	if progress.Completed < progress.Total {
+		r.bar.Describe(fmt.Sprintf("Installing tools (~%s left)", FormatEstimate(seconds(progress.ETASeconds))))
+	}
+	r.bar.Set(progress.Completed)
+}
+
+// showSummary prints the result of every tool and the totals
+func (r *textReporter) showSummary(summary *InstallSummary) {
+	fmt.Fprintf(r.out, "\n\n📊 Installation Results\n")
+	fmt.Fprintf(r.out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+
+	for _, result := range summary.Results {
+		if result.Success {
+			fmt.Fprintf(r.out, "✅ %-15s (%6.1fs) - %s\n", result.Tool, result.DurationSeconds, result.Description)
+		} else {
+			fmt.Fprintf(r.out, "❌ %-15s (%6.1fs) - %s\n", result.Tool, result.DurationSeconds, result.Error)
+		}
+	}
+
+	fmt.Fprintf(r.out, "\n📈 Summary\n")
+	fmt.Fprintf(r.out, "Successful: %d\n", summary.Successful)
+	fmt.Fprintf(r.out, "Failed: %d\n", summary.Failed)
+	fmt.Fprintf(r.out, "Total Duration: %.1fs\n", summary.TotalSeconds)
+	if len(summary.Results) > 0 {
+		fmt.Fprintf(r.out, "Average Duration: %.1fs\n", summary.TotalSeconds/float64(len(summary.Results)))
+	}
+
+	if summary.Failed == 0 {
+		fmt.Fprintf(r.out, "\n🎉 All tools installed successfully!\n")
+	}
+}
+
+// seconds converts reported seconds back to a duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -3,6 +3,8 @@ package orchestrator
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"gearbox/pkg/manifest"
@@ -33,6 +35,11 @@ func handleTrackInstallation(args []string) error {
 				config.BinaryPaths = strings.Split(args[i+1], ",")
 				i++
 			}
+		case "--build-type":
+			if i+1 < len(args) {
+				config.BuildType = args[i+1]
+				i++
+			}
 		case "--build-dir":
 			if i+1 < len(args) {
 				config.BuildDir = args[i+1]
@@ -75,6 +82,7 @@ func handleTrackInstallation(args []string) error {
 	if config.UserRequested {
 		config.InstallationContext = append(config.InstallationContext, "user_request")
 	}
+	config.Files = manifest.RecordFiles(config.BinaryPaths)
 
 	// Create tracker and track installation
 	tracker, err := manifest.NewTracker()
@@ -90,6 +98,138 @@ func handleTrackInstallation(args []string) error {
 	return nil
 }
 
+// recordInstallations records the tools that installed successfully in the
+// manifest, together with their configuration files, environment and the
+// checksums of their binaries. Tools a script tracked itself only get those
+// added. Only the tools named in the request are user-requested; the tools
+// of the requested bundles are recorded as installed by their bundle.
+func (o *Orchestrator) recordInstallations(requested []string, bundles map[string][]string) {
+	o.mu.Lock()
+	results := append([]InstallationResult(nil), o.results...)
+	o.mu.Unlock()
+
+	var tracker *manifest.Tracker
+	for _, result := range results {
+		if !result.Success {
+			continue
+		}
+		configFiles := o.installConfigFiles(result.Tool)
+
+		if tracker == nil {
+			var err error
+			if tracker, err = manifest.NewTracker(); err != nil {
+				o.reportf("⚠️  Failed to record installations: %v\n", err)
+				return
+			}
+		}
+
+		userRequested := contains(requested, result.Tool.Name)
+		var err error
+		if record, found := tracker.GetInstallation(result.Tool.Name); found {
+			// A reinstall replaced the binaries
+			err = tracker.RecordFiles(result.Tool.Name)
+			if err == nil && userRequested && !record.UserRequested {
+				err = tracker.MarkUserRequested(result.Tool.Name)
+			}
+			if err == nil && len(configFiles) > 0 {
+				err = tracker.AddConfigFiles(result.Tool.Name, configFiles)
+			}
+			if err == nil && len(result.Tool.Env) > 0 {
+				err = tracker.SetEnvironment(result.Tool.Name, result.Tool.Env)
+			}
+		} else {
+			config := o.trackingConfig(result.Tool, configFiles, userRequested, bundleOf(result.Tool.Name, bundles))
+			err = tracker.TrackInstallation(result.Tool.Name, config)
+		}
+		if err != nil {
+			o.reportf("⚠️  Failed to record %s: %v\n", result.Tool.Name, err)
+		}
+	}
+}
+
+// recordBundles records the installed bundles in the manifest with the
+// build type they were installed with, so that an exported environment
+// installs the bundle again rather than its tools one by one
+func (o *Orchestrator) recordBundles(bundles map[string][]string) {
+	if len(bundles) == 0 {
+		return
+	}
+	tracker, err := manifest.NewTracker()
+	if err != nil {
+		o.reportf("⚠️  Failed to record bundles: %v\n", err)
+		return
+	}
+
+	var names []string
+	for name := range bundles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		err := tracker.TrackBundle(name, bundles[name], true)
+		if err == nil {
+			err = tracker.SetBuildType(name+"_bundle", o.options.BuildType)
+		}
+		if err != nil {
+			o.reportf("⚠️  Failed to record bundle %s: %v\n", name, err)
+		}
+	}
+}
+
+// bundleOf returns the first bundle, by name, that installed a tool
+func bundleOf(tool string, bundles map[string][]string) string {
+	var names []string
+	for name, tools := range bundles {
+		if contains(tools, tool) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
+
+// trackingConfig describes a tool the orchestrator installed from source,
+// either because the user asked for it, for a bundle, or as a dependency
+func (o *Orchestrator) trackingConfig(tool ToolConfig, configFiles []string, userRequested bool, bundle string) manifest.TrackingConfig {
+	binaryName := tool.BinaryName
+	if binaryName == "" {
+		binaryName = tool.Name
+	}
+	buildDir := filepath.Join(toolsBuildDir(), tool.Name)
+	if _, err := os.Stat(buildDir); err != nil {
+		buildDir = ""
+	}
+	var context []string
+	if userRequested {
+		context = append(context, "user_request")
+	}
+	if bundle != "" {
+		context = append(context, "bundle:"+bundle)
+	}
+	if len(context) == 0 {
+		context = []string{"dependency"}
+	}
+	binaryPaths := manifest.DetectBinaryPaths(binaryName, nil)
+	return manifest.TrackingConfig{
+		Method:              manifest.MethodSourceBuild,
+		Version:             getToolVersion(tool),
+		BuildType:           o.options.BuildType,
+		BinaryPaths:         binaryPaths,
+		BuildDir:            buildDir,
+		SourceRepo:          tool.Repository,
+		Dependencies:        tool.Dependencies,
+		InstalledByBundle:   bundle,
+		UserRequested:       userRequested,
+		InstallationContext: context,
+		ConfigFiles:         configFiles,
+		Environment:         tool.Env,
+		Files:               manifest.RecordFiles(binaryPaths),
+	}
+}
+
 // handleTrackBundle processes track-bundle command
 func handleTrackBundle(args []string) error {
 	if len(args) < 2 {
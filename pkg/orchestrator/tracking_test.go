@@ -0,0 +1,56 @@
+package orchestrator
+
+import (
+	"reflect"
+	"testing"
+
+	"gearbox/pkg/manifest"
+)
+
+func TestRecordInstallationsKeepsBundleToolsApartFromRequestedTools(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	o := newRepoOrchestrator(t, InstallationOptions{BuildType: "minimal"})
+	o.results = []InstallationResult{
+		{Tool: ToolConfig{Name: "fd", Language: "rust"}, Success: true},
+		{Tool: ToolConfig{Name: "ripgrep", Language: "rust"}, Success: true},
+	}
+
+	o.recordInstallations([]string{"fd"}, map[string][]string{"search": {"fd", "ripgrep"}})
+
+	tracker, err := manifest.NewTracker()
+	if err != nil {
+		t.Fatalf("failed to load manifest: %v", err)
+	}
+
+	fd, found := tracker.GetInstallation("fd")
+	if !found {
+		t.Fatal("fd was not recorded")
+	}
+	if !fd.UserRequested || fd.InstalledByBundle != "search" {
+		t.Errorf("fd: UserRequested = %v, InstalledByBundle = %q", fd.UserRequested, fd.InstalledByBundle)
+	}
+	if want := []string{"user_request", "bundle:search"}; !reflect.DeepEqual(fd.InstallationContext, want) {
+		t.Errorf("fd context = %v, want %v", fd.InstallationContext, want)
+	}
+
+	rg, found := tracker.GetInstallation("ripgrep")
+	if !found {
+		t.Fatal("ripgrep was not recorded")
+	}
+	if rg.UserRequested || rg.InstalledByBundle != "search" {
+		t.Errorf("ripgrep: UserRequested = %v, InstalledByBundle = %q", rg.UserRequested, rg.InstalledByBundle)
+	}
+	if want := []string{"bundle:search"}; !reflect.DeepEqual(rg.InstallationContext, want) {
+		t.Errorf("ripgrep context = %v, want %v", rg.InstallationContext, want)
+	}
+
+	// Asking for a bundle tool later makes it user-requested
+	o.recordInstallations([]string{"ripgrep"}, nil)
+	tracker, err = manifest.NewTracker()
+	if err != nil {
+		t.Fatalf("failed to reload manifest: %v", err)
+	}
+	if rg, _ := tracker.GetInstallation("ripgrep"); !rg.UserRequested {
+		t.Error("ripgrep is not user-requested after being asked for")
+	}
+}
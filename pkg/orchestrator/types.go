@@ -1,11 +1,13 @@
 package orchestrator
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/schollz/progressbar/v3"
+	"gearbox/pkg/configfiles"
 )
 
 // ToolConfig represents a single tool configuration
@@ -21,12 +23,30 @@ type ToolConfig struct {
 	MinVersion       string            `json:"min_version"`
 	ShellIntegration bool              `json:"shell_integration"`
 	TestCommand      string            `json:"test_command"`
+	Mirror           *MirrorConfig     `json:"mirror,omitempty"`
+	Disk             map[string]DiskEstimate `json:"disk,omitempty"` // Build type -> disk space estimate
+	Resources        map[string]ResourceWeight `json:"resources,omitempty"` // Build type -> memory and cores
+	BuildMinutes     map[string]float64 `json:"build_minutes,omitempty"` // Build type -> build time with the seeded cores
+	ConfigTemplates  []configfiles.Template `json:"config_templates,omitempty"` // Default configuration files
+	Env              map[string]string `json:"env,omitempty"` // Environment variables the tool needs in the shell
+}
+
+// MirrorConfig describes what an offline mirror needs for a tool besides
+// its repository and the dependencies of its language
+type MirrorConfig struct {
+	NoRepository bool     `json:"no_repository,omitempty"` // The script does not build from the repository
+	Release      string   `json:"release,omitempty"`       // Pinned release tag (default: latest)
+	Assets       []string `json:"assets,omitempty"`        // Release asset patterns (default: Linux archives for the architecture)
+	GoModules    []string `json:"go_modules,omitempty"`    // Modules installed with 'go install'
 }
 
 // LanguageConfig represents language-specific configuration
 type LanguageConfig struct {
 	MinVersion string `json:"min_version"`
 	BuildTool  string `json:"build_tool"`
+	Disk       map[string]DiskEstimate `json:"disk,omitempty"` // Build type -> default disk space estimate
+	Resources  map[string]ResourceWeight `json:"resources,omitempty"` // Build type -> default memory and cores
+	BuildMinutes map[string]float64 `json:"build_minutes,omitempty"` // Build type -> default build time
 }
 
 // Config represents the complete configuration structure
@@ -48,6 +68,12 @@ type InstallationOptions struct {
 	MaxParallelJobs  int
 	Verbose          bool
 	DryRun           bool
+	Mirror           string // Offline mirror directory
+	NoCache          bool   // Clone sources directly instead of through the source cache
+	SkipDiskCheck    bool   // Start installations even when disk space looks insufficient
+	NoConfig         bool   // Do not install the default configuration files of tools
+	SystemUpgrade    bool   // Upgrade the system to install packages with pacman
+	BusyTools        []string // Tools and bundles with a queued or running daemon job
 	
 	// Nerd-fonts specific options
 	Fonts            string
@@ -67,16 +93,22 @@ type InstallationResult struct {
 
 // Orchestrator handles tool installation orchestration
 type Orchestrator struct {
-	configMgr     *ConfigManager
-	bundleConfig  *BundleConfiguration
-	packageMgr    *PackageManager
-	options       InstallationOptions
-	repoDir       string
-	scriptsDir    string
-	mu            sync.RWMutex  // Use RWMutex for better read performance
-	results       []InstallationResult
-	progressBar   *progressbar.ProgressBar
-	resultPool    sync.Pool     // Memory pool for result objects
+	configMgr      *ConfigManager
+	bundleConfig   *BundleConfiguration
+	packageMgr     *PackageManager
+	packageMapping *PackageMapping
+	mirror         *Mirror
+	sourceCache    *SourceCache
+	buildStats     *BuildStats
+	options        InstallationOptions
+	repoDir        string
+	scriptsDir     string
+	mu             sync.RWMutex  // Use RWMutex for better read performance
+	results        []InstallationResult
+	reporter       Reporter      // Receives the events of installations
+	resultPool     sync.Pool     // Memory pool for result objects
+	diskSpaceLow   atomic.Bool   // Set when free space runs out during installations
+	ctx            context.Context // Stops the installation scripts when cancelled, nil for never
 }
 
 // ConfigManager handles configuration management without global state
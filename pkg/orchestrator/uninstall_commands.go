@@ -2,9 +2,12 @@ package orchestrator
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"gearbox/pkg/manifest"
 	"gearbox/pkg/uninstall"
 )
 
@@ -121,6 +124,35 @@ func showRemovalPlan(plan *uninstall.RemovalPlan) error {
 			}
 			fmt.Printf("  %s %-15s (%s) - %s\n", 
 				safety, action.Target, action.Method, action.Reason)
+			if action.Method == uninstall.RemovalSystemPackage && len(action.Packages) > 0 {
+				fmt.Printf("     packages: %s\n", strings.Join(action.Packages, " "))
+			}
+		}
+		fmt.Printf("\n")
+	}
+
+	// Show what the tools left outside their install locations
+	if plan.Summary.ArtifactCount() > 0 {
+		fmt.Printf("🧹 Shell integration, configuration and caches to be removed:\n")
+		for _, kind := range uninstall.ArtifactKinds {
+			if plan.Summary.ArtifactBreakdown[kind] == 0 {
+				continue
+			}
+			fmt.Printf("  %s:\n", kind.Title())
+			for _, action := range plan.ToRemove {
+				for _, artifact := range action.Artifacts {
+					if artifact.Kind != kind {
+						continue
+					}
+					detail := ""
+					if artifact.Kind == uninstall.ArtifactShell {
+						detail = fmt.Sprintf(" (%d lines)", len(artifact.Lines))
+					} else if artifact.Changed {
+						detail = " (changed by you)"
+					}
+					fmt.Printf("    %-15s %s%s\n", action.Target, artifact.Path, detail)
+				}
+			}
 		}
 		fmt.Printf("\n")
 	}
@@ -203,4 +235,126 @@ func showDetailedRemovalPlan(plan *uninstall.RemovalPlan, engine *uninstall.Remo
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// showTrash lists the removals in the trash
+func showTrash() error {
+	trash := uninstall.NewTrash()
+	entries, err := trash.List()
+	if err != nil {
+		return fmt.Errorf("failed to read the trash: %w", err)
+	}
+
+	fmt.Printf("♻️  Trash\n")
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	if len(entries) == 0 {
+		fmt.Printf("The trash is empty\n")
+		return nil
+	}
+
+	var total int64
+	for _, entry := range entries {
+		tools := strings.Join(entry.Tools(), ", ")
+		if tools == "" {
+			tools = fmt.Sprintf("%d files", len(entry.Files))
+		}
+		fmt.Printf("%-20s %-10s %-14s %s\n", entry.ID, formatBytes(entry.Size), formatAge(entry.CreatedAt), tools)
+		total += entry.Size
+	}
+	fmt.Printf("\nTotal: %d removals, %s in %s\n", len(entries), formatBytes(total), trash.Dir)
+	fmt.Printf("💡 Restore one with 'gearbox uninstall --undo <id>'\n")
+	return nil
+}
+
+// undoRemoval restores the files and manifest records of a removal from the
+// trash, the newest one for an empty ID
+func undoRemoval(id string) error {
+	trash := uninstall.NewTrash()
+	entry, err := trash.Get(id)
+	if err != nil {
+		return err
+	}
+	tracker, err := manifest.NewTracker()
+	if err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	fmt.Printf("♻️  Restoring removal %s (%s)\n", entry.ID, formatBytes(entry.Size))
+	for _, file := range entry.Files {
+		fmt.Printf("   %s\n", file.Original)
+	}
+	for _, edit := range entry.Edits {
+		fmt.Printf("   %s (%d lines)\n", edit.Path, len(edit.Lines))
+	}
+	tools := entry.Tools()
+	files := len(entry.Files)
+	if err := trash.Restore(entry, tracker); err != nil {
+		return err
+	}
+	if len(tools) > 0 {
+		fmt.Printf("✅ Restored %s\n", strings.Join(tools, ", "))
+	} else {
+		fmt.Printf("✅ Restored %d files\n", files)
+	}
+	return nil
+}
+
+// emptyTrash deletes every removal in the trash after confirmation
+func emptyTrash(dryRun bool) error {
+	trash := uninstall.NewTrash()
+	entries, err := trash.List()
+	if err != nil {
+		return fmt.Errorf("failed to read the trash: %w", err)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("✅ The trash is empty\n")
+		return nil
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+	}
+	if dryRun {
+		fmt.Printf("Would purge %d removals (%s) from the trash\n", len(entries), formatBytes(total))
+		return nil
+	}
+
+	fmt.Printf("Purge %d removals (%s) from the trash? They cannot be undone. [y/N]: ", len(entries), formatBytes(total))
+	var response string
+	fmt.Scanln(&response)
+	if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+		fmt.Printf("❌ Purge cancelled\n")
+		return nil
+	}
+
+	for _, entry := range entries {
+		if err := trash.Purge(entry); err != nil {
+			return fmt.Errorf("failed to purge %s: %w", entry.ID, err)
+		}
+	}
+	fmt.Printf("✅ Freed %s from %d removals\n", formatBytes(total), len(entries))
+	return nil
+}
+
+// autoPurgeTrash purges removals older than TRASH_MAX_AGE_DAYS, then the
+// oldest ones while the trash is larger than TRASH_MAX_SIZE_MB
+func autoPurgeTrash() {
+	days, err := strconv.Atoi(UserConfigValue("TRASH_MAX_AGE_DAYS", strconv.Itoa(uninstall.DefaultTrashDays)))
+	if err != nil {
+		days = uninstall.DefaultTrashDays
+	}
+	sizeMB, err := strconv.ParseInt(UserConfigValue("TRASH_MAX_SIZE_MB", strconv.Itoa(uninstall.DefaultTrashSizeMB)), 10, 64)
+	if err != nil {
+		sizeMB = uninstall.DefaultTrashSizeMB
+	}
+
+	purged, err := uninstall.NewTrash().AutoPurge(time.Duration(days)*24*time.Hour, sizeMB<<20)
+	if err != nil {
+		fmt.Printf("⚠️  Failed to purge the trash: %v\n", err)
+		return
+	}
+	for _, entry := range purged {
+		fmt.Printf("♻️  Purged removal %s (%s) from the trash\n", entry.ID, formatBytes(entry.Size))
+	}
+}
@@ -200,6 +200,23 @@ func formatList(items []string) string {
 	return strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
 }
 
+// formatBytes returns a human-readable size
+func formatBytes(size int64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	value := float64(size)
+	unit := 0
+
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if value == float64(int64(value)) {
+		return fmt.Sprintf("%.0f %s", value, units[unit])
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
+
 // createProgressBar creates a new progress bar with consistent styling
 func createProgressBar(max int, description string) *progressbar.ProgressBar {
 	return progressbar.NewOptions(max,
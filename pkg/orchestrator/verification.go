@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"fmt"
 	"os/exec"
 	"strings"
 )
@@ -18,23 +19,33 @@ func isToolInstalled(tool ToolConfig) bool {
 
 // verifyTool verifies a tool installation by running its test command
 func verifyTool(tool ToolConfig) bool {
+	_, err := runToolTest(tool)
+	return err == nil
+}
+
+// runToolTest runs the test command of a tool and returns its output. The
+// error explains why the tool failed verification.
+func runToolTest(tool ToolConfig) (string, error) {
 	if !isToolInstalled(tool) {
-		return false
+		if tool.Name == "nerd-fonts" {
+			return "", fmt.Errorf("no Nerd Fonts installed")
+		}
+		return "", fmt.Errorf("%s not found in PATH", tool.BinaryName)
 	}
 
 	// Special handling for nerd-fonts
 	if tool.Name == "nerd-fonts" {
-		return isNerdFontsInstalled()
+		return "", nil
 	}
 
 	if tool.TestCommand == "" {
-		return true // If no test command, just check if binary exists
+		return "", nil // If no test command, just check if binary exists
 	}
 
 	// Parse and execute test command
 	parts := strings.Fields(tool.TestCommand)
 	if len(parts) == 0 {
-		return true
+		return "", nil
 	}
 
 	// Use binary_name instead of the first part of test_command for tools with different binary names
@@ -48,8 +59,11 @@ func verifyTool(tool ToolConfig) bool {
 	cmdArgs := parts
 
 	cmd := exec.Command(binaryName, cmdArgs...)
-	err := cmd.Run()
-	return err == nil
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("'%s %s' failed: %w", binaryName, tool.TestCommand, err)
+	}
+	return string(output), nil
 }
 
 // getToolVersion gets the version of an installed tool
@@ -74,11 +88,20 @@ func getToolVersion(tool ToolConfig) string {
 		binaryName = tool.Name // Fallback to tool name if binary_name not specified
 	}
 	
+	return toolVersionAt(tool, binaryName)
+}
+
+// toolVersionAt runs the test command of a tool with a binary, which may be
+// a path off PATH, and extracts the version it reports
+func toolVersionAt(tool ToolConfig, binary string) string {
 	// For test commands, use ALL parts as arguments (don't skip the first one)
 	// Most test commands are just "--version", not "tool_name --version"
-	cmdArgs := parts
+	cmdArgs := strings.Fields(tool.TestCommand)
+	if len(cmdArgs) == 0 {
+		return "installed"
+	}
 	
-	cmd := exec.Command(binaryName, cmdArgs...)
+	cmd := exec.Command(binary, cmdArgs...)
 	output, err := cmd.Output()
 	if err != nil {
 		return "installed"
@@ -0,0 +1,85 @@
+// Package output renders query command results as JSON or YAML for scripts.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the version of the documents written by query commands.
+// Fields are only added within a version.
+const SchemaVersion = "1.0"
+
+// Format selects how query commands print their results
+type Format string
+
+const (
+	// Table is the human-readable text output
+	Table Format = "table"
+	// JSON is indented JSON
+	JSON Format = "json"
+	// YAML is block-style YAML with the same fields as JSON
+	YAML Format = "yaml"
+)
+
+// Formats lists the supported output formats
+var Formats = []Format{Table, JSON, YAML}
+
+// ParseFormat returns the format named by s
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(strings.ToLower(s)); format {
+	case "", Table:
+		return Table, nil
+	case JSON, YAML:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported output format %q (use json, yaml or table)", s)
+}
+
+// Structured reports whether the format is meant for scripts rather than people
+func (f Format) Structured() bool {
+	return f == JSON || f == YAML
+}
+
+// Write encodes v in the given format. YAML is produced from the JSON
+// encoding so that both formats share the field names of the json tags and
+// their order.
+func Write(w io.Writer, format Format, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	switch format {
+	case JSON:
+		_, err = w.Write(append(data, '\n'))
+		return err
+	case YAML:
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return fmt.Errorf("failed to convert output to YAML: %w", err)
+		}
+		blockStyle(&node)
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+		return encoder.Close()
+	}
+	return fmt.Errorf("format %q is not a structured format", format)
+}
+
+// blockStyle turns the flow style of parsed JSON into block style and
+// drops the quotes JSON requires on every string
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
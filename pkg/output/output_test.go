@@ -0,0 +1,66 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type sample struct {
+	Name      string            `json:"name"`
+	Installed bool              `json:"installed"`
+	Paths     []string          `json:"paths"`
+	Flags     map[string]string `json:"build_flags,omitempty"`
+}
+
+func TestParseFormat(t *testing.T) {
+	for input, want := range map[string]Format{"": Table, "table": Table, "JSON": JSON, "yaml": YAML} {
+		got, err := ParseFormat(input)
+		if err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Errorf("expected an error for xml")
+	}
+}
+
+func TestWriteFormatsShareFields(t *testing.T) {
+	value := sample{Name: "fd", Installed: true, Paths: []string{"/usr/local/bin/fd"}, Flags: map[string]string{"standard": "-r"}}
+
+	var jsonOut, yamlOut bytes.Buffer
+	if err := Write(&jsonOut, JSON, value); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	if err := Write(&yamlOut, YAML, value); err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+
+	var fromJSON, fromYAML map[string]interface{}
+	if err := json.Unmarshal(jsonOut.Bytes(), &fromJSON); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if err := yaml.Unmarshal(yamlOut.Bytes(), &fromYAML); err != nil {
+		t.Fatalf("invalid yaml: %v", err)
+	}
+
+	for _, key := range []string{"name", "installed", "paths", "build_flags"} {
+		if _, found := fromYAML[key]; !found {
+			t.Errorf("yaml is missing %q:\n%s", key, yamlOut.String())
+		}
+		if _, found := fromJSON[key]; !found {
+			t.Errorf("json is missing %q", key)
+		}
+	}
+	if bytes.Contains(yamlOut.Bytes(), []byte("{")) {
+		t.Errorf("expected block style yaml, got:\n%s", yamlOut.String())
+	}
+}
+
+func TestWriteRejectsTable(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, Table, sample{}); err == nil {
+		t.Errorf("expected an error for table format")
+	}
+}
@@ -0,0 +1,186 @@
+package shellinit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gearbox/pkg/manifest"
+)
+
+// systemDirs are always on PATH and never exported
+var systemDirs = map[string]bool{
+	"/bin":      true,
+	"/sbin":     true,
+	"/usr/bin":  true,
+	"/usr/sbin": true,
+}
+
+// Var is an environment variable a tool needs
+type Var struct {
+	Name  string
+	Value string // May refer to other variables such as $HOME
+}
+
+// Environment is what the installed tools need from the shell
+type Environment struct {
+	PathDirs []string // Install locations, in order of precedence
+	Vars     []Var
+}
+
+// Empty reports whether the tools need nothing from the shell
+func (e Environment) Empty() bool {
+	return len(e.PathDirs) == 0 && len(e.Vars) == 0
+}
+
+// ManifestEnvironment collects the install locations of the binaries and the
+// variables recorded in the manifest. Tools are visited by name, so the first
+// tool to set a variable wins.
+func ManifestEnvironment(m *manifest.InstallationManifest) Environment {
+	var env Environment
+	seenDirs := make(map[string]bool)
+	seenVars := make(map[string]bool)
+	for _, name := range toolNames(m) {
+		record := m.Installations[name]
+		for _, path := range record.BinaryPaths {
+			dir := filepath.Dir(path)
+			if path == "" || systemDirs[dir] || seenDirs[dir] {
+				continue
+			}
+			seenDirs[dir] = true
+			env.PathDirs = append(env.PathDirs, dir)
+		}
+
+		var vars []string
+		for variable := range record.Environment {
+			vars = append(vars, variable)
+		}
+		sort.Strings(vars)
+		for _, variable := range vars {
+			if !seenVars[variable] {
+				seenVars[variable] = true
+				env.Vars = append(env.Vars, Var{Name: variable, Value: record.Environment[variable]})
+			}
+		}
+	}
+	return env
+}
+
+// toolchainDirs are where the common dependencies install the Rust and Go
+// toolchains, relative to $HOME unless absolute
+var toolchainDirs = []string{".cargo/bin", "/usr/local/go/bin"}
+
+// WithToolchains adds the toolchain directories that exist, including the
+// binaries of go install, after the install locations of the tools. Install
+// scripts used to add them to rc files.
+func (e Environment) WithToolchains(home string) Environment {
+	dirs := append([]string(nil), toolchainDirs...)
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		gopath = filepath.Join(home, "go")
+	}
+	dirs = append(dirs, filepath.Join(filepath.SplitList(gopath)[0], "bin"))
+
+	pathDirs := append([]string(nil), e.PathDirs...)
+	for _, dir := range dirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(home, dir)
+		}
+		if containsLine(pathDirs, dir) {
+			continue
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			pathDirs = append(pathDirs, dir)
+		}
+	}
+	e.PathDirs = pathDirs
+	return e
+}
+
+// EnvScript returns the shell code that sets up the environment
+func EnvScript(shell string, env Environment) (string, error) {
+	if !supported(shell) {
+		return "", fmt.Errorf("unsupported shell %q (use bash, zsh or fish)", shell)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by gearbox env %s\n", shell)
+	writeEnv(&b, shell, env)
+	return b.String(), nil
+}
+
+// writeEnv writes the PATH entries and variables. PATH entries are added in
+// reverse, so the first location ends up first, and only when missing.
+// Variables are defaults: a value the user already set is kept.
+func writeEnv(b *strings.Builder, shell string, env Environment) {
+	for i := len(env.PathDirs) - 1; i >= 0; i-- {
+		dir := homeRelative(env.PathDirs[i])
+		if shell == "fish" {
+			fmt.Fprintf(b, "fish_add_path -g %s\n", doubleQuote(dir))
+			continue
+		}
+		fmt.Fprintf(b, "case \":$PATH:\" in *%s*) ;; *) export PATH=%s ;; esac\n",
+			doubleQuote(":"+dir+":"), doubleQuote(dir+":$PATH"))
+	}
+	for _, v := range env.Vars {
+		if shell == "fish" {
+			fmt.Fprintf(b, "set -q %s; or set -gx %s %s\n", v.Name, v.Name, doubleQuote(v.Value))
+			continue
+		}
+		fmt.Fprintf(b, ": \"${%s:=%s}\"; export %s\n", v.Name, defaultValue(v.Value), v.Name)
+	}
+}
+
+// defaultValue escapes a value for ${VAR:=value} inside double quotes
+func defaultValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", "}", `\}`).Replace(value)
+}
+
+// CheckPath returns the drift of recorded binaries that are missing or that
+// the current PATH does not run, and the number of tools checked. See
+// manifest.CheckFiles.
+func CheckPath(m *manifest.InstallationManifest, lookPath func(string) (string, error)) ([]manifest.Drift, int) {
+	var problems []manifest.Drift
+	checked := 0
+	for _, name := range toolNames(m) {
+		record := m.Installations[name]
+		if len(record.BinaryPaths) == 0 {
+			continue
+		}
+		checked++
+		for _, drift := range manifest.CheckFiles(name, record, lookPath, false) {
+			if drift.Kind != manifest.DriftModified {
+				problems = append(problems, drift)
+			}
+		}
+	}
+	return problems, checked
+}
+
+// toolNames returns the tracked tools by name, without bundles
+func toolNames(m *manifest.InstallationManifest) []string {
+	var names []string
+	for name, record := range m.Installations {
+		if record.Method != manifest.MethodBundle {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// homeRelative writes paths below the home directory relative to $HOME
+func homeRelative(path string) string {
+	home, err := os.UserHomeDir()
+	if err == nil && home != "/" && strings.HasPrefix(path, home+string(filepath.Separator)) {
+		return "$HOME" + strings.TrimPrefix(path, home)
+	}
+	return path
+}
+
+// doubleQuote quotes a value so the shell still expands variables in it
+func doubleQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`").Replace(value) + `"`
+}
@@ -0,0 +1,127 @@
+package shellinit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gearbox/pkg/manifest"
+)
+
+func testManifest(records map[string]*manifest.InstallationRecord) *manifest.InstallationManifest {
+	m := manifest.NewManifest()
+	for name, record := range records {
+		m.AddInstallation(name, record)
+	}
+	return m
+}
+
+func TestManifestEnvironment(t *testing.T) {
+	m := testManifest(map[string]*manifest.InstallationRecord{
+		"bat":        {BinaryPaths: []string{"/home/me/.cargo/bin/bat"}, Environment: map[string]string{"BAT_THEME": "TwoDark"}},
+		"fd":         {BinaryPaths: []string{"/home/me/.cargo/bin/fd"}},
+		"jq":         {BinaryPaths: []string{"/usr/bin/jq"}},
+		"lazygit":    {BinaryPaths: []string{"/home/me/go/bin/lazygit"}},
+		"dev_bundle": {Method: manifest.MethodBundle},
+	})
+
+	env := ManifestEnvironment(m)
+	if strings.Join(env.PathDirs, ",") != "/home/me/.cargo/bin,/home/me/go/bin" {
+		t.Errorf("unexpected PATH entries %v", env.PathDirs)
+	}
+	if len(env.Vars) != 1 || env.Vars[0] != (Var{"BAT_THEME", "TwoDark"}) {
+		t.Errorf("unexpected variables %v", env.Vars)
+	}
+}
+
+func TestWithToolchains(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("GOPATH", "")
+	for _, dir := range []string{".cargo/bin", "go/bin"} {
+		if err := os.MkdirAll(filepath.Join(home, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	env := Environment{PathDirs: []string{filepath.Join(home, ".cargo/bin"), "/opt/tool/bin"}}.WithToolchains(home)
+	want := []string{filepath.Join(home, ".cargo/bin"), "/opt/tool/bin"}
+	if _, err := os.Stat("/usr/local/go/bin"); err == nil {
+		want = append(want, "/usr/local/go/bin")
+	}
+	want = append(want, filepath.Join(home, "go/bin"))
+	if strings.Join(env.PathDirs, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, env.PathDirs)
+	}
+}
+
+func TestEnvScript(t *testing.T) {
+	t.Setenv("HOME", "/home/me")
+	env := Environment{
+		PathDirs: []string{"/home/me/.cargo/bin", "/opt/tools/bin"},
+		Vars:     []Var{{"FZF_DEFAULT_OPTS", "--height 40% --layout=reverse"}},
+	}
+
+	bash, err := EnvScript("bash", env)
+	if err != nil {
+		t.Fatalf("EnvScript failed: %v", err)
+	}
+	cargo := strings.Index(bash, `case ":$PATH:" in *":$HOME/.cargo/bin:"*) ;; *) export PATH="$HOME/.cargo/bin:$PATH" ;; esac`)
+	opt := strings.Index(bash, `export PATH="/opt/tools/bin:$PATH"`)
+	if cargo < 0 || opt < 0 || opt > cargo {
+		t.Errorf("expected the first location to be added last:\n%s", bash)
+	}
+	if !strings.Contains(bash, `: "${FZF_DEFAULT_OPTS:=--height 40% --layout=reverse}"; export FZF_DEFAULT_OPTS`) {
+		t.Errorf("missing variable default:\n%s", bash)
+	}
+
+	fish, _ := EnvScript("fish", env)
+	for _, want := range []string{`fish_add_path -g "$HOME/.cargo/bin"`, `set -q FZF_DEFAULT_OPTS; or set -gx FZF_DEFAULT_OPTS "--height 40% --layout=reverse"`} {
+		if !strings.Contains(fish, want) {
+			t.Errorf("expected %q in:\n%s", want, fish)
+		}
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	dir := t.TempDir()
+	binary := func(name string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	fd, rg, other := binary("fd"), binary("rg"), binary("other-rg")
+
+	m := testManifest(map[string]*manifest.InstallationRecord{
+		"fd":      {BinaryPaths: []string{fd}},
+		"ripgrep": {BinaryPaths: []string{rg}},
+		"bat":     {BinaryPaths: []string{filepath.Join(dir, "bat")}},
+		"lazygit": {BinaryPaths: []string{binary("lazygit")}},
+	})
+	lookPath := func(name string) (string, error) {
+		switch name {
+		case "fd":
+			return fd, nil
+		case "rg":
+			return other, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	problems, checked := CheckPath(m, lookPath)
+	if checked != 4 || len(problems) != 3 {
+		t.Fatalf("unexpected problems %+v of %d tools", problems, checked)
+	}
+	if problems[0].Kind != manifest.DriftMissing || problems[0].Tool != "bat" {
+		t.Errorf("expected bat to be missing, got %+v", problems[0])
+	}
+	if problems[1].Kind != manifest.DriftUnreachable || problems[1].Tool != "lazygit" {
+		t.Errorf("expected lazygit to be off PATH, got %+v", problems[1])
+	}
+	if problems[2].Kind != manifest.DriftShadowed || problems[2].Tool != "ripgrep" || problems[2].Detail != other {
+		t.Errorf("expected ripgrep to be shadowed, got %+v", problems[2])
+	}
+}
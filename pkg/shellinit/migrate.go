@@ -0,0 +1,323 @@
+package shellinit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// legacyLines are the lines install scripts appended to rc files before
+// shell-init by tool, including the lines of the bun installer they ran
+var legacyLines = map[string][]string{
+	"fzf": {
+		"# fzf key bindings and fuzzy completion",
+		"source <(fzf --bash)",
+	},
+	"zoxide": {
+		"# zoxide integration",
+		`eval "$(zoxide init bash)"`,
+		`eval "$(zoxide init zsh)"`,
+		"zoxide init fish | source",
+	},
+	"starship": {
+		"# Initialize Starship prompt",
+		`eval "$(starship init bash)"`,
+		`eval "$(starship init zsh)"`,
+		"starship init fish | source",
+	},
+	"bun": {
+		`export PATH="$HOME/.bun/bin:$PATH"`,
+		"# bun",
+		`export BUN_INSTALL="$HOME/.bun"`,
+		`export PATH="$BUN_INSTALL/bin:$PATH"`,
+		`set --export BUN_INSTALL "$HOME/.bun"`,
+		`set --export PATH $BUN_INSTALL/bin $PATH`,
+	},
+}
+
+// legacyPathLines are the PATH lines install scripts appended to rc files
+// by tool. Other tools and the user rely on these directories as well, so
+// they are only removed by the migration, which loads shell-init instead.
+var legacyPathLines = map[string][]string{
+	"common-deps": {
+		"# Rust environment",
+		cargoPathLine,
+		"# Go environment",
+		goPathLine,
+	},
+	"bandwhich":      {cargoPathLine},
+	"fd":             {cargoPathLine},
+	"just":           {cargoPathLine},
+	"ripgrep":        {cargoPathLine},
+	"yazi":           {cargoPathLine},
+	"zoxide":         {cargoPathLine},
+	"fzf":            {goPathLine},
+	"gh":             {goPathLine},
+	"lazygit":        {goPathLine},
+	"claude-monitor": {localPathLine},
+	"tokei":          {localPathLine},
+	"uv":             {localPathLine},
+	"ccusage":        {`export PATH="$HOME/.npm-global/bin:$PATH"`},
+}
+
+// PATH lines several install scripts appended
+const (
+	cargoPathLine = `export PATH="$HOME/.cargo/bin:$PATH"`
+	goPathLine    = `export PATH="/usr/local/go/bin:$PATH"`
+	localPathLine = `export PATH="$HOME/.local/bin:$PATH"`
+)
+
+// goBinLine is the line the gopls script appended, which holds the GOPATH
+// that go env reported at the time
+func goBinLine() string {
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		home, _ := os.UserHomeDir()
+		gopath = filepath.Join(home, "go")
+	}
+	return fmt.Sprintf(`export PATH="%s/bin:$PATH"`, gopath)
+}
+
+// isLegacyLine reports whether an install script appended the line
+func isLegacyLine(line string) bool {
+	for _, lines := range legacyLines {
+		if containsLine(lines, line) {
+			return true
+		}
+	}
+	for _, lines := range legacyPathLines {
+		if containsLine(lines, line) {
+			return true
+		}
+	}
+	return line == goBinLine()
+}
+
+// RCFile is a shell startup file and the shell that reads it
+type RCFile struct {
+	Path  string
+	Shell string // Empty for files shared by shells, such as ~/.profile
+}
+
+// RCFiles returns the startup files the install scripts edited
+func RCFiles(home string) []RCFile {
+	fishConfig := os.Getenv("XDG_CONFIG_HOME")
+	if fishConfig == "" {
+		fishConfig = filepath.Join(home, ".config")
+	}
+	return []RCFile{
+		{Path: filepath.Join(home, ".bashrc"), Shell: "bash"},
+		{Path: filepath.Join(home, ".zshrc"), Shell: "zsh"},
+		{Path: filepath.Join(home, ".profile")},
+		{Path: filepath.Join(fishConfig, "fish", "config.fish"), Shell: "fish"},
+	}
+}
+
+// Migration is the change to one rc file
+type Migration struct {
+	File     RCFile
+	Removed  []string // Legacy lines removed from the file
+	AddsInit bool     // Whether the shell-init line is appended
+	Backup   string   // Copy of the file before the change, empty for dry runs
+}
+
+// Migrate removes the legacy lines from the rc files and appends the
+// shell-init line to the files of a shell that lost lines, so the tools stay
+// set up. Changed files are backed up next to the original. Files without
+// legacy lines are left alone.
+func Migrate(files []RCFile, dryRun bool) ([]Migration, error) {
+	var migrations []Migration
+	for _, file := range files {
+		data, err := os.ReadFile(file.Path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return migrations, fmt.Errorf("failed to read %s: %w", file.Path, err)
+		}
+
+		kept, removed := removeLines(string(data), isLegacyLine)
+		if len(removed) == 0 {
+			continue
+		}
+
+		migration := Migration{File: file, Removed: removed}
+		if file.Shell != "" && !loadsShellInit(kept) {
+			migration.AddsInit = true
+			kept = appendInitLine(kept, file.Shell)
+		}
+
+		if !dryRun {
+			info, err := os.Stat(file.Path)
+			if err != nil {
+				return migrations, fmt.Errorf("failed to read %s: %w", file.Path, err)
+			}
+			migration.Backup = fmt.Sprintf("%s.backup-%s", file.Path, time.Now().Format("20060102-150405"))
+			if err := os.WriteFile(migration.Backup, data, info.Mode().Perm()); err != nil {
+				return migrations, fmt.Errorf("failed to back up %s: %w", file.Path, err)
+			}
+			if err := os.WriteFile(file.Path, []byte(kept), info.Mode().Perm()); err != nil {
+				return migrations, fmt.Errorf("failed to update %s: %w", file.Path, err)
+			}
+		}
+		migrations = append(migrations, migration)
+	}
+	return migrations, nil
+}
+
+// AddInitLine appends the shell-init line to an rc file that does not load
+// shell-init yet, creating the file if needed. It reports whether the file
+// changed.
+func AddInitLine(file RCFile) (bool, error) {
+	if file.Shell == "" {
+		return false, fmt.Errorf("%s is not read by a supported shell", file.Path)
+	}
+	data, err := os.ReadFile(file.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read %s: %w", file.Path, err)
+	}
+	if loadsShellInit(string(data)) {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file.Path), 0755); err != nil {
+		return false, fmt.Errorf("failed to create %s: %w", filepath.Dir(file.Path), err)
+	}
+	content := appendInitLine(string(data), file.Shell)
+	if len(data) == 0 {
+		content = strings.TrimLeft(content, "\n")
+	}
+	if err := os.WriteFile(file.Path, []byte(content), 0644); err != nil {
+		return false, fmt.Errorf("failed to update %s: %w", file.Path, err)
+	}
+	return true, nil
+}
+
+// RCFileFor returns the rc file of a shell
+func RCFileFor(home, shell string) (RCFile, bool) {
+	for _, file := range RCFiles(home) {
+		if file.Shell == shell {
+			return file, true
+		}
+	}
+	return RCFile{}, false
+}
+
+// LoadsShellInit reports whether the rc file loads shell-init
+func (f RCFile) LoadsShellInit() bool {
+	data, err := os.ReadFile(f.Path)
+	return err == nil && loadsShellInit(string(data))
+}
+
+// ToolLines returns the lines install scripts appended to the rc file for a
+// tool, such as the zoxide init line. PATH lines other tools need as well
+// are left to the migration.
+func (f RCFile) ToolLines(tool string) []string {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil
+	}
+	_, removed := removeLines(string(data), func(line string) bool {
+		return containsLine(legacyLines[tool], line)
+	})
+	return removed
+}
+
+// RemoveLines removes lines from the rc file and returns the copy of the
+// file it keeps next to the original
+func (f RCFile) RemoveLines(lines []string) (string, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", f.Path, err)
+	}
+	kept, removed := removeLines(string(data), func(line string) bool {
+		return containsLine(lines, line)
+	})
+	if len(removed) == 0 {
+		return "", nil
+	}
+
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", f.Path, err)
+	}
+	backup := fmt.Sprintf("%s.backup-%s", f.Path, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(backup, data, info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", f.Path, err)
+	}
+	if err := os.WriteFile(f.Path, []byte(kept), info.Mode().Perm()); err != nil {
+		return backup, fmt.Errorf("failed to update %s: %w", f.Path, err)
+	}
+	return backup, nil
+}
+
+// AppendLines appends the lines the rc file does not have yet as a block,
+// creating the file if needed
+func (f RCFile) AppendLines(lines []string) error {
+	data, err := os.ReadFile(f.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", f.Path, err)
+	}
+	existing := strings.Split(string(data), "\n")
+	for i := range existing {
+		existing[i] = strings.TrimSpace(existing[i])
+	}
+	var missing []string
+	for _, line := range lines {
+		if !containsLine(existing, line) {
+			missing = append(missing, line)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(f.Path), err)
+	}
+	content := strings.TrimRight(string(data), "\n") + "\n\n" + strings.Join(missing, "\n") + "\n"
+	if len(data) == 0 {
+		content = strings.TrimLeft(content, "\n")
+	}
+	if err := os.WriteFile(f.Path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to update %s: %w", f.Path, err)
+	}
+	return nil
+}
+
+func loadsShellInit(content string) bool {
+	return strings.Contains(content, "gearbox shell-init")
+}
+
+func appendInitLine(content, shell string) string {
+	return strings.TrimRight(content, "\n") + "\n\n# Shell integration of tools installed by gearbox\n" + InitLine(shell) + "\n"
+}
+
+// removeLines returns the content without the lines that match, and the
+// lines it removed. The blank line the scripts wrote before a block goes too.
+func removeLines(content string, match func(string) bool) (string, []string) {
+	lines := strings.Split(content, "\n")
+	var kept, removed []string
+	for _, line := range lines {
+		if !match(strings.TrimSpace(line)) {
+			kept = append(kept, line)
+			continue
+		}
+		removed = append(removed, strings.TrimSpace(line))
+		if strings.HasPrefix(strings.TrimSpace(line), "#") && len(kept) > 0 && strings.TrimSpace(kept[len(kept)-1]) == "" {
+			kept = kept[:len(kept)-1]
+		}
+	}
+	return strings.Join(kept, "\n"), removed
+}
+
+func containsLine(lines []string, line string) bool {
+	for _, l := range lines {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
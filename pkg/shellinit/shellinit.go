@@ -0,0 +1,180 @@
+// Package shellinit generates the shell code that sets up tools with shell
+// integration, such as fzf key bindings or the starship prompt, for
+//
+//	eval "$(gearbox shell-init bash)"
+//
+// Only tools that are installed are set up, so removing a tool never leaves a
+// line behind that breaks new shells. The install locations and variables
+// recorded in the manifest are exported first. The package also removes the
+// lines that older install scripts appended to rc files.
+package shellinit
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Shells lists the supported shells
+var Shells = []string{"bash", "zsh", "fish"}
+
+// integration sets up one tool. Snippets use {bin} for the command of the
+// tool, which is an absolute path when the tool is not on PATH.
+type integration struct {
+	tool     string
+	binary   string
+	paths    []string          // Install locations outside PATH, relative to $HOME
+	snippets map[string]string // Shell code by shell
+}
+
+// integrations in the order they are set up: tools that change PATH or the
+// environment first, then key bindings and hooks, the prompt last
+var integrations = []integration{
+	{
+		tool:   "bun",
+		binary: "bun",
+		paths:  []string{".bun/bin"},
+		snippets: map[string]string{
+			"bash": `export BUN_INSTALL="$HOME/.bun"
+case ":$PATH:" in *":$BUN_INSTALL/bin:"*) ;; *) export PATH="$BUN_INSTALL/bin:$PATH" ;; esac`,
+			"zsh": `export BUN_INSTALL="$HOME/.bun"
+case ":$PATH:" in *":$BUN_INSTALL/bin:"*) ;; *) export PATH="$BUN_INSTALL/bin:$PATH" ;; esac`,
+			"fish": `set -gx BUN_INSTALL "$HOME/.bun"
+fish_add_path -g "$BUN_INSTALL/bin"`,
+		},
+	},
+	{
+		tool:   "mise",
+		binary: "mise",
+		paths:  []string{".local/bin", ".cargo/bin"},
+		snippets: map[string]string{
+			"bash": `eval "$({bin} activate bash)"`,
+			"zsh":  `eval "$({bin} activate zsh)"`,
+			"fish": `{bin} activate fish | source`,
+		},
+	},
+	{
+		tool:   "fzf",
+		binary: "fzf",
+		snippets: map[string]string{
+			"bash": `eval "$({bin} --bash)"`,
+			"zsh":  `source <({bin} --zsh)`,
+			"fish": `{bin} --fish | source`,
+		},
+	},
+	{
+		tool:   "zoxide",
+		binary: "zoxide",
+		paths:  []string{".cargo/bin", ".local/bin"},
+		snippets: map[string]string{
+			"bash": `eval "$({bin} init bash)"`,
+			"zsh":  `eval "$({bin} init zsh)"`,
+			"fish": `{bin} init fish | source`,
+		},
+	},
+	{
+		tool:   "starship",
+		binary: "starship",
+		paths:  []string{".cargo/bin", ".local/bin"},
+		snippets: map[string]string{
+			"bash": `eval "$({bin} init bash)"`,
+			"zsh":  `eval "$({bin} init zsh)"`,
+			"fish": `{bin} init fish | source`,
+		},
+	},
+}
+
+// Tools returns the tools with shell integration in the order they are set up
+func Tools() []string {
+	var tools []string
+	for _, i := range integrations {
+		tools = append(tools, i.tool)
+	}
+	return tools
+}
+
+// Finder locates the binary of a tool and returns the command to run it, or
+// false when the tool is not installed
+type Finder func(binary string, paths []string) (string, bool)
+
+// FindInstalled looks for a binary on PATH and in the install locations below
+// $HOME
+func FindInstalled(binary string, paths []string) (string, bool) {
+	if _, err := exec.LookPath(binary); err == nil {
+		return binary, true
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", false
+	}
+	for _, dir := range paths {
+		path := filepath.Join(home, dir, binary)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// Script returns the shell code that sets up the environment and then the
+// installed tools
+func Script(shell string, env Environment, find Finder) (string, error) {
+	if !supported(shell) {
+		return "", fmt.Errorf("unsupported shell %q (use bash, zsh or fish)", shell)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by gearbox shell-init %s\n", shell)
+	if !env.Empty() {
+		b.WriteString("\n# Environment of installed tools\n")
+		writeEnv(&b, shell, env)
+	}
+	for _, i := range integrations {
+		command, found := find(i.binary, i.paths)
+		if !found {
+			continue
+		}
+		fmt.Fprintf(&b, "\n# %s\n", i.tool)
+		fmt.Fprintln(&b, strings.ReplaceAll(i.snippets[shell], "{bin}", quote(command)))
+	}
+	return b.String(), nil
+}
+
+// InitLine returns the line that loads the integrations in a shell's rc file
+func InitLine(shell string) string {
+	if shell == "fish" {
+		return "gearbox shell-init fish | source"
+	}
+	return fmt.Sprintf(`eval "$(gearbox shell-init %s)"`, shell)
+}
+
+// DetectShell returns the shell of $SHELL, or bash when it is not supported
+func DetectShell() string {
+	shell := filepath.Base(os.Getenv("SHELL"))
+	if supported(shell) {
+		return shell
+	}
+	return "bash"
+}
+
+// supported reports whether shell is one of Shells
+func supported(shell string) bool {
+	for _, s := range Shells {
+		if s == shell {
+			return true
+		}
+	}
+	return false
+}
+
+// quote quotes a command for the shell unless it is a plain word
+func quote(command string) string {
+	if strings.IndexFunc(command, func(r rune) bool {
+		return !(r == '/' || r == '.' || r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	}) < 0 {
+		return command
+	}
+	return "'" + strings.ReplaceAll(command, "'", `'\''`) + "'"
+}
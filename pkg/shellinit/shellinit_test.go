@@ -0,0 +1,243 @@
+package shellinit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// installed returns a finder for the given tools, with zoxide outside PATH
+func installed(tools ...string) Finder {
+	return func(binary string, paths []string) (string, bool) {
+		for _, tool := range tools {
+			if tool == binary {
+				if binary == "zoxide" {
+					return "/home/me/.cargo/bin/zoxide", true
+				}
+				return binary, true
+			}
+		}
+		return "", false
+	}
+}
+
+func TestScriptSetsUpInstalledToolsInOrder(t *testing.T) {
+	script, err := Script("zsh", Environment{}, installed("starship", "fzf", "zoxide"))
+	if err != nil {
+		t.Fatalf("Script failed: %v", err)
+	}
+
+	fzf := strings.Index(script, "source <(fzf --zsh)")
+	zoxide := strings.Index(script, `eval "$(/home/me/.cargo/bin/zoxide init zsh)"`)
+	starship := strings.Index(script, `eval "$(starship init zsh)"`)
+	if fzf < 0 || zoxide < 0 || starship < 0 {
+		t.Fatalf("missing integrations in:\n%s", script)
+	}
+	if !(fzf < zoxide && zoxide < starship) {
+		t.Errorf("expected fzf, zoxide, then the prompt:\n%s", script)
+	}
+	if strings.Contains(script, "mise") || strings.Contains(script, "BUN_INSTALL") {
+		t.Errorf("set up tools that are not installed:\n%s", script)
+	}
+}
+
+func TestScriptFish(t *testing.T) {
+	script, err := Script("fish", Environment{}, installed("bun", "fzf"))
+	if err != nil {
+		t.Fatalf("Script failed: %v", err)
+	}
+	for _, want := range []string{`fish_add_path -g "$BUN_INSTALL/bin"`, "fzf --fish | source"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("expected %q in:\n%s", want, script)
+		}
+	}
+}
+
+func TestScriptRejectsUnknownShell(t *testing.T) {
+	if _, err := Script("tcsh", Environment{}, installed()); err == nil {
+		t.Errorf("expected an error for tcsh")
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if got := quote("/home/me/.cargo/bin/zoxide"); got != "/home/me/.cargo/bin/zoxide" {
+		t.Errorf("plain path quoted: %s", got)
+	}
+	if got := quote("/home/my user/bin/fzf"); got != "'/home/my user/bin/fzf'" {
+		t.Errorf("unexpected quoting: %s", got)
+	}
+}
+
+// The snippets are kept here rather than in tools.json, so both must name
+// the same tools
+func TestIntegrationsMatchConfiguredTools(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "config", "tools.json"))
+	if err != nil {
+		t.Skipf("tools.json not available: %v", err)
+	}
+	var config struct {
+		Tools []struct {
+			Name             string `json:"name"`
+			ShellIntegration bool   `json:"shell_integration"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("invalid tools.json: %v", err)
+	}
+
+	known := make(map[string]bool)
+	for _, tool := range Tools() {
+		known[tool] = true
+	}
+	configured := make(map[string]bool)
+	for _, tool := range config.Tools {
+		if tool.ShellIntegration {
+			configured[tool.Name] = true
+			if !known[tool.Name] {
+				t.Errorf("%s has shell_integration but no shell-init snippet", tool.Name)
+			}
+		}
+	}
+	for tool := range known {
+		if !configured[tool] {
+			t.Errorf("%s has a shell-init snippet but no shell_integration in tools.json", tool)
+		}
+	}
+}
+
+func TestMigrateRemovesLegacyLines(t *testing.T) {
+	dir := t.TempDir()
+	bashrc := filepath.Join(dir, ".bashrc")
+	original := "alias ll='ls -l'\n\n# fzf key bindings and fuzzy completion\nsource <(fzf --bash)\n# zoxide integration\neval \"$(zoxide init bash)\"\nexport EDITOR=vim\n"
+	if err := os.WriteFile(bashrc, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	profile := filepath.Join(dir, ".profile")
+	if err := os.WriteFile(profile, []byte("export PATH=\"$HOME/.bun/bin:$PATH\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	untouched := filepath.Join(dir, ".zshrc")
+	if err := os.WriteFile(untouched, []byte("bindkey -e\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []RCFile{{Path: bashrc, Shell: "bash"}, {Path: untouched, Shell: "zsh"}, {Path: profile}, {Path: filepath.Join(dir, "missing")}}
+
+	// A dry run changes nothing
+	migrations, err := Migrate(files, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Backup != "" {
+		t.Fatalf("unexpected dry run %+v", migrations)
+	}
+	if data, _ := os.ReadFile(bashrc); string(data) != original {
+		t.Fatalf("dry run changed the file")
+	}
+
+	migrations, err = Migrate(files, false)
+	if err != nil {
+		t.Fatalf("migration failed: %v", err)
+	}
+	if len(migrations) != 2 || len(migrations[0].Removed) != 4 || !migrations[0].AddsInit || migrations[1].AddsInit {
+		t.Fatalf("unexpected migrations %+v", migrations)
+	}
+
+	data, _ := os.ReadFile(bashrc)
+	want := "alias ll='ls -l'\nexport EDITOR=vim\n\n# Shell integration of tools installed by gearbox\neval \"$(gearbox shell-init bash)\"\n"
+	if string(data) != want {
+		t.Errorf("unexpected .bashrc:\n%s", data)
+	}
+	if backup, _ := os.ReadFile(migrations[0].Backup); string(backup) != original {
+		t.Errorf("backup does not hold the original")
+	}
+	if data, _ := os.ReadFile(untouched); string(data) != "bindkey -e\n" {
+		t.Errorf("changed a file without legacy lines")
+	}
+
+	// Migrating again finds nothing
+	if migrations, _ := Migrate(files, false); len(migrations) != 0 {
+		t.Errorf("expected nothing left to migrate, got %+v", migrations)
+	}
+}
+
+func TestMigrateRemovesPathLinesOfInstallScripts(t *testing.T) {
+	t.Setenv("GOPATH", "/home/me/go")
+	bashrc := RCFile{Path: filepath.Join(t.TempDir(), ".bashrc"), Shell: "bash"}
+	original := "export PATH=\"$HOME/.cargo/bin:$PATH\"\nexport PATH=\"/home/me/go/bin:$PATH\"\n# Go environment\nexport PATH=\"/usr/local/go/bin:$PATH\"\nexport PATH=\"$HOME/bin:$PATH\"\n"
+	if err := os.WriteFile(bashrc.Path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Other tools rely on the directories, so removing one tool keeps them
+	if lines := bashrc.ToolLines("yazi"); len(lines) != 0 {
+		t.Errorf("expected the PATH lines to be left to the migration, got %q", lines)
+	}
+
+	migrations, err := Migrate([]RCFile{bashrc}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 1 || len(migrations[0].Removed) != 4 || !migrations[0].AddsInit {
+		t.Fatalf("unexpected migrations %+v", migrations)
+	}
+	if data, _ := os.ReadFile(bashrc.Path); !strings.HasPrefix(string(data), "export PATH=\"$HOME/bin:$PATH\"\n") {
+		t.Errorf("expected only the lines of install scripts to go, got:\n%s", data)
+	}
+}
+
+func TestAddInitLine(t *testing.T) {
+	dir := t.TempDir()
+	zshrc := RCFile{Path: filepath.Join(dir, ".zshrc"), Shell: "zsh"}
+
+	changed, err := AddInitLine(zshrc)
+	if err != nil || !changed {
+		t.Fatalf("expected the line to be added to a new file, got %v, %v", changed, err)
+	}
+	data, _ := os.ReadFile(zshrc.Path)
+	if string(data) != "# Shell integration of tools installed by gearbox\neval \"$(gearbox shell-init zsh)\"\n" {
+		t.Errorf("unexpected .zshrc:\n%s", data)
+	}
+
+	if changed, err := AddInitLine(zshrc); err != nil || changed {
+		t.Errorf("expected a file loading shell-init to stay unchanged, got %v, %v", changed, err)
+	}
+	if _, err := AddInitLine(RCFile{Path: filepath.Join(dir, ".profile")}); err == nil {
+		t.Error("expected an error for a file without a shell")
+	}
+}
+
+func TestRemoveAndAppendToolLines(t *testing.T) {
+	bashrc := RCFile{Path: filepath.Join(t.TempDir(), ".bashrc"), Shell: "bash"}
+	original := "alias ll='ls -l'\n\n# zoxide integration\neval \"$(zoxide init bash)\"\nsource <(fzf --bash)\n"
+	if err := os.WriteFile(bashrc.Path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := bashrc.ToolLines("zoxide")
+	if len(lines) != 2 || lines[1] != `eval "$(zoxide init bash)"` {
+		t.Fatalf("unexpected zoxide lines %q", lines)
+	}
+	backup, err := bashrc.RemoveLines(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(bashrc.Path); string(data) != "alias ll='ls -l'\nsource <(fzf --bash)\n" {
+		t.Errorf("expected only the zoxide lines to go, got:\n%s", data)
+	}
+	if data, _ := os.ReadFile(backup); string(data) != original {
+		t.Errorf("backup does not hold the original")
+	}
+
+	if err := bashrc.AppendLines(lines); err != nil {
+		t.Fatal(err)
+	}
+	if err := bashrc.AppendLines(lines); err != nil {
+		t.Fatal(err)
+	}
+	if got := bashrc.ToolLines("zoxide"); len(got) != 2 {
+		t.Errorf("expected the lines back once, got %q", got)
+	}
+}
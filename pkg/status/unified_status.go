@@ -1,24 +1,42 @@
 package status
 
 import (
+	"fmt"
 	"os/exec"
+	"sort"
 	"strings"
 	
 	"gearbox/pkg/manifest"
 	"gearbox/pkg/orchestrator"
+	"gearbox/pkg/output"
 )
 
 // ToolStatus represents the comprehensive status of a tool
 type ToolStatus struct {
-	Name                string
-	Installed           bool
-	Version             string
-	Source              string // "gearbox", "system", "unknown"
-	BinaryPaths         []string
-	InManifest          bool
-	ManifestVersion     string
-	LiveDetection       bool
-	NeedsSync          bool // True if manifest and live detection disagree
+	Name                string   `json:"name"`
+	Installed           bool     `json:"installed"`
+	Version             string   `json:"version"`
+	Source              string   `json:"source"` // "gearbox", "system", "unknown"
+	BinaryPaths         []string `json:"binary_paths"`
+	InManifest          bool     `json:"in_manifest"`
+	ManifestVersion     string   `json:"manifest_version"`
+	LiveDetection       bool     `json:"live_detection"`
+	NeedsSync          bool     `json:"needs_sync"` // True if manifest and live detection disagree, or the installation drifted
+	Drift              []manifest.Drift `json:"drift,omitempty"` // How the installation differs from the manifest
+}
+
+// StatusSummary counts the tools of a status report
+type StatusSummary struct {
+	Installed    int `json:"installed"`
+	NotInstalled int `json:"not_installed"`
+	NeedsSync    int `json:"needs_sync"`
+}
+
+// StatusReport is the structured output of 'gearbox status'
+type StatusReport struct {
+	SchemaVersion string        `json:"schema_version"`
+	Tools         []*ToolStatus `json:"tools"`
+	Summary       StatusSummary `json:"summary"`
 }
 
 // UnifiedStatusService provides consistent tool status across CLI and TUI
@@ -66,9 +84,11 @@ func (s *UnifiedStatusService) GetToolStatus(toolName string) (*ToolStatus, erro
 	}
 	
 	// Check manifest
+	var record *manifest.InstallationRecord
 	manifestData, err := s.manifest.Load()
 	if err == nil && manifestData != nil && manifestData.Installations != nil {
-		if record, exists := manifestData.Installations[toolName]; exists {
+		if found, exists := manifestData.Installations[toolName]; exists {
+			record = found
 			status.InManifest = true
 			status.ManifestVersion = record.Version
 			status.BinaryPaths = record.BinaryPaths
@@ -89,7 +109,10 @@ func (s *UnifiedStatusService) GetToolStatus(toolName string) (*ToolStatus, erro
 	}
 	
 	// Determine if sync is needed
-	status.NeedsSync = status.InManifest != status.LiveDetection
+	if record != nil {
+		status.Drift = s.orchestrator.ToolDrift(toolName, record, false)
+	}
+	status.NeedsSync = status.InManifest != status.LiveDetection || len(status.Drift) > 0
 	
 	// Final installed status (true if either manifest or live detection)
 	status.Installed = status.InManifest || status.LiveDetection
@@ -126,37 +149,53 @@ func (s *UnifiedStatusService) GetAllToolsStatus() (map[string]*ToolStatus, erro
 	return result, nil
 }
 
-// SyncManifestWithSystem reconciles manifest with actual system state
-func (s *UnifiedStatusService) SyncManifestWithSystem() error {
-	allStatus, err := s.GetAllToolsStatus()
-	if err != nil {
-		return err
-	}
-	
-	// Create a tracker for handling pre-existing tools
-	tracker, err := manifest.NewTracker()
-	if err != nil {
-		return err
+// GetStatusReport returns the status of the named tools, or of all tools,
+// sorted by name
+func (s *UnifiedStatusService) GetStatusReport(toolNames []string) (*StatusReport, error) {
+	report := &StatusReport{SchemaVersion: output.SchemaVersion, Tools: []*ToolStatus{}}
+
+	if len(toolNames) == 0 {
+		allStatus, err := s.GetAllToolsStatus()
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range allStatus {
+			report.Tools = append(report.Tools, status)
+		}
+		sort.Slice(report.Tools, func(i, j int) bool {
+			return report.Tools[i].Name < report.Tools[j].Name
+		})
+	} else {
+		for _, name := range toolNames {
+			if s.findToolConfig(name) == nil {
+				return nil, fmt.Errorf("tool not found: %s", name)
+			}
+			status, err := s.GetToolStatus(name)
+			if err != nil {
+				return nil, err
+			}
+			report.Tools = append(report.Tools, status)
+		}
 	}
-	
-	for _, status := range allStatus {
+
+	for _, status := range report.Tools {
+		if status.Installed {
+			report.Summary.Installed++
+		} else {
+			report.Summary.NotInstalled++
+		}
 		if status.NeedsSync {
-			if status.LiveDetection && !status.InManifest {
-				// Tool exists on system but not in manifest - add it as pre-existing
-				err := tracker.TrackPreExisting(status.Name, strings.Join(status.BinaryPaths, ","), status.Version)
-				if err != nil {
-					// Log error but continue with other tools
-					continue
-				}
-			} else if !status.LiveDetection && status.InManifest {
-				// Tool in manifest but not on system - mark as missing or remove
-				// This could be handled based on user preference
-				// For now, we'll leave it in the manifest as it might be temporarily unavailable
-			}
+			report.Summary.NeedsSync++
 		}
 	}
-	
-	return nil
+	return report, nil
+}
+
+// SyncManifestWithSystem reconciles the manifest with the system, resolving
+// every mismatch with the policy (orchestrator.SyncUpdate, SyncReinstall,
+// SyncUntrack, SyncIgnore, or SyncAsk to ask for each)
+func (s *UnifiedStatusService) SyncManifestWithSystem(policy string) error {
+	return s.orchestrator.Sync(orchestrator.SyncOptions{Policy: policy})
 }
 
 // GetInstalledCount returns count of installed tools (for TUI dashboard)
@@ -0,0 +1,229 @@
+// Package testreport writes the results of verify, doctor and install as
+// JUnit XML or TAP so that CI dashboards show them per tool.
+package testreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Format selects the report format
+type Format string
+
+const (
+	// JUnit is the JUnit XML format understood by most CI servers
+	JUnit Format = "junit"
+	// TAP is the Test Anything Protocol, version 13
+	TAP Format = "tap"
+)
+
+// Case is one tool or check
+type Case struct {
+	Name     string
+	Duration time.Duration
+	Output   string // Output of the tool or check
+	Failure  string // Empty when the case passed
+}
+
+// Suite is the result of one command
+type Suite struct {
+	Name      string // Command that ran, such as "verify"
+	Timestamp time.Time
+	Cases     []Case
+}
+
+// Add appends a case
+func (s *Suite) Add(c Case) {
+	s.Cases = append(s.Cases, c)
+}
+
+// Failures counts the failed cases
+func (s *Suite) Failures() int {
+	failures := 0
+	for _, c := range s.Cases {
+		if c.Failure != "" {
+			failures++
+		}
+	}
+	return failures
+}
+
+// Target is a format and the file to write it to
+type Target struct {
+	Format Format
+	Path   string // Empty for stdout
+}
+
+// ParseTarget parses "junit=<file>", "tap=<file>", "junit" or "tap".
+// Without a file the report goes to stdout.
+func ParseTarget(spec string) (Target, error) {
+	name, path, _ := strings.Cut(spec, "=")
+	target := Target{Format: Format(strings.ToLower(name)), Path: path}
+	if target.Format != JUnit && target.Format != TAP {
+		return Target{}, fmt.Errorf("unsupported report format %q (use junit=<file> or tap[=<file>])", name)
+	}
+	return target, nil
+}
+
+// ParseTargets parses the values of repeated --report flags
+func ParseTargets(specs []string) ([]Target, error) {
+	var targets []Target
+	for _, spec := range specs {
+		target, err := ParseTarget(spec)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// UsesStdout reports whether any target writes to stdout, in which case
+// commands print their text output to stderr
+func UsesStdout(targets []Target) bool {
+	for _, target := range targets {
+		if target.Path == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteAll writes the suite to every target. stdout is the writer for
+// targets without a file.
+func WriteAll(targets []Target, suite Suite, stdout io.Writer) error {
+	for _, target := range targets {
+		if err := target.write(suite, stdout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write writes the suite to the target
+func (t Target) write(suite Suite, stdout io.Writer) error {
+	w := stdout
+	if t.Path != "" {
+		file, err := os.Create(t.Path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s report: %w", t.Format, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	var err error
+	switch t.Format {
+	case JUnit:
+		err = WriteJUnit(w, suite)
+	case TAP:
+		err = WriteTAP(w, suite)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s report: %w", t.Format, err)
+	}
+	return nil
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the suite as JUnit XML with one test case per tool or check
+func WriteJUnit(w io.Writer, suite Suite) error {
+	var total time.Duration
+	testSuite := junitTestSuite{
+		Name:      "gearbox " + suite.Name,
+		Tests:     len(suite.Cases),
+		Failures:  suite.Failures(),
+		Timestamp: suite.Timestamp.Format("2006-01-02T15:04:05"),
+	}
+	for _, c := range suite.Cases {
+		total += c.Duration
+		testCase := junitTestCase{
+			Name:      c.Name,
+			Classname: "gearbox." + suite.Name,
+			Time:      junitSeconds(c.Duration),
+			SystemOut: c.Output,
+		}
+		if c.Failure != "" {
+			testCase.Failure = &junitFailure{Message: c.Failure, Text: c.Failure}
+		}
+		testSuite.Cases = append(testSuite.Cases, testCase)
+	}
+	testSuite.Time = junitSeconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{testSuite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitSeconds formats a duration as the seconds JUnit expects
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// WriteTAP writes the suite as TAP version 13. Every case carries its
+// duration and output in a YAML block, failures their message too.
+func WriteTAP(w io.Writer, suite Suite) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "TAP version 13\n")
+	fmt.Fprintf(&b, "1..%d\n", len(suite.Cases))
+	for i, c := range suite.Cases {
+		if c.Failure == "" {
+			fmt.Fprintf(&b, "ok %d - %s\n", i+1, c.Name)
+		} else {
+			fmt.Fprintf(&b, "not ok %d - %s\n", i+1, c.Name)
+		}
+
+		fmt.Fprintf(&b, "  ---\n")
+		if c.Failure != "" {
+			fmt.Fprintf(&b, "  message: %q\n", c.Failure)
+		}
+		fmt.Fprintf(&b, "  duration_ms: %d\n", c.Duration.Milliseconds())
+		if output := strings.TrimRight(c.Output, "\n"); output != "" {
+			fmt.Fprintf(&b, "  output: |\n")
+			for _, line := range strings.Split(output, "\n") {
+				fmt.Fprintf(&b, "    %s\n", line)
+			}
+		}
+		fmt.Fprintf(&b, "  ...\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
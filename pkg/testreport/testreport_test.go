@@ -0,0 +1,82 @@
+package testreport
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleSuite() Suite {
+	suite := Suite{Name: "verify", Timestamp: time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC)}
+	suite.Add(Case{Name: "fd", Duration: 120 * time.Millisecond, Output: "fd 10.2.0\n"})
+	suite.Add(Case{Name: "ripgrep", Duration: 2 * time.Second, Output: "error: bad\nexit\n", Failure: "'rg --version' failed: exit status 2"})
+	return suite
+}
+
+func TestParseTarget(t *testing.T) {
+	for spec, want := range map[string]Target{
+		"junit=out/report.xml": {Format: JUnit, Path: "out/report.xml"},
+		"TAP":                  {Format: TAP},
+		"tap=verify.tap":       {Format: TAP, Path: "verify.tap"},
+	} {
+		got, err := ParseTarget(spec)
+		if err != nil || got != want {
+			t.Errorf("ParseTarget(%q) = %+v, %v; want %+v", spec, got, err, want)
+		}
+	}
+	if _, err := ParseTarget("html=report.html"); err == nil {
+		t.Errorf("expected an error for html")
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteJUnit(&out, sampleSuite()); err != nil {
+		t.Fatalf("WriteJUnit failed: %v", err)
+	}
+
+	var parsed junitTestSuites
+	if err := xml.Unmarshal(out.Bytes(), &parsed); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, out.String())
+	}
+	suite := parsed.Suites[0]
+	if suite.Tests != 2 || suite.Failures != 1 || suite.Time != "2.120" {
+		t.Errorf("unexpected suite attributes %+v", suite)
+	}
+	if suite.Cases[0].Failure != nil || suite.Cases[0].SystemOut != "fd 10.2.0\n" {
+		t.Errorf("unexpected passing case %+v", suite.Cases[0])
+	}
+	if suite.Cases[1].Failure == nil || !strings.Contains(suite.Cases[1].Failure.Message, "exit status 2") {
+		t.Errorf("expected a failure message, got %+v", suite.Cases[1])
+	}
+}
+
+func TestWriteTAP(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteTAP(&out, sampleSuite()); err != nil {
+		t.Fatalf("WriteTAP failed: %v", err)
+	}
+
+	want := `TAP version 13
+1..2
+ok 1 - fd
+  ---
+  duration_ms: 120
+  output: |
+    fd 10.2.0
+  ...
+not ok 2 - ripgrep
+  ---
+  message: "'rg --version' failed: exit status 2"
+  duration_ms: 2000
+  output: |
+    error: bad
+    exit
+  ...
+`
+	if out.String() != want {
+		t.Errorf("unexpected TAP:\n%s", out.String())
+	}
+}
@@ -0,0 +1,187 @@
+package uninstall
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"gearbox/pkg/configfiles"
+	"gearbox/pkg/manifest"
+	"gearbox/pkg/shellinit"
+)
+
+// ArtifactKind is what a tool left outside its install location
+type ArtifactKind string
+
+const (
+	ArtifactShell      ArtifactKind = "shell"      // Lines install scripts appended to rc files
+	ArtifactConfig     ArtifactKind = "config"     // Configuration files gearbox installed
+	ArtifactCompletion ArtifactKind = "completion" // Shell completion files
+	ArtifactCache      ArtifactKind = "cache"      // Caches and databases, such as the zoxide database
+)
+
+// ArtifactKinds lists the kinds in the order plans show them
+var ArtifactKinds = []ArtifactKind{ArtifactShell, ArtifactConfig, ArtifactCompletion, ArtifactCache}
+
+// Title returns a heading for the artifacts of a kind
+func (k ArtifactKind) Title() string {
+	switch k {
+	case ArtifactShell:
+		return "Shell integration"
+	case ArtifactConfig:
+		return "Configuration files"
+	case ArtifactCompletion:
+		return "Shell completions"
+	case ArtifactCache:
+		return "Caches and databases"
+	default:
+		return string(k)
+	}
+}
+
+// Artifact is a file a tool left outside its install location, or the lines
+// it added to an rc file
+type Artifact struct {
+	Kind    ArtifactKind `json:"kind"`
+	Path    string       `json:"path"`
+	Lines   []string     `json:"lines,omitempty"`   // Lines removed from the rc file, which itself stays
+	Changed bool         `json:"changed,omitempty"` // The user changed the configuration file
+}
+
+// toolCaches returns the caches and databases of tools, which are user data
+// and only removed with the configuration
+var toolCaches = map[string]func() []string{
+	"zoxide": func() []string {
+		if dir := os.Getenv("_ZO_DATA_DIR"); dir != "" {
+			return []string{dir}
+		}
+		return []string{filepath.Join(dataHome(), "zoxide")}
+	},
+	"starship": func() []string {
+		return []string{filepath.Join(cacheHome(), "starship")}
+	},
+	"bat": func() []string {
+		return []string{filepath.Join(cacheHome(), "bat")}
+	},
+	"tealdeer": func() []string {
+		return []string{filepath.Join(cacheHome(), "tealdeer")}
+	},
+}
+
+// findArtifacts returns what a tool left outside its install location: the
+// rc lines of its shell integration and its completion files, and with
+// removeConfig its configuration files and caches
+func findArtifacts(target string, record *manifest.InstallationRecord, removeConfig bool) []Artifact {
+	home := os.Getenv("HOME")
+	var artifacts []Artifact
+
+	for _, file := range shellinit.RCFiles(home) {
+		if lines := file.ToolLines(target); len(lines) > 0 {
+			artifacts = append(artifacts, Artifact{Kind: ArtifactShell, Path: file.Path, Lines: lines})
+		}
+	}
+
+	if removeConfig {
+		stateDir := configfiles.ToolStateDir(filepath.Join(home, manifest.ManifestDir), target)
+		for _, path := range record.ConfigFiles {
+			if exists(path) {
+				artifacts = append(artifacts, Artifact{Kind: ArtifactConfig, Path: path, Changed: !installedCopy(path, stateDir)})
+			}
+		}
+		// Drop the copies gearbox keeps of the files it installed
+		if exists(stateDir) {
+			artifacts = append(artifacts, Artifact{Kind: ArtifactConfig, Path: stateDir})
+		}
+	}
+
+	for _, path := range completionFiles(target, record) {
+		if exists(path) {
+			artifacts = append(artifacts, Artifact{Kind: ArtifactCompletion, Path: path})
+		}
+	}
+
+	if caches, found := toolCaches[target]; found && removeConfig {
+		for _, path := range caches() {
+			if exists(path) {
+				artifacts = append(artifacts, Artifact{Kind: ArtifactCache, Path: path})
+			}
+		}
+	}
+	return artifacts
+}
+
+// completionFiles returns where install scripts write the completions of a
+// tool's binaries for bash, zsh and fish
+func completionFiles(target string, record *manifest.InstallationRecord) []string {
+	names := []string{target}
+	for _, path := range record.BinaryPaths {
+		if name := filepath.Base(path); path != "" && !contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	var paths []string
+	for _, name := range names {
+		paths = append(paths,
+			filepath.Join(dataHome(), "bash-completion", "completions", name),
+			filepath.Join(dataHome(), "zsh", "site-functions", "_"+name),
+			filepath.Join(configHome(), "fish", "completions", name+".fish"),
+		)
+	}
+	return paths
+}
+
+// installedCopy reports whether a configuration file still holds what
+// gearbox installed, one of the copies in the state directory
+func installedCopy(path, stateDir string) bool {
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	found := false
+	filepath.Walk(stateDir, func(copyPath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || found {
+			return nil
+		}
+		if data, err := os.ReadFile(copyPath); err == nil && bytes.Equal(data, current) {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+func dataHome() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.Getenv("HOME"), ".local", "share")
+}
+
+func cacheHome() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.Getenv("HOME"), ".cache")
+}
+
+func configHome() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.Getenv("HOME"), ".config")
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+func contains(list []string, item string) bool {
+	for _, s := range list {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
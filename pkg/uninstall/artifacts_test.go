@@ -0,0 +1,135 @@
+package uninstall
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gearbox/pkg/configfiles"
+	"gearbox/pkg/manifest"
+)
+
+// setupZoxideArtifacts tracks zoxide with everything it leaves behind: rc
+// lines, a completion file, a changed configuration file and its database
+func setupZoxideArtifacts(t *testing.T) (*manifest.Tracker, string) {
+	t.Helper()
+	tracker, cleanup := setupTestTracker(t)
+	t.Cleanup(cleanup)
+	home := os.Getenv("HOME")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("_ZO_DATA_DIR", "")
+
+	stateDir := configfiles.ToolStateDir(filepath.Join(home, manifest.ManifestDir), "zoxide")
+	files := map[string]string{
+		".bashrc": "alias ll='ls -l'\n\n# zoxide integration\neval \"$(zoxide init bash)\"\n",
+		".local/share/bash-completion/completions/zoxide": "complete -F _zoxide z\n",
+		".local/share/zoxide/db.zo":                       "database",
+		".config/zoxide/config":                           "changed",
+		"bin/zoxide":                                      "binary",
+	}
+	files[stateDir+"/zoxide/config"] = "installed"
+	for path, content := range files {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(home, path)
+		}
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := manifest.TrackingConfig{
+		Method:      manifest.MethodSourceBuild,
+		Version:     "0.9.0",
+		BinaryPaths: []string{filepath.Join(home, "bin", "zoxide")},
+		ConfigFiles: []string{filepath.Join(home, ".config", "zoxide", "config")},
+	}
+	if err := tracker.TrackInstallation("zoxide", config); err != nil {
+		t.Fatalf("TrackInstallation() error = %v", err)
+	}
+	return tracker, home
+}
+
+func TestRemovalEngine_PlanRemoval_FindsArtifacts(t *testing.T) {
+	setupZoxideArtifacts(t)
+	engine, err := NewRemovalEngine(SafetyStandard)
+	if err != nil {
+		t.Fatalf("NewRemovalEngine() error = %v", err)
+	}
+
+	kinds := func(options RemovalOptions) map[ArtifactKind]int {
+		plan, err := engine.PlanRemoval([]string{"zoxide"}, options)
+		if err != nil {
+			t.Fatalf("PlanRemoval() error = %v", err)
+		}
+		return plan.Summary.ArtifactBreakdown
+	}
+
+	// Configuration and the database are user data
+	if got := kinds(RemovalOptions{}); len(got) != 2 || got[ArtifactShell] != 1 || got[ArtifactCompletion] != 1 {
+		t.Errorf("expected only the rc lines and the completion, got %v", got)
+	}
+	if got := kinds(RemovalOptions{RemoveConfig: true}); got[ArtifactConfig] != 2 || got[ArtifactCache] != 1 {
+		t.Errorf("expected the configuration, its copies and the database, got %v", got)
+	}
+
+	plan, _ := engine.PlanRemoval([]string{"zoxide"}, RemovalOptions{RemoveConfig: true})
+	var changed bool
+	for _, artifact := range plan.ToRemove[0].Artifacts {
+		changed = changed || artifact.Changed
+	}
+	if !changed || len(plan.Warnings) != 1 {
+		t.Errorf("expected a warning for the changed configuration, got %+v", plan.Warnings)
+	}
+}
+
+func TestRemovalExecutor_RemovesAndRestoresArtifacts(t *testing.T) {
+	_, home := setupZoxideArtifacts(t)
+	bashrc := filepath.Join(home, ".bashrc")
+	database := filepath.Join(home, ".local", "share", "zoxide", "db.zo")
+
+	engine, err := NewRemovalEngine(SafetyStandard)
+	if err != nil {
+		t.Fatalf("NewRemovalEngine() error = %v", err)
+	}
+	options := RemovalOptions{RemoveConfig: true}
+	plan, err := engine.PlanRemoval([]string{"zoxide"}, options)
+	if err != nil {
+		t.Fatalf("PlanRemoval() error = %v", err)
+	}
+	executor, err := NewRemovalExecutor(false)
+	if err != nil {
+		t.Fatalf("NewRemovalExecutor() error = %v", err)
+	}
+	result, err := executor.ExecutePlan(plan, options)
+	if err != nil || len(result.Failed) > 0 {
+		t.Fatalf("ExecutePlan() = %+v, %v", result, err)
+	}
+
+	if data, _ := os.ReadFile(bashrc); string(data) != "alias ll='ls -l'\n" {
+		t.Errorf("expected the zoxide lines to be removed, got:\n%s", data)
+	}
+	if backups, _ := filepath.Glob(bashrc + ".backup-*"); len(backups) != 1 {
+		t.Errorf("expected a backup of the rc file, got %v", backups)
+	}
+	if _, err := os.Stat(database); !os.IsNotExist(err) {
+		t.Error("expected the database to be moved to the trash")
+	}
+
+	trash := NewTrash()
+	entry, err := trash.Get(result.TrashID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	restorer, _ := manifest.NewTracker()
+	if err := trash.Restore(entry, restorer); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if data, _ := os.ReadFile(database); string(data) != "database" {
+		t.Errorf("expected the database to be restored, got %q", data)
+	}
+	if data, _ := os.ReadFile(bashrc); string(data) != "alias ll='ls -l'\n\n# zoxide integration\neval \"$(zoxide init bash)\"\n" {
+		t.Errorf("expected the zoxide lines back, got:\n%s", data)
+	}
+}
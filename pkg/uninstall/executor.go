@@ -4,16 +4,19 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
+	"gearbox/pkg/fsutil"
 	"gearbox/pkg/manifest"
+	"gearbox/pkg/shellinit"
 )
 
 // RemovalExecutor handles the actual execution of removal operations
 type RemovalExecutor struct {
 	tracker *manifest.Tracker
 	dryRun  bool
+	trash   *Trash
+	entry   *TrashEntry // Where removed files go, nil to delete them
 }
 
 // NewRemovalExecutor creates a new removal executor
@@ -26,6 +29,7 @@ func NewRemovalExecutor(dryRun bool) (*RemovalExecutor, error) {
 	return &RemovalExecutor{
 		tracker: tracker,
 		dryRun:  dryRun,
+		trash:   NewTrash(),
 	}, nil
 }
 
@@ -51,6 +55,11 @@ func (e *RemovalExecutor) ExecutePlan(plan *RemovalPlan, options RemovalOptions)
 		result.BackupCreated = true
 	}
 
+	// Move removed files to the trash unless they are purged right away
+	if !e.dryRun && !options.Purge {
+		e.entry = e.trash.NewEntry()
+	}
+
 	// Execute removal actions
 	for _, action := range plan.ToRemove {
 		if err := e.executeRemovalAction(action, result); err != nil {
@@ -60,8 +69,23 @@ func (e *RemovalExecutor) ExecutePlan(plan *RemovalPlan, options RemovalOptions)
 			})
 		} else {
 			result.Removed = append(result.Removed, action.Target)
+			if err := e.removeArtifacts(action, result); err != nil {
+				result.Failed = append(result.Failed, RemovalError{
+					Target: action.Target,
+					Error:  err.Error(),
+				})
+			}
+			if err := e.untrack(action); err != nil {
+				result.Failed = append(result.Failed, RemovalError{
+					Target: action.Target,
+					Error:  err.Error(),
+				})
+			}
 		}
 	}
+	if e.entry != nil && !e.entry.Empty() {
+		result.TrashID = e.entry.ID
+	}
 
 	// Execute dependency actions
 	for _, depAction := range plan.Dependencies {
@@ -97,7 +121,7 @@ func (e *RemovalExecutor) executeRemovalAction(action RemovalAction, result *Rem
 	case RemovalNpmGlobal:
 		return e.removeNpmTool(action.Target)
 	case RemovalSystemPackage:
-		return e.removeSystemPackage(action.Target)
+		return e.removeSystemPackages(action)
 	case RemovalSourceBuild, RemovalManualDelete:
 		return e.removeFiles(action.Paths, result)
 	case RemovalBundle:
@@ -109,6 +133,93 @@ func (e *RemovalExecutor) executeRemovalAction(action RemovalAction, result *Rem
 	}
 }
 
+// removeArtifacts removes what a removed tool left outside its install
+// location. Files go to the trash like the tool's own; lines are removed from
+// rc files, which are backed up next to the original, and kept in the trash.
+func (e *RemovalExecutor) removeArtifacts(action RemovalAction, result *RemovalResult) error {
+	var errors []string
+	for _, artifact := range action.Artifacts {
+		if e.dryRun {
+			fmt.Printf("🧪 DRY RUN: Would remove %s %s\n", artifact.Kind, artifact.Path)
+			continue
+		}
+
+		if artifact.Kind == ArtifactShell {
+			backup, err := (shellinit.RCFile{Path: artifact.Path}).RemoveLines(artifact.Lines)
+			if err != nil {
+				errors = append(errors, err.Error())
+				continue
+			}
+			if backup == "" {
+				continue // The lines were removed since the plan
+			}
+			fmt.Printf("🐚 Removed %d lines from %s (backup: %s)\n", len(artifact.Lines), artifact.Path, backup)
+			if e.entry != nil {
+				if err := e.entry.KeepLines(artifact.Path, artifact.Lines); err != nil {
+					errors = append(errors, fmt.Sprintf("failed to keep the lines of %s in the trash: %v", artifact.Path, err))
+				}
+			}
+			continue
+		}
+
+		size, err := e.discard(artifact.Path)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("failed to remove %s %s: %v", artifact.Kind, artifact.Path, err))
+			continue
+		}
+		result.SpaceFreed += size
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("artifact removal errors: %s", strings.Join(errors, "; "))
+	}
+	return nil
+}
+
+// untrack removes a removed tool from the manifest. Tools whose files went
+// to the trash leave their record there, so that undoing the removal tracks
+// them again.
+func (e *RemovalExecutor) untrack(action RemovalAction) error {
+	if e.dryRun {
+		return nil
+	}
+	record, tracked := e.tracker.GetInstallation(action.Target)
+	if !tracked {
+		return nil
+	}
+
+	if e.entry != nil {
+		switch action.Method {
+		case RemovalSourceBuild, RemovalManualDelete, RemovalGoInstall:
+			if err := e.entry.KeepRecord(action.Target, record); err != nil {
+				return fmt.Errorf("failed to keep the record in the trash: %w", err)
+			}
+		}
+	}
+	return e.tracker.Untrack(action.Target)
+}
+
+// discard moves a path to the trash, or deletes it when there is no trash,
+// and returns the size it took
+func (e *RemovalExecutor) discard(path string) (int64, error) {
+	if e.entry != nil {
+		return e.entry.Move(path)
+	}
+
+	var size int64
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			size = fsutil.DirSize(path)
+		} else {
+			size = info.Size()
+		}
+	}
+	if err := os.RemoveAll(path); err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	return size, nil
+}
+
 // removeCargoTool removes a Rust tool installed via cargo
 func (e *RemovalExecutor) removeCargoTool(toolName string) error {
 	cmd := exec.Command("cargo", "uninstall", toolName)
@@ -123,7 +234,7 @@ func (e *RemovalExecutor) removeCargoTool(toolName string) error {
 func (e *RemovalExecutor) removeGoTool(toolName string, paths []string) error {
 	// Remove binaries
 	for _, path := range paths {
-		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		if _, err := e.discard(path); err != nil {
 			return fmt.Errorf("failed to remove binary %s: %w", path, err)
 		}
 	}
@@ -155,6 +266,21 @@ func (e *RemovalExecutor) removeNpmTool(toolName string) error {
 	return nil
 }
 
+// removeSystemPackages removes the system packages recorded for a tool, such
+// as fd-find for fd, or the package named like the tool when none are
+func (e *RemovalExecutor) removeSystemPackages(action RemovalAction) error {
+	packages := action.Packages
+	if len(packages) == 0 {
+		packages = []string{action.Target}
+	}
+	for _, packageName := range packages {
+		if err := e.removeSystemPackage(packageName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // removeSystemPackage removes a system package (careful implementation)
 func (e *RemovalExecutor) removeSystemPackage(packageName string) error {
 	// For safety, we only remove packages that were installed by gearbox
@@ -168,6 +294,12 @@ func (e *RemovalExecutor) removeSystemPackage(packageName string) error {
 		cmd = exec.Command("yum", "remove", "-y", packageName)
 	} else if _, err := exec.LookPath("dnf"); err == nil {
 		cmd = exec.Command("dnf", "remove", "-y", packageName)
+	} else if _, err := exec.LookPath("pacman"); err == nil {
+		cmd = exec.Command("pacman", "-R", "--noconfirm", packageName)
+	} else if _, err := exec.LookPath("zypper"); err == nil {
+		cmd = exec.Command("zypper", "--non-interactive", "remove", packageName)
+	} else if _, err := exec.LookPath("apk"); err == nil {
+		cmd = exec.Command("apk", "del", packageName)
 	} else {
 		return fmt.Errorf("no supported package manager found")
 	}
@@ -179,7 +311,7 @@ func (e *RemovalExecutor) removeSystemPackage(packageName string) error {
 	return nil
 }
 
-// removeFiles removes files and directories
+// removeFiles moves files and directories to the trash, or deletes them
 func (e *RemovalExecutor) removeFiles(paths []string, result *RemovalResult) error {
 	var errors []string
 	var totalSize int64
@@ -189,21 +321,12 @@ func (e *RemovalExecutor) removeFiles(paths []string, result *RemovalResult) err
 			continue
 		}
 
-		// Get size before removal for space calculation
-		if info, err := os.Stat(path); err == nil {
-			if info.IsDir() {
-				if size, err := getDirSize(path); err == nil {
-					totalSize += size
-				}
-			} else {
-				totalSize += info.Size()
-			}
-		}
-
-		// Remove the path
-		if err := os.RemoveAll(path); err != nil && !os.IsNotExist(err) {
+		size, err := e.discard(path)
+		if err != nil {
 			errors = append(errors, fmt.Sprintf("failed to remove %s: %v", path, err))
+			continue
 		}
+		totalSize += size
 	}
 
 	result.SpaceFreed += totalSize
@@ -250,21 +373,6 @@ func (e *RemovalExecutor) executeDependencyAction(action DependencyAction, resul
 	}
 }
 
-// getDirSize calculates the total size of a directory
-func getDirSize(path string) (int64, error) {
-	var size int64
-	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip files we can't access
-		}
-		if !info.IsDir() {
-			size += info.Size()
-		}
-		return nil
-	})
-	return size, err
-}
-
 // RemovalResult contains the results of a removal operation
 type RemovalResult struct {
 	Removed       []string       `json:"removed"`
@@ -272,6 +380,7 @@ type RemovalResult struct {
 	DryRun        bool           `json:"dry_run"`
 	SpaceFreed    int64          `json:"space_freed"`
 	BackupCreated bool           `json:"backup_created"`
+	TrashID       string         `json:"trash_id,omitempty"` // Removal in the trash that 'uninstall --undo' restores
 }
 
 // RemovalError represents a failure in removal
@@ -316,7 +425,9 @@ func (r *RemovalResult) Summary() string {
 		summary.WriteString(fmt.Sprintf("❌ Failed to remove: %d tools\n", len(r.Failed)))
 	}
 	
-	if r.SpaceFreed > 0 {
+	if r.TrashID != "" {
+		summary.WriteString(fmt.Sprintf("♻️  Moved %s to the trash as %s (undo with 'gearbox uninstall --undo %s')\n", r.FormatSpaceFreed(), r.TrashID, r.TrashID))
+	} else if r.SpaceFreed > 0 {
 		summary.WriteString(fmt.Sprintf("💾 Space freed: %s\n", r.FormatSpaceFreed()))
 	}
 	
@@ -1,7 +1,6 @@
 package uninstall
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -498,53 +497,6 @@ func TestRemovalExecutor_executeDependencyAction_DryRun(t *testing.T) {
 	}
 }
 
-func TestGetDirSize(t *testing.T) {
-	// Create temporary directory structure
-	tempDir := t.TempDir()
-	
-	// Create nested directories with files
-	subDir := filepath.Join(tempDir, "subdir")
-	if err := os.Mkdir(subDir, 0755); err != nil {
-		t.Fatalf("Failed to create subdirectory: %v", err)
-	}
-	
-	file1 := filepath.Join(tempDir, "file1.txt")
-	file2 := filepath.Join(subDir, "file2.txt")
-	
-	content1 := "Hello, World!"
-	content2 := "This is a test file."
-	
-	if err := os.WriteFile(file1, []byte(content1), 0644); err != nil {
-		t.Fatalf("Failed to create file1: %v", err)
-	}
-	
-	if err := os.WriteFile(file2, []byte(content2), 0644); err != nil {
-		t.Fatalf("Failed to create file2: %v", err)
-	}
-	
-	size, err := getDirSize(tempDir)
-	if err != nil {
-		t.Errorf("getDirSize() error = %v", err)
-		return
-	}
-	
-	expectedSize := int64(len(content1) + len(content2))
-	if size != expectedSize {
-		t.Errorf("getDirSize() = %d, want %d", size, expectedSize)
-	}
-}
-
-func TestGetDirSize_NonExistentDir(t *testing.T) {
-	size, err := getDirSize("/nonexistent/directory")
-	if err == nil {
-		t.Error("getDirSize() should error for non-existent directory")
-	}
-	
-	if size != 0 {
-		t.Errorf("getDirSize() should return 0 for non-existent directory, got %d", size)
-	}
-}
-
 func TestRemovalResult_FormatSpaceFreed(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -801,20 +753,33 @@ func BenchmarkRemovalResult_Summary(b *testing.B) {
 	}
 }
 
-func BenchmarkGetDirSize(b *testing.B) {
-	// Create a temporary directory with some files
-	tempDir := b.TempDir()
+func TestRemovalExecutor_RemovesRecordedSystemPackages(t *testing.T) {
+	_, cleanup := setupTestTracker(t)
+	defer cleanup()
 	
-	for i := 0; i < 10; i++ {
-		file := filepath.Join(tempDir, fmt.Sprintf("file%d.txt", i))
-		content := strings.Repeat("test content ", 100) // ~1.3KB per file
-		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
-			b.Fatalf("Failed to create test file: %v", err)
-		}
+	// A package manager that records what it was asked to remove
+	bin := t.TempDir()
+	calls := filepath.Join(bin, "calls")
+	script := "#!/bin/sh\necho \"$@\" >> " + calls + "\n"
+	if err := os.WriteFile(filepath.Join(bin, "apt"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
 	}
+	t.Setenv("PATH", bin)
 	
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_, _ = getDirSize(tempDir)
+	executor, err := NewRemovalExecutor(false)
+	if err != nil {
+		t.Fatalf("NewRemovalExecutor() error = %v", err)
+	}
+	action := RemovalAction{Target: "fd", Method: RemovalSystemPackage, Packages: []string{"fd-find"}}
+	if err := executor.executeRemovalAction(action, &RemovalResult{}); err != nil {
+		t.Fatalf("executeRemovalAction() error = %v", err)
+	}
+	
+	data, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatal(err)
 	}
-}
\ No newline at end of file
+	if got := strings.TrimSpace(string(data)); got != "remove -y fd-find" {
+		t.Errorf("expected the fd-find package to be removed, got %q", got)
+	}
+}
@@ -36,9 +36,11 @@ type RemovalAction struct {
 	Target      string        `json:"target"`
 	Method      RemovalMethod `json:"method"`
 	Paths       []string      `json:"paths"`
+	Packages    []string      `json:"packages,omitempty"` // System packages to remove, such as fd-find for fd
 	Reason      string        `json:"reason"`
 	Dependencies []string     `json:"dependencies"`
 	IsSafe      bool          `json:"is_safe"`
+	Artifacts   []Artifact    `json:"artifacts,omitempty"` // Shell integration, configuration, completions and caches
 }
 
 // KeepReason explains why a tool should not be removed
@@ -80,6 +82,16 @@ type RemovalSummary struct {
 	MethodBreakdown    map[RemovalMethod]int     `json:"method_breakdown"`
 	DependencyActions  map[string]int            `json:"dependency_actions"`
 	EstimatedSpaceFreed string                   `json:"estimated_space_freed"`
+	ArtifactBreakdown  map[ArtifactKind]int      `json:"artifact_breakdown"`
+}
+
+// ArtifactCount returns how many artifacts the plan removes
+func (s RemovalSummary) ArtifactCount() int {
+	count := 0
+	for _, n := range s.ArtifactBreakdown {
+		count += n
+	}
+	return count
 }
 
 // RemovalEngine handles the analysis and planning of tool removal
@@ -183,6 +195,7 @@ func (r *RemovalEngine) analyzeTarget(target string, plan *RemovalPlan, options
 		Target:       target,
 		Method:       r.getRemovalMethod(record.Method),
 		Paths:        record.BinaryPaths,
+		Packages:     record.SystemPackages,
 		Dependencies: record.Dependencies,
 		IsSafe:       canRemove,
 		Reason:       "User requested removal",
@@ -193,9 +206,16 @@ func (r *RemovalEngine) analyzeTarget(target string, plan *RemovalPlan, options
 		action.Paths = append(action.Paths, record.BuildDir)
 	}
 
-	// Add config files if removing
-	if options.RemoveConfig && len(record.ConfigFiles) > 0 {
-		action.Paths = append(action.Paths, record.ConfigFiles...)
+	// Add what the tool left outside its install location
+	action.Artifacts = findArtifacts(target, record, options.RemoveConfig)
+	for _, artifact := range action.Artifacts {
+		if artifact.Changed {
+			plan.Warnings = append(plan.Warnings, SafetyWarning{
+				Target:  target,
+				Level:   "warning",
+				Message: "Removing configuration file you changed: " + artifact.Path,
+			})
+		}
 	}
 
 	plan.ToRemove = append(plan.ToRemove, action)
@@ -294,11 +314,15 @@ func (r *RemovalEngine) generateSummary(plan *RemovalPlan) {
 		WarningCount:      len(plan.Warnings),
 		MethodBreakdown:   make(map[RemovalMethod]int),
 		DependencyActions: make(map[string]int),
+		ArtifactBreakdown: make(map[ArtifactKind]int),
 	}
 
-	// Count removal methods
+	// Count removal methods and artifacts
 	for _, action := range plan.ToRemove {
 		summary.MethodBreakdown[action.Method]++
+		for _, artifact := range action.Artifacts {
+			summary.ArtifactBreakdown[artifact.Kind]++
+		}
 	}
 
 	// Count dependency actions
@@ -313,11 +337,12 @@ func (r *RemovalEngine) generateSummary(plan *RemovalPlan) {
 type RemovalOptions struct {
 	Force               bool   // Force removal even if there are dependents
 	Cascade             bool   // Remove unused dependencies
-	RemoveConfig        bool   // Remove configuration files
+	RemoveConfig        bool   // Remove configuration files and caches
 	DryRun              bool   // Only plan, don't execute
 	Backup              bool   // Create backup before removal
 	BackupSuffix        string // Suffix for backup files
 	RemoveBundleContents bool   // Remove all tools in bundle, not just bundle tracking
+	Purge               bool   // Delete files instead of moving them to the trash
 }
 
 // ValidatePlan checks if a removal plan is safe to execute
@@ -2,6 +2,7 @@ package uninstall
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -371,6 +372,38 @@ func TestRemovalEngine_PlanRemoval_StandaloneTool(t *testing.T) {
 	}
 }
 
+func TestRemovalEngine_PlanRemoval_SystemPackage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	
+	engine, err := NewRemovalEngine(SafetyStandard)
+	if err != nil {
+		t.Fatalf("NewRemovalEngine() error = %v", err)
+	}
+	
+	// An adopted tool whose package is named differently
+	config := manifest.TrackingConfig{
+		Method:         manifest.MethodSystemPackage,
+		BinaryPaths:    []string{"/usr/bin/fdfind"},
+		SystemPackages: []string{"fd-find"},
+	}
+	if err := engine.tracker.TrackInstallation("fd", config); err != nil {
+		t.Fatalf("Failed to track fd: %v", err)
+	}
+	
+	plan, err := engine.PlanRemoval([]string{"fd"}, RemovalOptions{})
+	if err != nil {
+		t.Fatalf("PlanRemoval() error = %v", err)
+	}
+	if len(plan.ToRemove) != 1 {
+		t.Fatalf("PlanRemoval() should plan removal of fd, got %d removals", len(plan.ToRemove))
+	}
+	
+	removal := plan.ToRemove[0]
+	if removal.Method != RemovalSystemPackage || len(removal.Packages) != 1 || removal.Packages[0] != "fd-find" {
+		t.Errorf("PlanRemoval() should remove the fd-find package, got %s %v", removal.Method, removal.Packages)
+	}
+}
+
 func TestRemovalEngine_PlanRemoval_WithConfig(t *testing.T) {
 	// Create a temporary directory for testing
 	tempDir := t.TempDir()
@@ -390,7 +423,11 @@ func TestRemovalEngine_PlanRemoval_WithConfig(t *testing.T) {
 		Method:      manifest.MethodSourceBuild,
 		Version:     "1.0.0",
 		BinaryPaths: []string{"/usr/local/bin/tool-with-config"},
-		ConfigFiles: []string{"/home/user/.config/tool.conf", "/etc/tool/config.ini"},
+		ConfigFiles: []string{filepath.Join(tempDir, ".config", "tool.conf"), filepath.Join(tempDir, ".toolrc")},
+	}
+	for _, path := range config.ConfigFiles {
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte("config"), 0644)
 	}
 	if err := engine.tracker.TrackInstallation("tool-with-config", config); err != nil {
 		t.Fatalf("Failed to track tool with config: %v", err)
@@ -413,26 +450,15 @@ func TestRemovalEngine_PlanRemoval_WithConfig(t *testing.T) {
 	removal := plan.ToRemove[0]
 	
 	// Check that config files are included when RemoveConfig is true
-	expectedPaths := []string{
-		"/usr/local/bin/tool-with-config",
-		"/home/user/.config/tool.conf",
-		"/etc/tool/config.ini",
+	if len(removal.Paths) != 1 || removal.Paths[0] != "/usr/local/bin/tool-with-config" {
+		t.Errorf("PlanRemoval() paths = %v, want only the binary", removal.Paths)
 	}
-	
-	if len(removal.Paths) != len(expectedPaths) {
-		t.Errorf("PlanRemoval() paths count = %d, want %d", len(removal.Paths), len(expectedPaths))
+	if len(removal.Artifacts) != len(config.ConfigFiles) {
+		t.Fatalf("PlanRemoval() artifacts = %+v, want the config files", removal.Artifacts)
 	}
-	
-	for _, expectedPath := range expectedPaths {
-		found := false
-		for _, path := range removal.Paths {
-			if path == expectedPath {
-				found = true
-				break
-			}
-		}
-		if !found {
-			t.Errorf("PlanRemoval() should include config path %s", expectedPath)
+	for i, expectedPath := range config.ConfigFiles {
+		if artifact := removal.Artifacts[i]; artifact.Kind != ArtifactConfig || artifact.Path != expectedPath {
+			t.Errorf("PlanRemoval() should include config path %s, got %+v", expectedPath, artifact)
 		}
 	}
 }
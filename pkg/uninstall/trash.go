@@ -0,0 +1,381 @@
+package uninstall
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"gearbox/pkg/fsutil"
+	"gearbox/pkg/manifest"
+	"gearbox/pkg/shellinit"
+)
+
+const (
+	// TrashDir is where removed files are kept, below the manifest directory
+	TrashDir = "trash"
+	// trashEntryFile holds the metadata of a removal in its trash directory
+	trashEntryFile = "entry.json"
+
+	// DefaultTrashDays is how long removed files are kept
+	DefaultTrashDays = 30
+	// DefaultTrashSizeMB is how large the trash may grow before the oldest
+	// removals are purged
+	DefaultTrashSizeMB = 2048
+)
+
+// TrashedFile is a file or directory moved to the trash
+type TrashedFile struct {
+	Original string `json:"original"`
+	Stored   string `json:"stored"` // Name below the files directory of the entry
+	Size     int64  `json:"size"`
+}
+
+// RCEdit is a set of lines removed from an rc file
+type RCEdit struct {
+	Path  string   `json:"path"`
+	Lines []string `json:"lines"`
+}
+
+// TrashEntry is one removal in the trash: the files it moved, the lines it
+// removed from rc files and the manifest records of the tools it removed
+type TrashEntry struct {
+	ID        string                                  `json:"id"`
+	CreatedAt time.Time                               `json:"created_at"`
+	Files     []TrashedFile                           `json:"files"`
+	Edits     []RCEdit                                `json:"edits,omitempty"`
+	Records   map[string]*manifest.InstallationRecord `json:"records,omitempty"`
+	Size      int64                                   `json:"size"`
+
+	dir string
+}
+
+// Trash keeps removed files so that an uninstall can be undone
+type Trash struct {
+	Dir string
+}
+
+// NewTrash returns the trash in ~/.gearbox/trash
+func NewTrash() *Trash {
+	return &Trash{Dir: filepath.Join(os.Getenv("HOME"), manifest.ManifestDir, TrashDir)}
+}
+
+// NewEntry starts a removal in the trash, named by the time. Its directory
+// is only created when a file is moved into it.
+func (t *Trash) NewEntry() *TrashEntry {
+	now := time.Now()
+	id := now.Format("20060102-150405")
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(t.Dir, id)); os.IsNotExist(err) {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", now.Format("20060102-150405"), i)
+	}
+	return &TrashEntry{ID: id, CreatedAt: now, Records: make(map[string]*manifest.InstallationRecord), dir: filepath.Join(t.Dir, id)}
+}
+
+// Tools returns the tools whose records the entry keeps, sorted by name
+func (e *TrashEntry) Tools() []string {
+	var tools []string
+	for name := range e.Records {
+		tools = append(tools, name)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// Move moves a file or directory into the trash and returns its size.
+// Paths that do not exist are skipped.
+func (e *TrashEntry) Move(path string) (int64, error) {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	size := info.Size()
+	if info.IsDir() {
+		size = fsutil.DirSize(path)
+	}
+
+	filesDir := filepath.Join(e.dir, "files")
+	if err := os.MkdirAll(filesDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create trash: %w", err)
+	}
+	stored := fmt.Sprintf("%d-%s", len(e.Files), filepath.Base(path))
+	if err := movePath(path, filepath.Join(filesDir, stored)); err != nil {
+		return 0, err
+	}
+
+	e.Files = append(e.Files, TrashedFile{Original: path, Stored: stored, Size: size})
+	e.Size += size
+	return size, e.save()
+}
+
+// KeepLines keeps the lines removed from an rc file, so that undoing the
+// removal appends them again
+func (e *TrashEntry) KeepLines(path string, lines []string) error {
+	if err := os.MkdirAll(e.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create trash: %w", err)
+	}
+	e.Edits = append(e.Edits, RCEdit{Path: path, Lines: lines})
+	return e.save()
+}
+
+// Empty reports whether the removal kept nothing to restore
+func (e *TrashEntry) Empty() bool {
+	return len(e.Files) == 0 && len(e.Edits) == 0
+}
+
+// KeepRecord keeps the manifest record of a removed tool, so that undoing
+// the removal tracks it again
+func (e *TrashEntry) KeepRecord(toolName string, record *manifest.InstallationRecord) error {
+	e.Records[toolName] = record
+	if e.Empty() {
+		// Nothing was moved, so there is nothing to restore the record with
+		return nil
+	}
+	return e.save()
+}
+
+// save writes the metadata of the entry
+func (e *TrashEntry) save() error {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(e.dir, trashEntryFile), data, 0644)
+}
+
+// List returns the removals in the trash, newest first
+func (t *Trash) List() ([]*TrashEntry, error) {
+	dirs, err := os.ReadDir(t.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var entries []*TrashEntry
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		entry, err := t.load(dir.Name())
+		if err != nil {
+			continue // Not a removal, or one that never moved a file
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+// Get returns a removal in the trash, the newest one for an empty ID
+func (t *Trash) Get(id string) (*TrashEntry, error) {
+	if id != "" {
+		entry, err := t.load(id)
+		if err != nil {
+			return nil, fmt.Errorf("no removal %s in the trash", id)
+		}
+		return entry, nil
+	}
+
+	entries, err := t.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("the trash is empty")
+	}
+	return entries[0], nil
+}
+
+// load reads the metadata of a removal
+func (t *Trash) load(id string) (*TrashEntry, error) {
+	dir := filepath.Join(t.Dir, filepath.Base(id))
+	data, err := os.ReadFile(filepath.Join(dir, trashEntryFile))
+	if err != nil {
+		return nil, err
+	}
+	var entry TrashEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	entry.dir = dir
+	if entry.Records == nil {
+		entry.Records = make(map[string]*manifest.InstallationRecord)
+	}
+	return &entry, nil
+}
+
+// Restore moves the files of a removal back, appends the lines it removed
+// to rc files and tracks its tools again. A file whose original path exists
+// again is left in the trash, and so is the record of a tool tracked again;
+// the removal stays in the trash until everything is restored.
+func (t *Trash) Restore(entry *TrashEntry, tracker *manifest.Tracker) error {
+	var problems []string
+	var remaining []TrashedFile
+	for _, file := range entry.Files {
+		if _, err := os.Lstat(file.Original); err == nil {
+			problems = append(problems, fmt.Sprintf("%s already exists", file.Original))
+			remaining = append(remaining, file)
+			continue
+		}
+		err := os.MkdirAll(filepath.Dir(file.Original), 0755)
+		if err == nil {
+			err = movePath(filepath.Join(entry.dir, "files", file.Stored), file.Original)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("failed to restore %s: %v", file.Original, err))
+			remaining = append(remaining, file)
+		}
+	}
+
+	var remainingEdits []RCEdit
+	for _, edit := range entry.Edits {
+		if err := (shellinit.RCFile{Path: edit.Path}).AppendLines(edit.Lines); err != nil {
+			problems = append(problems, err.Error())
+			remainingEdits = append(remainingEdits, edit)
+		}
+	}
+
+	for _, name := range entry.Tools() {
+		if tracker.IsInstalled(name) {
+			problems = append(problems, fmt.Sprintf("%s is tracked again", name))
+			continue
+		}
+		if err := tracker.RestoreInstallation(name, entry.Records[name]); err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		delete(entry.Records, name)
+	}
+
+	if len(problems) == 0 {
+		return os.RemoveAll(entry.dir)
+	}
+	entry.Files = remaining
+	entry.Edits = remainingEdits
+	entry.Size = 0
+	for _, file := range remaining {
+		entry.Size += file.Size
+	}
+	if err := entry.save(); err != nil {
+		return err
+	}
+	return fmt.Errorf("partially restored: %s", strings.Join(problems, "; "))
+}
+
+// Purge deletes a removal from the trash for good
+func (t *Trash) Purge(entry *TrashEntry) error {
+	return os.RemoveAll(entry.dir)
+}
+
+// AutoPurge deletes removals older than maxAge, then the oldest ones while
+// the trash is larger than maxSize. The newest removal is always kept, so
+// that the last uninstall can be undone.
+func (t *Trash) AutoPurge(maxAge time.Duration, maxSize int64) ([]*TrashEntry, error) {
+	entries, err := t.List()
+	if err != nil {
+		return nil, err
+	}
+
+	purgeable := selectPurgeable(entries, maxAge, maxSize, time.Now())
+	for _, entry := range purgeable {
+		if err := t.Purge(entry); err != nil {
+			return nil, fmt.Errorf("failed to purge %s: %w", entry.ID, err)
+		}
+	}
+	return purgeable, nil
+}
+
+// selectPurgeable returns the removals AutoPurge deletes from entries sorted
+// newest first
+func selectPurgeable(entries []*TrashEntry, maxAge time.Duration, maxSize int64, now time.Time) []*TrashEntry {
+	if len(entries) <= 1 {
+		return nil
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+	}
+
+	var purgeable []*TrashEntry
+	for i := len(entries) - 1; i > 0; i-- {
+		entry := entries[i]
+		if now.Sub(entry.CreatedAt) > maxAge || total > maxSize {
+			purgeable = append(purgeable, entry)
+			total -= entry.Size
+		}
+	}
+	return purgeable
+}
+
+// movePath renames a file or directory, copying it when the destination is
+// on another file system
+func movePath(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	if err := copyPath(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	return os.RemoveAll(src)
+}
+
+// copyPath recursively copies a file or directory, preserving file modes
+// and symlinks
+func copyPath(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, relPath)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case !info.Mode().IsRegular():
+			return nil
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
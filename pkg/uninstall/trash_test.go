@@ -0,0 +1,153 @@
+package uninstall
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gearbox/pkg/manifest"
+)
+
+func TestRemovalExecutor_ExecutePlan_MovesFilesToTheTrash(t *testing.T) {
+	tracker, cleanup := setupTestTracker(t)
+	defer cleanup()
+
+	binDir := t.TempDir()
+	binary := filepath.Join(binDir, "gearbox-tool")
+	buildDir := filepath.Join(binDir, "build", "gearbox-tool")
+	os.MkdirAll(buildDir, 0755)
+	os.WriteFile(binary, []byte("binary"), 0755)
+	os.WriteFile(filepath.Join(buildDir, "README"), []byte("readme"), 0644)
+
+	config := manifest.TrackingConfig{Method: manifest.MethodSourceBuild, Version: "1.0.0", BinaryPaths: []string{binary}, BuildDir: buildDir}
+	if err := tracker.TrackInstallation("gearbox-tool", config); err != nil {
+		t.Fatalf("TrackInstallation() error = %v", err)
+	}
+
+	executor, err := NewRemovalExecutor(false)
+	if err != nil {
+		t.Fatalf("NewRemovalExecutor() error = %v", err)
+	}
+	plan := &RemovalPlan{ToRemove: []RemovalAction{
+		{Target: "gearbox-tool", Method: RemovalSourceBuild, Paths: []string{binary, buildDir}},
+	}}
+	result, err := executor.ExecutePlan(plan, RemovalOptions{})
+	if err != nil {
+		t.Fatalf("ExecutePlan() error = %v", err)
+	}
+	if len(result.Failed) > 0 || result.TrashID == "" {
+		t.Fatalf("expected the removal in the trash, got %+v", result)
+	}
+	if _, err := os.Stat(binary); !os.IsNotExist(err) {
+		t.Error("expected the binary to be moved away")
+	}
+	if reloaded, _ := manifest.NewTracker(); reloaded.IsInstalled("gearbox-tool") {
+		t.Error("expected the tool to be untracked")
+	}
+
+	trash := NewTrash()
+	entry, err := trash.Get("")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if entry.ID != result.TrashID || len(entry.Files) != 2 || entry.Size != int64(len("binary")+len("readme")) {
+		t.Errorf("unexpected trash entry %+v", entry)
+	}
+
+	restorer, _ := manifest.NewTracker()
+	if err := trash.Restore(entry, restorer); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if data, err := os.ReadFile(binary); err != nil || string(data) != "binary" {
+		t.Errorf("expected the binary to be restored, got %q %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(buildDir, "README")); err != nil {
+		t.Errorf("expected the build directory to be restored: %v", err)
+	}
+	if reloaded, _ := manifest.NewTracker(); !reloaded.IsInstalled("gearbox-tool") {
+		t.Error("expected the tool to be tracked again")
+	}
+	if entries, _ := trash.List(); len(entries) != 0 {
+		t.Errorf("expected the restored removal to leave the trash, got %+v", entries)
+	}
+}
+
+func TestRemovalExecutor_ExecutePlan_Purge(t *testing.T) {
+	_, cleanup := setupTestTracker(t)
+	defer cleanup()
+
+	binary := filepath.Join(t.TempDir(), "gearbox-tool")
+	os.WriteFile(binary, []byte("binary"), 0755)
+
+	executor, err := NewRemovalExecutor(false)
+	if err != nil {
+		t.Fatalf("NewRemovalExecutor() error = %v", err)
+	}
+	plan := &RemovalPlan{ToRemove: []RemovalAction{
+		{Target: "gearbox-tool", Method: RemovalManualDelete, Paths: []string{binary}},
+	}}
+	result, err := executor.ExecutePlan(plan, RemovalOptions{Purge: true})
+	if err != nil {
+		t.Fatalf("ExecutePlan() error = %v", err)
+	}
+	if result.TrashID != "" || result.SpaceFreed != int64(len("binary")) {
+		t.Errorf("expected the binary to be deleted, got %+v", result)
+	}
+	if entries, _ := NewTrash().List(); len(entries) != 0 {
+		t.Errorf("expected an empty trash, got %+v", entries)
+	}
+}
+
+func TestTrash_RestoreKeepsFilesThatWouldOverwrite(t *testing.T) {
+	tracker, cleanup := setupTestTracker(t)
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "gearbox-tool")
+	os.WriteFile(path, []byte("old"), 0755)
+
+	trash := NewTrash()
+	entry := trash.NewEntry()
+	if _, err := entry.Move(path); err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+	os.WriteFile(path, []byte("new"), 0755)
+
+	if err := trash.Restore(entry, tracker); err == nil {
+		t.Error("expected an error when the original path exists again")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new" {
+		t.Errorf("expected the new file to be kept, got %q", data)
+	}
+	if entries, _ := trash.List(); len(entries) != 1 || len(entries[0].Files) != 1 {
+		t.Errorf("expected the file to stay in the trash, got %+v", entries)
+	}
+}
+
+func TestSelectPurgeable(t *testing.T) {
+	now := time.Now()
+	entries := []*TrashEntry{
+		{ID: "newest", CreatedAt: now, Size: 300},
+		{ID: "recent", CreatedAt: now.AddDate(0, 0, -1), Size: 300},
+		{ID: "old", CreatedAt: now.AddDate(0, 0, -40), Size: 10},
+	}
+	ids := func(purgeable []*TrashEntry) []string {
+		var ids []string
+		for _, entry := range purgeable {
+			ids = append(ids, entry.ID)
+		}
+		return ids
+	}
+
+	if got := ids(selectPurgeable(entries, 30*24*time.Hour, 1000, now)); len(got) != 1 || got[0] != "old" {
+		t.Errorf("expected only the old removal by age, got %v", got)
+	}
+	// Oldest removals go first until the trash fits
+	if got := ids(selectPurgeable(entries, 90*24*time.Hour, 400, now)); len(got) != 2 || got[0] != "old" || got[1] != "recent" {
+		t.Errorf("expected the oldest removals by size, got %v", got)
+	}
+	// The newest removal is kept even when it is too old or too large
+	if got := selectPurgeable(entries[:1], 0, 0, now); len(got) != 0 {
+		t.Errorf("expected a single removal to be kept, got %v", ids(got))
+	}
+}
@@ -411,12 +411,10 @@ fi
 if is_cached "just" "$BUILD_TYPE" "$version"; then
     log "Found cached just build, using cached version..."
     if get_cached_binary "just" "$BUILD_TYPE" "$version" "just"; then
-        # Add cargo bin to PATH if not already there
+        # Add cargo bin to PATH for this session; new shells get it from gearbox shell-init
         if [[ ":$PATH:" != *":$HOME/.cargo/bin:"* ]]; then
-            log "Adding ~/.cargo/bin to PATH..."
-            echo 'export PATH="$HOME/.cargo/bin:$PATH"' >> ~/.bashrc
             export PATH="$HOME/.cargo/bin:$PATH"
-            warning "You may need to restart your shell or run 'source ~/.bashrc' for PATH changes to take effect"
+            shell_integration_hint "just"
         fi
         
         success "just installed from cache successfully"
@@ -435,12 +433,10 @@ if ! is_cached "just" "$BUILD_TYPE" "$version" || ! get_cached_binary "just" "$B
         execute_command_safely cargo install --path . --locked
     fi
     
-    # Add cargo bin to PATH if not already there
+    # Add cargo bin to PATH for this session; new shells get it from gearbox shell-init
     if [[ ":$PATH:" != *":$HOME/.cargo/bin:"* ]]; then
-        log "Adding ~/.cargo/bin to PATH..."
-        echo 'export PATH="$HOME/.cargo/bin:$PATH"' >> ~/.bashrc
         export PATH="$HOME/.cargo/bin:$PATH"
-        warning "You may need to restart your shell or run 'source ~/.bashrc' for PATH changes to take effect"
+        shell_integration_hint "just"
     fi
     
     # Create system-wide symlink to ensure our version takes precedence
@@ -175,9 +175,8 @@ install_go() {
     # Clean up
     rm -f "$go_archive"
     
-    # Add Go to PATH
+    # Add Go to PATH for the build; new shells get it from gearbox shell-init
     if [[ ":$PATH:" != *":/usr/local/go/bin:"* ]]; then
-        echo 'export PATH="/usr/local/go/bin:$PATH"' >> ~/.bashrc
         export PATH="/usr/local/go/bin:$PATH"
     fi
     
@@ -486,18 +485,8 @@ fi
 
 # Setup shell integration if enabled and supported
 if [[ "$ENABLE_SHELL" == true ]] && [[ "fzf" == "fzf" ]]; then
-    log "Setting up shell integration for fzf..."
-    # Add fzf key bindings and completion
-    if [[ -f ~/.bashrc ]]; then
-        if ! grep -q "fzf" ~/.bashrc; then
-            echo "" >> ~/.bashrc
-            echo "# fzf key bindings and fuzzy completion" >> ~/.bashrc
-            echo "source <(fzf --bash)" >> ~/.bashrc
-            success "fzf shell integration added to ~/.bashrc"
-        else
-            log "fzf shell integration already configured"
-        fi
-    fi
+    # Key bindings and completion come from gearbox shell-init
+    shell_integration_hint "fzf"
 fi
 
 
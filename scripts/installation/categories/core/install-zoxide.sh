@@ -299,65 +299,8 @@ setup_shell_integration() {
         return 0
     fi
     
-    log "Setting up shell integrations..."
-    
-    # Detect available shells
-    local shells=()
-    if command -v bash &> /dev/null; then
-        shells+=("bash")
-    fi
-    if command -v zsh &> /dev/null; then
-        shells+=("zsh")
-    fi
-    if command -v fish &> /dev/null; then
-        shells+=("fish")
-    fi
-    
-    log "Detected shells: ${shells[*]}"
-    
-    # Setup shell integration for each detected shell
-    for shell in "${shells[@]}"; do
-        case $shell in
-            bash)
-                log "Setting up bash integration..."
-                if ! grep -q "zoxide init bash" ~/.bashrc 2>/dev/null; then
-                    echo '# zoxide integration' >> ~/.bashrc
-                    echo 'eval "$(zoxide init bash)"' >> ~/.bashrc
-                    log "Added zoxide integration to ~/.bashrc"
-                else
-                    log "zoxide integration already exists in ~/.bashrc"
-                fi
-                ;;
-            zsh)
-                log "Setting up zsh integration..."
-                if [[ -f ~/.zshrc ]]; then
-                    if ! grep -q "zoxide init zsh" ~/.zshrc 2>/dev/null; then
-                        echo '# zoxide integration' >> ~/.zshrc
-                        echo 'eval "$(zoxide init zsh)"' >> ~/.zshrc
-                        log "Added zoxide integration to ~/.zshrc"
-                    else
-                        log "zoxide integration already exists in ~/.zshrc"
-                    fi
-                fi
-                ;;
-            fish)
-                log "Setting up fish integration..."
-                local fish_config_dir="${XDG_CONFIG_HOME:-$HOME/.config}/fish"
-                if [[ -d "$fish_config_dir" ]]; then
-                    local fish_config="$fish_config_dir/config.fish"
-                    if ! grep -q "zoxide init fish" "$fish_config" 2>/dev/null; then
-                        echo '# zoxide integration' >> "$fish_config"
-                        echo 'zoxide init fish | source' >> "$fish_config"
-                        log "Added zoxide integration to $fish_config"
-                    else
-                        log "zoxide integration already exists in $fish_config"
-                    fi
-                fi
-                ;;
-        esac
-    done
-    
-    success "Shell integration setup completed"
+    # z and zi are set up by gearbox shell-init
+    shell_integration_hint "zoxide"
 }
 
 # Check if running as root
@@ -430,12 +373,10 @@ if [[ "$INSTALL_FZF" == true ]]; then
     fi
 fi
 
-# Add cargo bin to PATH if not already there
+# Add cargo bin to PATH for this session; new shells get it from gearbox shell-init
 if [[ ":$PATH:" != *":$HOME/.cargo/bin:"* ]]; then
-    log "Adding ~/.cargo/bin to PATH..."
-    echo 'export PATH="$HOME/.cargo/bin:$PATH"' >> ~/.bashrc
     export PATH="$HOME/.cargo/bin:$PATH"
-    warning "You may need to restart your shell or run 'source ~/.bashrc' for PATH changes to take effect"
+    shell_integration_hint "zoxide"
 fi
 
 # Create system-wide symlink to ensure our version takes precedence
@@ -473,9 +414,7 @@ if command -v zoxide &> /dev/null; then
         log "  'z' command for smart directory jumping"
         log "  'zi' command for interactive selection"
         echo
-        log "To activate shell integrations immediately, run:"
-        log "  hash -r && source ~/.bashrc"
-        log "Or simply open a new terminal window"
+        log "Open a new terminal window to use them"
     fi
     echo
     log "Installation paths:"
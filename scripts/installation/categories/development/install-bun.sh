@@ -280,13 +280,8 @@ if [[ ":$PATH:" != *":$BUN_PATH:"* ]]; then
     log "Added $BUN_PATH to PATH for this session"
     
     if [[ "$NO_SHELL" != true ]]; then
-        # Update shell profiles for persistent PATH
-        for profile in ~/.bashrc ~/.zshrc ~/.profile; do
-            if [[ -f "$profile" ]] && ! grep -q ".bun/bin" "$profile"; then
-                echo 'export PATH="$HOME/.bun/bin:$PATH"' >> "$profile"
-                log "Updated $profile to include ~/.bun/bin in PATH"
-            fi
-        done
+        # New shells get ~/.bun/bin from gearbox shell-init
+        shell_integration_hint "bun"
     fi
 fi
 
@@ -305,13 +305,8 @@ case $INSTALL_METHOD in
                             log "Added $NPM_GLOBAL_PATH to PATH for this session"
                             
                             if [[ "$NO_SHELL" != true ]]; then
-                                # Update shell profiles for persistent PATH
-                                for profile in ~/.bashrc ~/.zshrc ~/.profile; do
-                                    if [[ -f "$profile" ]] && ! grep -q ".npm-global/bin" "$profile"; then
-                                        echo 'export PATH="$HOME/.npm-global/bin:$PATH"' >> "$profile"
-                                        log "Updated $profile to include ~/.npm-global/bin in PATH"
-                                    fi
-                                done
+                                # New shells get ~/.npm-global/bin from gearbox shell-init
+                                shell_integration_hint "ccusage"
                             fi
                         fi
                     fi
@@ -357,13 +352,8 @@ case $INSTALL_METHOD in
                             log "Added $NPM_GLOBAL_PATH to PATH for this session"
                             
                             if [[ "$NO_SHELL" != true ]]; then
-                                # Update shell profiles for persistent PATH
-                                for profile in ~/.bashrc ~/.zshrc ~/.profile; do
-                                    if [[ -f "$profile" ]] && ! grep -q ".npm-global/bin" "$profile"; then
-                                        echo 'export PATH="$HOME/.npm-global/bin:$PATH"' >> "$profile"
-                                        log "Updated $profile to include ~/.npm-global/bin in PATH"
-                                    fi
-                                done
+                                # New shells get ~/.npm-global/bin from gearbox shell-init
+                                shell_integration_hint "ccusage"
                             fi
                         fi
                     fi
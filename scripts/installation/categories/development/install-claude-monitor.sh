@@ -255,14 +255,9 @@ if [[ "$SKIP_DEPS" != true ]]; then
                 curl -LsSf https://astral.sh/uv/install.sh | sh || error "Failed to install uv"
                 export PATH="$HOME/.local/bin:$PATH"
                 
-                # Update shell profiles for persistent uv access
+                # New shells get ~/.local/bin from gearbox shell-init
                 if [[ "$NO_SHELL" != true ]]; then
-                    for profile in ~/.bashrc ~/.zshrc ~/.profile; do
-                        if [[ -f "$profile" ]] && ! grep -q ".local/bin" "$profile"; then
-                            echo 'export PATH="$HOME/.local/bin:$PATH"' >> "$profile"
-                            log "Updated $profile to include ~/.local/bin in PATH"
-                        fi
-                    done
+                    shell_integration_hint "claude-monitor"
                 fi
             else
                 log "uv is already installed"
@@ -332,13 +327,8 @@ case $INSTALL_METHOD in
                     log "Added $USER_LOCAL_PATH to PATH for this session"
                     
                     if [[ "$NO_SHELL" != true ]]; then
-                        # Update shell profiles for persistent PATH
-                        for profile in ~/.bashrc ~/.zshrc ~/.profile; do
-                            if [[ -f "$profile" ]] && ! grep -q ".local/bin" "$profile"; then
-                                echo 'export PATH="$HOME/.local/bin:$PATH"' >> "$profile"
-                                log "Updated $profile to include ~/.local/bin in PATH"
-                            fi
-                        done
+                        # New shells get ~/.local/bin from gearbox shell-init
+                        shell_integration_hint "claude-monitor"
                     fi
                 fi
             fi
@@ -386,13 +376,8 @@ case $INSTALL_METHOD in
                     log "Added $USER_LOCAL_PATH to PATH for this session"
                     
                     if [[ "$NO_SHELL" != true ]]; then
-                        # Update shell profiles for persistent PATH
-                        for profile in ~/.bashrc ~/.zshrc ~/.profile; do
-                            if [[ -f "$profile" ]] && ! grep -q ".local/bin" "$profile"; then
-                                echo 'export PATH="$HOME/.local/bin:$PATH"' >> "$profile"
-                                log "Updated $profile to include ~/.local/bin in PATH"
-                            fi
-                        done
+                        # New shells get ~/.local/bin from gearbox shell-init
+                        shell_integration_hint "claude-monitor"
                     fi
                 fi
             fi
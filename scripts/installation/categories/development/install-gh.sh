@@ -168,7 +168,7 @@ if [[ "$SKIP_DEPS" != true ]]; then
         GO_VERSION="1.22.1"
         curl -fL "https://golang.org/dl/go${GO_VERSION}.linux-amd64.tar.gz" -o "/tmp/go.tar.gz"
         sudo rm -rf /usr/local/go; sudo tar -C /usr/local -xzf "/tmp/go.tar.gz"
-        export PATH="/usr/local/go/bin:$PATH"; echo 'export PATH="/usr/local/go/bin:$PATH"' >> ~/.bashrc
+        export PATH="/usr/local/go/bin:$PATH"  # New shells get it from gearbox shell-init
         rm "/tmp/go.tar.gz"
     else
         GO_VERSION=$(go version | grep -oP 'go\d+\.\d+\.\d+' | sed 's/go//')
@@ -287,25 +287,9 @@ if [[ ":$PATH:" != *":$GOPATH_BIN:"* ]]; then
         log "Adding Go binary directory to PATH..."
     fi
     
-    # Add to current session
+    # Add to current session; new shells get it from gearbox shell-init
     export PATH="$GOPATH_BIN:$PATH"
-    
-    # Add to shell profile for persistence
-    if [[ -f "$HOME/.bashrc" ]]; then
-        if ! grep -q "export PATH.*$(go env GOPATH)/bin" "$HOME/.bashrc"; then
-            echo "export PATH=\"$(go env GOPATH)/bin:\$PATH\"" >> "$HOME/.bashrc"
-            if [[ "$VERBOSE" == true ]]; then
-                log "Added Go binary path to ~/.bashrc"
-            fi
-        fi
-    fi
-    
-    # Also try .profile as fallback
-    if [[ -f "$HOME/.profile" ]]; then
-        if ! grep -q "export PATH.*$(go env GOPATH)/bin" "$HOME/.profile"; then
-            echo "export PATH=\"$(go env GOPATH)/bin:\$PATH\"" >> "$HOME/.profile"
-        fi
-    fi
+    shell_integration_hint "gopls"
 fi
 
 if [[ "$MODE" == "build" ]]; then
@@ -363,9 +363,8 @@ if [[ "$SKIP_DEPS" != true ]]; then
         sudo rm -rf /usr/local/go
         sudo tar -C /usr/local -xzf "/tmp/${GO_TARBALL}"
         
-        # Add Go to PATH
+        # Add Go to PATH for the build; new shells get it from gearbox shell-init
         export PATH="/usr/local/go/bin:$PATH"
-        echo 'export PATH="/usr/local/go/bin:$PATH"' >> ~/.bashrc
         
         rm "/tmp/${GO_TARBALL}"
     else
@@ -223,12 +223,10 @@ else
     chmod +x "$HOME/.local/bin/tokei"
     INSTALL_PATH="$HOME/.local/bin/tokei"
     
-    # Add to PATH if not already there
+    # Add to PATH for this session; new shells get it from gearbox shell-init
     if [[ ":$PATH:" != *":$HOME/.local/bin:"* ]]; then
-        log "Adding ~/.local/bin to PATH..."
-        echo 'export PATH="$HOME/.local/bin:$PATH"' >> ~/.bashrc
         export PATH="$HOME/.local/bin:$PATH"
-        warning "You may need to restart your shell or run 'source ~/.bashrc' for PATH changes to take effect"
+        shell_integration_hint "tokei"
     fi
     log "Installed tokei to user directory: $INSTALL_PATH"
 fi
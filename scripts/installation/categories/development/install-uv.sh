@@ -216,13 +216,8 @@ if [[ "$INSTALL_METHOD" == "official" ]]; then
         export PATH="$HOME/.local/bin:$PATH"
         log "Added ~/.local/bin to PATH for this session"
         
-        # Update shell profiles for persistent PATH
-        for profile in ~/.bashrc ~/.zshrc ~/.profile; do
-            if [[ -f "$profile" ]] && ! grep -q ".local/bin" "$profile"; then
-                echo 'export PATH="$HOME/.local/bin:$PATH"' >> "$profile"
-                log "Updated $profile to include ~/.local/bin in PATH"
-            fi
-        done
+        # New shells get ~/.local/bin from gearbox shell-init
+        shell_integration_hint "uv"
     fi
     
     # Verify installation
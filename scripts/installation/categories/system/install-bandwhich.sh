@@ -411,12 +411,10 @@ fi
 if is_cached "bandwhich" "$BUILD_TYPE" "$version"; then
     log "Found cached bandwhich build, using cached version..."
     if get_cached_binary "bandwhich" "$BUILD_TYPE" "$version" "bandwhich"; then
-        # Add cargo bin to PATH if not already there
+        # Add cargo bin to PATH for this session; new shells get it from gearbox shell-init
         if [[ ":$PATH:" != *":$HOME/.cargo/bin:"* ]]; then
-            log "Adding ~/.cargo/bin to PATH..."
-            echo 'export PATH="$HOME/.cargo/bin:$PATH"' >> ~/.bashrc
             export PATH="$HOME/.cargo/bin:$PATH"
-            warning "You may need to restart your shell or run 'source ~/.bashrc' for PATH changes to take effect"
+            shell_integration_hint "bandwhich"
         fi
         
         success "bandwhich installed from cache successfully"
@@ -435,12 +433,10 @@ if ! is_cached "bandwhich" "$BUILD_TYPE" "$version" || ! get_cached_binary "band
         execute_command_safely cargo install --path . --locked
     fi
     
-    # Add cargo bin to PATH if not already there
+    # Add cargo bin to PATH for this session; new shells get it from gearbox shell-init
     if [[ ":$PATH:" != *":$HOME/.cargo/bin:"* ]]; then
-        log "Adding ~/.cargo/bin to PATH..."
-        echo 'export PATH="$HOME/.cargo/bin:$PATH"' >> ~/.bashrc
         export PATH="$HOME/.cargo/bin:$PATH"
-        warning "You may need to restart your shell or run 'source ~/.bashrc' for PATH changes to take effect"
+        shell_integration_hint "bandwhich"
     fi
     
     # Create system-wide symlink to ensure our version takes precedence
@@ -275,46 +275,8 @@ configure_shell_integration() {
         return 0
     fi
     
-    log "Configuring shell integration..."
-    
-    # Detect current shell
-    local current_shell=$(basename "$SHELL" 2>/dev/null || echo "bash")
-    
-    case $current_shell in
-        bash)
-            local config_file="$HOME/.bashrc"
-            local init_line='eval "$(starship init bash)"'
-            ;;
-        zsh)
-            local config_file="$HOME/.zshrc"
-            local init_line='eval "$(starship init zsh)"'
-            ;;
-        fish)
-            local config_file="$HOME/.config/fish/config.fish"
-            local init_line='starship init fish | source'
-            mkdir -p "$(dirname "$config_file")"
-            ;;
-        *)
-            warning "Unsupported shell: $current_shell"
-            log "Manual setup required. See: https://starship.rs/guide/#%F0%9F%9A%80-installation"
-            return 0
-            ;;
-    esac
-    
-    # Check if already configured
-    if [[ -f "$config_file" ]] && grep -q "starship init" "$config_file"; then
-        log "✓ Starship already configured in $config_file"
-        return 0
-    fi
-    
-    # Add initialization line
-    log "Adding Starship initialization to $config_file"
-    echo "" >> "$config_file"
-    echo "# Initialize Starship prompt" >> "$config_file"
-    echo "$init_line" >> "$config_file"
-    
-    success "Shell integration configured for $current_shell"
-    log "Restart your shell or run 'source $config_file' to activate"
+    # The prompt is initialized by gearbox shell-init
+    shell_integration_hint "starship"
 }
 
 # Check if running as root
@@ -544,10 +506,6 @@ if command -v starship &> /dev/null; then
     echo
     check_nerd_fonts
     
-    # Configure shell integration
-    echo
-    configure_shell_integration
-    
     # Show usage information
     echo
     log "Build type: $BUILD_TYPE"
@@ -583,4 +541,6 @@ fi
 sudo ldconfig
 
 success "starship installation completed!"
-log "Restart your shell or run 'source ~/.bashrc' (or your shell's config) to activate"
+
+# Explain how the prompt gets activated
+configure_shell_integration
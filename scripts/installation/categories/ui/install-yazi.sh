@@ -523,12 +523,10 @@ else
     cache_build "yazi" "$BUILD_TYPE" "$BUILD_DIR/yazi" "$BUILD_DIR/ya"
 fi
 
-# Add cargo bin to PATH if not already there
+# Add cargo bin to PATH for this session; new shells get it from gearbox shell-init
 if [[ ":$PATH:" != *":$HOME/.cargo/bin:"* ]]; then
-    log "Adding ~/.cargo/bin to PATH..."
-    echo 'export PATH="$HOME/.cargo/bin:$PATH"' >> ~/.bashrc
     export PATH="$HOME/.cargo/bin:$PATH"
-    warning "You may need to restart your shell or run 'source ~/.bashrc' for PATH changes to take effect"
+    shell_integration_hint "yazi"
 fi
 
 # Verify installation
@@ -2,7 +2,9 @@
 
 # Common Dependencies Installation Script for Debian Linux
 # Installs shared dependencies once to avoid redundant installations
-# Usage: ./install-common-deps.sh
+# Usage: ./install-common-deps.sh [--skip-system-packages]
+#   --skip-system-packages  Only set up toolchains; system packages were
+#                           already installed by the orchestrator
 
 set -e  # Exit on any error
 
@@ -20,6 +22,7 @@ fi
 
 
 # Configuration
+SKIP_SYSTEM_PACKAGES=false
 RUST_MIN_VERSION="1.88.0"  # Highest requirement (ripgrep)
 GO_VERSION="1.23.4"        # Latest stable
 
@@ -48,6 +51,19 @@ version_compare() {
     return 0
 }
 
+# Parse command line arguments
+while [[ $# -gt 0 ]]; do
+    case $1 in
+        --skip-system-packages)
+            SKIP_SYSTEM_PACKAGES=true
+            shift
+            ;;
+        *)
+            error "Unknown option: $1"
+            ;;
+    esac
+done
+
 # Check if running as root
 if [[ $EUID -eq 0 ]]; then
    error "This script should not be run as root for security reasons"
@@ -55,65 +71,105 @@ fi
 
 log "Installing common dependencies for all build tools..."
 
-# Update package list once
-log "Updating package list..."
-sudo apt update || error "Failed to update package list"
-
-# Install common build tools
-log "Installing common build tools..."
-sudo apt install -y \
-    build-essential \
-    git \
-    curl \
-    wget \
-    make \
-    cmake \
-    pkg-config \
-    autoconf \
-    automake \
-    libtool \
-    || error "Failed to install common build tools"
-
-# Install additional tools used by multiple scripts
-log "Installing additional common tools..."
-sudo apt install -y \
-    nasm \
-    yasm \
-    bison \
-    flex \
-    python3-dev \
-    || warning "Some additional tools may not be available"
-
-success "Common build tools installed successfully"
-
-# Install/Update Rust to satisfy highest requirement
-log "Installing/updating Rust to version >= $RUST_MIN_VERSION..."
-if command -v rustc &> /dev/null; then
-    current_version=$(rustc --version | cut -d' ' -f2)
-    log "Found Rust version: $current_version"
-    
-    if version_compare $current_version $RUST_MIN_VERSION; then
+if [[ "$SKIP_SYSTEM_PACKAGES" == true ]]; then
+    log "Skipping system packages (installed by the orchestrator)"
+else
+    # Update package list once
+    log "Updating package list..."
+    sudo apt update || error "Failed to update package list"
+
+    # Install common build tools
+    log "Installing common build tools..."
+    sudo apt install -y \
+        build-essential \
+        git \
+        curl \
+        wget \
+        make \
+        cmake \
+        pkg-config \
+        autoconf \
+        automake \
+        libtool \
+        || error "Failed to install common build tools"
+
+    # Install additional tools used by multiple scripts
+    log "Installing additional common tools..."
+    sudo apt install -y \
+        nasm \
+        yasm \
+        bison \
+        flex \
+        python3-dev \
+        || warning "Some additional tools may not be available"
+
+    success "Common build tools installed successfully"
+fi
+
+# Install Rust from the standalone installers in an offline mirror; rustup
+# needs network access
+install_rust_from_mirror() {
+    local machine rust_archive std_archive work_dir
+    machine=$(uname -m)
+
+    if command -v rustc &> /dev/null && version_compare "$(rustc --version | cut -d' ' -f2)" "$RUST_MIN_VERSION"; then
         log "Rust version is sufficient (>= $RUST_MIN_VERSION)"
-    else
-        warning "Rust version $current_version is below required $RUST_MIN_VERSION"
-        log "Updating Rust..."
-        rustup update || error "Failed to update Rust"
-        success "Rust updated successfully"
+        return 0
+    fi
+
+    rust_archive=$(mirror_toolchain_archive "rust-[0-9]*-${machine}-unknown-linux-gnu.tar.gz") \
+        || error "Rust toolchain not found in mirror $GEARBOX_MIRROR"
+
+    work_dir=$(mktemp -d)
+    tar -xzf "$rust_archive" -C "$work_dir" || error "Failed to extract Rust"
+    "$work_dir"/rust-*/install.sh --prefix="$HOME/.cargo" --disable-ldconfig > /dev/null \
+        || error "Failed to install Rust"
+
+    # MUSL standard library for static builds (ripgrep)
+    if std_archive=$(mirror_toolchain_archive "rust-std-*-${machine}-unknown-linux-musl.tar.gz"); then
+        tar -xzf "$std_archive" -C "$work_dir" || error "Failed to extract MUSL standard library"
+        "$work_dir"/rust-std-*/install.sh --prefix="$HOME/.cargo" --disable-ldconfig > /dev/null \
+            || warning "Failed to add MUSL target"
     fi
+    rm -rf "$work_dir"
+
+    export PATH="$HOME/.cargo/bin:$PATH"
+    success "Rust installed from mirror"
+}
+
+if mirror_enabled; then
+    log "Installing Rust >= $RUST_MIN_VERSION from offline mirror..."
+    install_rust_from_mirror
 else
-    log "Rust not found, installing..."
-    curl --proto '=https' --tlsv1.2 -sSf https://sh.rustup.rs | sh -s -- -y || error "Failed to install Rust"
-    source ~/.cargo/env || error "Failed to source Rust environment"
-    success "Rust installed successfully"
-fi
+    # Install/Update Rust to satisfy highest requirement
+    log "Installing/updating Rust to version >= $RUST_MIN_VERSION..."
+    if command -v rustc &> /dev/null; then
+        current_version=$(rustc --version | cut -d' ' -f2)
+        log "Found Rust version: $current_version"
+    
+        if version_compare $current_version $RUST_MIN_VERSION; then
+            log "Rust version is sufficient (>= $RUST_MIN_VERSION)"
+        else
+            warning "Rust version $current_version is below required $RUST_MIN_VERSION"
+            log "Updating Rust..."
+            rustup update || error "Failed to update Rust"
+            success "Rust updated successfully"
+        fi
+    else
+        log "Rust not found, installing..."
+        curl --proto '=https' --tlsv1.2 -sSf https://sh.rustup.rs | sh -s -- -y || error "Failed to install Rust"
+        source ~/.cargo/env || error "Failed to source Rust environment"
+        success "Rust installed successfully"
+    fi
 
-# Ensure Rust is up to date
-log "Ensuring Rust toolchain is current..."
-rustup update || warning "Failed to update Rust toolchain"
+    # Ensure Rust is up to date
+    log "Ensuring Rust toolchain is current..."
+    rustup update || warning "Failed to update Rust toolchain"
 
-# Add MUSL target for static builds (ripgrep)
-log "Adding MUSL target for static builds..."
-rustup target add x86_64-unknown-linux-musl || warning "Failed to add MUSL target"
+    # Add MUSL target for static builds (ripgrep)
+    log "Adding MUSL target for static builds..."
+    rustup target add x86_64-unknown-linux-musl || warning "Failed to add MUSL target"
+fi
 
 # Install/Update Go
 log "Installing/updating Go to version $GO_VERSION..."
@@ -148,23 +204,12 @@ fi
 # Setup environment once
 log "Setting up environment variables..."
 
-# Add Rust to PATH if not already there
+# Add Rust and Go to PATH for the builds; new shells get them from
+# gearbox shell-init
 if [[ ":$PATH:" != *":$HOME/.cargo/bin:"* ]]; then
-    if ! grep -q 'export PATH="$HOME/.cargo/bin:$PATH"' ~/.bashrc 2>/dev/null; then
-        echo '# Rust environment' >> ~/.bashrc
-        echo 'export PATH="$HOME/.cargo/bin:$PATH"' >> ~/.bashrc
-        log "Added Rust to PATH in ~/.bashrc"
-    fi
     export PATH="$HOME/.cargo/bin:$PATH"
 fi
-
-# Add Go to PATH if not already there
 if [[ ":$PATH:" != *":/usr/local/go/bin:"* ]]; then
-    if ! grep -q 'export PATH="/usr/local/go/bin:$PATH"' ~/.bashrc 2>/dev/null; then
-        echo '# Go environment' >> ~/.bashrc
-        echo 'export PATH="/usr/local/go/bin:$PATH"' >> ~/.bashrc
-        log "Added Go to PATH in ~/.bashrc"
-    fi
     export PATH="/usr/local/go/bin:$PATH"
 fi
 
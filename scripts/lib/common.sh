@@ -72,6 +72,9 @@ load_module "core/utilities.sh" || exit 1
 # Load tracking module
 load_module "tracking.sh" || exit 1
 
+# Load offline mirror support
+load_module "mirror.sh" || exit 1
+
 # Load configuration management
 if [[ -f "$GEARBOX_LIB_DIR/config.sh" ]]; then
     source "$GEARBOX_LIB_DIR/config.sh"
@@ -19,12 +19,15 @@ declare -A DEFAULT_CONFIG=(
     ["MAX_PARALLEL_JOBS"]="auto"
     ["CACHE_ENABLED"]="true"
     ["CACHE_MAX_AGE_DAYS"]="7"
+    ["CACHE_DIR"]="~/tools/cache"
     ["AUTO_UPDATE_REPOS"]="true"
     ["INSTALL_MISSING_DEPS"]="true"
     ["SKIP_TESTS_BY_DEFAULT"]="false"
     ["VERBOSE_OUTPUT"]="false"
     ["SHELL_INTEGRATION"]="true"
     ["BACKUP_BEFORE_INSTALL"]="true"
+    ["TRASH_MAX_AGE_DAYS"]="30"
+    ["TRASH_MAX_SIZE_MB"]="2048"
 )
 
 # Load configuration from file
@@ -151,6 +154,7 @@ apply_config() {
     export GEARBOX_DEFAULT_BUILD_TYPE="$(get_config DEFAULT_BUILD_TYPE)"
     export GEARBOX_MAX_PARALLEL_JOBS="$(get_config MAX_PARALLEL_JOBS)"
     export GEARBOX_CACHE_ENABLED="$(get_config CACHE_ENABLED)"
+    export GEARBOX_CACHE_DIR="$(get_config CACHE_DIR)"
     export GEARBOX_AUTO_UPDATE_REPOS="$(get_config AUTO_UPDATE_REPOS)"
     export GEARBOX_INSTALL_MISSING_DEPS="$(get_config INSTALL_MISSING_DEPS)"
     export GEARBOX_SKIP_TESTS_BY_DEFAULT="$(get_config SKIP_TESTS_BY_DEFAULT)"
@@ -17,6 +17,9 @@ readonly GEARBOX_UTILITIES_LOADED=1
 
 # @function get_optimal_jobs
 # @brief Calculate optimal number of parallel jobs based on system resources
+# @description
+#   When the orchestrator runs several builds at once it assigns each build a
+#   share of the cores in GEARBOX_BUILD_JOBS, which takes precedence.
 # @return Number of jobs to use
 get_optimal_jobs() {
     local cpu_cores
@@ -24,6 +27,13 @@ get_optimal_jobs() {
     local max_jobs
     local configured_jobs
     
+    # Use the jobs assigned by the orchestrator's scheduler
+    if [[ "${GEARBOX_BUILD_JOBS:-}" =~ ^[1-9][0-9]*$ ]]; then
+        debug "Optimal parallel jobs: $GEARBOX_BUILD_JOBS (assigned by orchestrator)"
+        echo "$GEARBOX_BUILD_JOBS"
+        return
+    fi
+    
     # Get CPU core count
     cpu_cores=$(nproc 2>/dev/null || echo 1)
     
@@ -155,6 +165,26 @@ restore_file_backup() {
     success "Backup restored successfully"
 }
 
+# @function shell_integration_hint
+# @brief Explain how to load a tool's shell integration through gearbox shell-init
+# @description
+#   Install scripts no longer edit shell rc files. gearbox shell-init sets up
+#   every installed tool, so removing a tool leaves nothing behind.
+# @param $1 Tool name
+shell_integration_hint() {
+    local tool="$1"
+
+    log "Shell integration for $tool is loaded by gearbox shell-init"
+    if grep -qs "gearbox shell-init" ~/.bashrc ~/.zshrc "${XDG_CONFIG_HOME:-$HOME/.config}/fish/config.fish"; then
+        log "✓ gearbox shell-init is already set up, start a new shell to use $tool"
+        return 0
+    fi
+    log "Add one line to your shell's rc file:"
+    log '  bash: eval "$(gearbox shell-init bash)"   # ~/.bashrc'
+    log '  zsh:  eval "$(gearbox shell-init zsh)"    # ~/.zshrc'
+    log '  fish: gearbox shell-init fish | source    # ~/.config/fish/config.fish'
+}
+
 # @function clone_or_update_repo
 # @brief Clone repository or update if it already exists
 # @param $1 Repository URL
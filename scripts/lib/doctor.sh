@@ -109,7 +109,13 @@ check_system_requirements() {
     fi
     
     # Check internet connectivity
-    if command -v curl &>/dev/null; then
+    if [[ -n "${GEARBOX_MIRROR:-}" ]]; then
+        if [[ -f "$GEARBOX_MIRROR/mirror.json" ]]; then
+            add_check_result "PASS" "system" "Internet" "Offline mode: using mirror $GEARBOX_MIRROR"
+        else
+            add_check_result "FAIL" "system" "Internet" "Offline mirror not found: $GEARBOX_MIRROR"
+        fi
+    elif command -v curl &>/dev/null; then
         if curl -s --connect-timeout 5 https://github.com >/dev/null; then
             add_check_result "PASS" "system" "Internet" "Internet connectivity working"
         else
@@ -316,7 +322,8 @@ check_configuration() {
 check_cache() {
     log "Checking build cache..."
     
-    local cache_dir="$HOME/tools/cache"
+    local cache_dir="${GEARBOX_CACHE_DIR:-$HOME/tools/cache}"
+    cache_dir="${cache_dir/#\~/$HOME}"
     if [[ -d "$cache_dir" ]]; then
         local cache_size
         cache_size=$(du -sh "$cache_dir" 2>/dev/null | cut -f1)
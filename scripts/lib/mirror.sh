@@ -0,0 +1,105 @@
+#!/bin/bash
+#
+# @file lib/mirror.sh
+# @brief Offline mirror support for installation scripts
+# @description
+#   When GEARBOX_MIRROR points at a directory created by 'gearbox mirror',
+#   curl and wget serve mirrored URLs from it so that release assets, font
+#   archives and toolchains install without network access. Repository URLs
+#   are rewritten to the bare mirrors through git configuration set by the
+#   orchestrator.
+#
+
+# Prevent multiple inclusion
+[[ -n "${GEARBOX_MIRROR_LOADED:-}" ]] && return 0
+readonly GEARBOX_MIRROR_LOADED=1
+
+# @function mirror_enabled
+# @brief Check whether installations use an offline mirror
+# @return 0 if a mirror is configured, 1 otherwise
+mirror_enabled() {
+    [[ -n "${GEARBOX_MIRROR:-}" && -f "$GEARBOX_MIRROR/mirror.json" ]]
+}
+
+# @function mirror_asset_path
+# @brief Print the local path of a mirrored URL
+# @param $1 URL
+# @return 0 if the URL is mirrored, 1 otherwise
+mirror_asset_path() {
+    local url="$1"
+    mirror_enabled || return 1
+
+    local path="${url#*://}"
+    path="${path%%\?*}"
+    local file="$GEARBOX_MIRROR/assets/$path"
+
+    [[ -f "$file" ]] || return 1
+    echo "$file"
+}
+
+# @function mirror_toolchain_archive
+# @brief Print the path of a mirrored toolchain archive
+# @param $1 Archive name pattern (e.g. "rust-*-x86_64-unknown-linux-gnu.tar.gz")
+# @return 0 if found, 1 otherwise
+mirror_toolchain_archive() {
+    local pattern="$1"
+    mirror_enabled || return 1
+
+    local archive
+    for archive in "$GEARBOX_MIRROR"/toolchains/$pattern; do
+        if [[ -f "$archive" ]]; then
+            echo "$archive"
+            return 0
+        fi
+    done
+    return 1
+}
+
+if mirror_enabled; then
+    # @function curl
+    # @brief Serve mirrored URLs from the offline mirror
+    curl() {
+        local args=() arg file
+        for arg in "$@"; do
+            if [[ "$arg" =~ ^https?:// ]] && file=$(mirror_asset_path "$arg"); then
+                arg="file://$file"
+            fi
+            args+=("$arg")
+        done
+        command curl "${args[@]}"
+    }
+
+    # @function wget
+    # @brief Serve mirrored URLs from the offline mirror
+    wget() {
+        local args=("$@") output="" url="" file i
+        for ((i = 0; i < ${#args[@]}; i++)); do
+            case "${args[i]}" in
+                -O|-[!-]*O)
+                    output="${args[i+1]:-}"
+                    ((i++)) || true
+                    ;;
+                -O*|-[!-]*O*)
+                    output="${args[i]#*O}"
+                    ;;
+                --output-document=*)
+                    output="${args[i]#*=}"
+                    ;;
+                http://*|https://*)
+                    url="${args[i]}"
+                    ;;
+            esac
+        done
+
+        if [[ -n "$url" ]] && file=$(mirror_asset_path "$url"); then
+            [[ -z "$output" ]] && output="$(basename "${url%%\?*}")"
+            if [[ "$output" == "-" ]]; then
+                cat "$file"
+            else
+                cp "$file" "$output"
+            fi
+            return
+        fi
+        command wget "$@"
+    }
+fi
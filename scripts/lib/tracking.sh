@@ -26,6 +26,7 @@ track_installation() {
     # Parse optional parameters
     local binary_paths=""
     local build_dir=""
+    local build_type=""
     local source_repo=""
     local dependencies=""
     local installed_by_bundle=""
@@ -43,6 +44,10 @@ track_installation() {
                 build_dir="$2"
                 shift 2
                 ;;
+            --build-type)
+                build_type="$2"
+                shift 2
+                ;;
             --source-repo)
                 source_repo="$2"
                 shift 2
@@ -95,6 +100,10 @@ track_installation() {
         tracking_args+=(--build-dir "$build_dir")
     fi
     
+    if [[ -n "$build_type" ]]; then
+        tracking_args+=(--build-type "$build_type")
+    fi
+    
     if [[ -n "$source_repo" ]]; then
         tracking_args+=(--source-repo "$source_repo")
     fi